@@ -0,0 +1,223 @@
+package ast
+
+// Node is implemented by all nodes of the syntax tree
+type Node interface {
+	// String returns the JSONPath representation of the node
+	String() string
+}
+
+// Selector is implemented by the nodes that select the children of a value within a segment
+type Selector interface {
+	Node
+	selectorNode()
+}
+
+// Expression is implemented by the nodes of a filter or script expression
+type Expression interface {
+	Node
+	expressionNode()
+}
+
+// Query represents a JSONPath selector, or a query embedded in an expression,
+// as the root or current identifier followed by a list of segments.
+type Query struct {
+	// Relative is true if the query starts with the current identifier @ rather than the root identifier $
+	Relative bool
+	// Segments the segments of the query in the order they are applied
+	Segments []*Segment
+}
+
+// String returns the query in bracket notation, for example $['store']['book'][0]
+func (query *Query) String() string {
+	return defaultPrinter.Print(query)
+}
+
+// Segment represents a step of a query that applies its selectors to the current values,
+// or to the current values and all of their descendants.
+type Segment struct {
+	// Descendant is true if the selectors are applied to all descendants, for example $..author
+	Descendant bool
+	// Selectors the selectors of the segment, a descendant segment without selectors selects all descendants
+	Selectors []Selector
+}
+
+// String returns the segment in bracket notation, for example ['author'] or ..[0,1]
+func (segment *Segment) String() string {
+	return defaultPrinter.Print(segment)
+}
+
+// NameSelector selects the value of an object member, or struct field, by name
+type NameSelector struct {
+	// Name the name of the member
+	Name string
+}
+
+func (selector *NameSelector) selectorNode() {}
+
+// String returns the quoted name, for example 'author'
+func (selector *NameSelector) String() string {
+	return defaultPrinter.Print(selector)
+}
+
+// IndexSelector selects an array element, negative indices are relative to the end of the array
+type IndexSelector struct {
+	// Index the index of the element
+	Index int64
+}
+
+func (selector *IndexSelector) selectorNode() {}
+
+// String returns the index, for example -1
+func (selector *IndexSelector) String() string {
+	return defaultPrinter.Print(selector)
+}
+
+// WildcardSelector selects all children of a value
+type WildcardSelector struct{}
+
+func (selector *WildcardSelector) selectorNode() {}
+
+// String returns *
+func (selector *WildcardSelector) String() string {
+	return defaultPrinter.Print(selector)
+}
+
+// SliceSelector selects a range of array elements
+type SliceSelector struct {
+	// Start the start of the range, nil if not set
+	Start Expression
+	// End the exclusive end of the range, nil if not set
+	End Expression
+	// Step the step between selected elements, nil if not set
+	Step Expression
+}
+
+func (selector *SliceSelector) selectorNode() {}
+
+// String returns the slice, for example 1:5:2
+func (selector *SliceSelector) String() string {
+	return defaultPrinter.Print(selector)
+}
+
+// FilterSelector selects the children of a value for which the expression is true
+type FilterSelector struct {
+	// Expression the filter expression
+	Expression Expression
+}
+
+func (selector *FilterSelector) selectorNode() {}
+
+// String returns the filter, for example ?(@.price < 10)
+func (selector *FilterSelector) String() string {
+	return defaultPrinter.Print(selector)
+}
+
+// ScriptSelector selects the child of a value using the name or index the expression evaluates to
+type ScriptSelector struct {
+	// Expression the script expression
+	Expression Expression
+}
+
+func (selector *ScriptSelector) selectorNode() {}
+
+// String returns the script, for example (@.length-1)
+func (selector *ScriptSelector) String() string {
+	return defaultPrinter.Print(selector)
+}
+
+// LengthSelector selects the length of an array, object, or string
+type LengthSelector struct{}
+
+func (selector *LengthSelector) selectorNode() {}
+
+// String returns .length
+func (selector *LengthSelector) String() string {
+	return defaultPrinter.Print(selector)
+}
+
+// Literal represents a literal value, such as a string, number, boolean, null, array, or object
+type Literal struct {
+	// Value the literal value, nil for null
+	Value interface{}
+}
+
+func (expression *Literal) expressionNode() {}
+
+// String returns the literal, strings are single quoted and other values are formatted as JSON
+func (expression *Literal) String() string {
+	return defaultPrinter.Print(expression)
+}
+
+// QueryExpression represents a query used within an expression, in a logical context it tests if the query selects a value
+type QueryExpression struct {
+	// Query the embedded query
+	Query *Query
+}
+
+func (expression *QueryExpression) expressionNode() {}
+
+// String returns the embedded query
+func (expression *QueryExpression) String() string {
+	return defaultPrinter.Print(expression)
+}
+
+// UnaryExpression represents an operator applied to a single operand, for example !@.isbn
+type UnaryExpression struct {
+	// Operator the operator, for example !
+	Operator string
+	// Operand the operand
+	Operand Expression
+}
+
+func (expression *UnaryExpression) expressionNode() {}
+
+// String returns the expression, for example !@['isbn']
+func (expression *UnaryExpression) String() string {
+	return defaultPrinter.Print(expression)
+}
+
+// BinaryExpression represents an operator applied to two operands, such as a comparison, logical, or arithmetic operator
+type BinaryExpression struct {
+	// Operator the operator, for example == or &&
+	Operator string
+	// Left the left operand
+	Left Expression
+	// Right the right operand
+	Right Expression
+}
+
+func (expression *BinaryExpression) expressionNode() {}
+
+// String returns the expression, nested binary expressions are wrapped in parentheses
+func (expression *BinaryExpression) String() string {
+	return defaultPrinter.Print(expression)
+}
+
+// FunctionCall represents a call to a function extension, for example length(@.title)
+type FunctionCall struct {
+	// Name the name of the function
+	Name string
+	// Arguments the arguments passed to the function
+	Arguments []Expression
+}
+
+func (expression *FunctionCall) expressionNode() {}
+
+// String returns the function call
+func (expression *FunctionCall) String() string {
+	return defaultPrinter.Print(expression)
+}
+
+// RawExpression represents an expression that could not be described as a tree,
+// such as one compiled by a custom script engine.
+type RawExpression struct {
+	// Source the expression as written in the selector
+	Source string
+}
+
+func (expression *RawExpression) expressionNode() {}
+
+// String returns the expression as written in the selector
+func (expression *RawExpression) String() string {
+	return defaultPrinter.Print(expression)
+}
@@ -0,0 +1,105 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_String(t *testing.T) {
+
+	tests := []struct {
+		input    Node
+		expected string
+	}{
+		{
+			input:    &Query{},
+			expected: "$",
+		},
+		{
+			input: &Query{
+				Relative: true,
+				Segments: []*Segment{
+					{Selectors: []Selector{&NameSelector{Name: "a"}}},
+					{Selectors: []Selector{&LengthSelector{}}},
+				},
+			},
+			expected: "@['a'].length",
+		},
+		{
+			input: &Query{
+				Segments: []*Segment{
+					{Descendant: true, Selectors: []Selector{&NameSelector{Name: "it's"}, &IndexSelector{Index: -1}}},
+					{Descendant: true},
+				},
+			},
+			expected: "$..['it\\'s',-1]..",
+		},
+		{
+			input:    &WildcardSelector{},
+			expected: "*",
+		},
+		{
+			input:    &SliceSelector{},
+			expected: ":",
+		},
+		{
+			input: &SliceSelector{
+				Start: &Literal{Value: int64(1)},
+				End: &BinaryExpression{
+					Operator: "-",
+					Left:     &QueryExpression{Query: &Query{Relative: true, Segments: []*Segment{{Selectors: []Selector{&LengthSelector{}}}}}},
+					Right:    &Literal{Value: int64(1)},
+				},
+				Step: &Literal{Value: int64(2)},
+			},
+			expected: "1:(@.length - 1):2",
+		},
+		{
+			input: &FilterSelector{
+				Expression: &BinaryExpression{
+					Operator: "&&",
+					Left:     &UnaryExpression{Operator: "!", Operand: &QueryExpression{Query: &Query{Relative: true}}},
+					Right: &BinaryExpression{
+						Operator: "==",
+						Left:     &FunctionCall{Name: "length", Arguments: []Expression{&Literal{Value: "ab"}}},
+						Right:    &Literal{Value: json.Number("2")},
+					},
+				},
+			},
+			expected: "?(!@ && (length('ab') == 2))",
+		},
+		{
+			input:    &ScriptSelector{Expression: &RawExpression{Source: "@.length-1"}},
+			expected: "(@.length-1)",
+		},
+		{
+			input:    &Literal{Value: nil},
+			expected: "null",
+		},
+		{
+			input:    &Literal{Value: true},
+			expected: "true",
+		},
+		{
+			input:    &Literal{Value: 1.5},
+			expected: "1.5",
+		},
+		{
+			input:    &Literal{Value: []interface{}{1, "a"}},
+			expected: `[1,"a"]`,
+		},
+		{
+			input:    &Literal{Value: map[string]interface{}{"a": 1}},
+			expected: `{"a":1}`,
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, test.input.String())
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultPrinter the printer used by the String functions of the nodes
+var defaultPrinter = &Printer{}
+
+// Printer formats the nodes of a syntax tree in a canonical form, using bracket notation for
+// segments, single spaces around operators, and parentheses around nested binary expressions.
+type Printer struct {
+	// QuoteName returns the quoted representation of the name of a name selector,
+	// if nil names are quoted using Quote.
+	QuoteName func(name string) string
+	// QuoteString returns the quoted representation of a string literal,
+	// if nil strings are quoted using Quote.
+	QuoteString func(value string) string
+}
+
+// Print returns the canonical representation of the node
+func (printer *Printer) Print(node Node) string {
+	builder := &strings.Builder{}
+	printer.print(builder, node)
+	return builder.String()
+}
+
+func (printer *Printer) print(builder *strings.Builder, node Node) {
+	switch node := node.(type) {
+	case nil:
+		return
+	case *Query:
+		if node.Relative {
+			builder.WriteString("@")
+		} else {
+			builder.WriteString("$")
+		}
+		for _, segment := range node.Segments {
+			printer.print(builder, segment)
+		}
+	case *Segment:
+		if node.Descendant {
+			builder.WriteString("..")
+		}
+		if len(node.Selectors) == 0 {
+			return
+		}
+		if len(node.Selectors) == 1 {
+			if _, ok := node.Selectors[0].(*LengthSelector); ok {
+				printer.print(builder, node.Selectors[0])
+				return
+			}
+		}
+		builder.WriteString("[")
+		for idx, selector := range node.Selectors {
+			if idx > 0 {
+				builder.WriteString(",")
+			}
+			printer.print(builder, selector)
+		}
+		builder.WriteString("]")
+	case *NameSelector:
+		builder.WriteString(printer.quoteName(node.Name))
+	case *IndexSelector:
+		builder.WriteString(fmt.Sprint(node.Index))
+	case *WildcardSelector:
+		builder.WriteString("*")
+	case *SliceSelector:
+		printer.printSliceArgument(builder, node.Start)
+		builder.WriteString(":")
+		printer.printSliceArgument(builder, node.End)
+		if node.Step != nil {
+			builder.WriteString(":")
+			printer.printSliceArgument(builder, node.Step)
+		}
+	case *FilterSelector:
+		builder.WriteString("?(")
+		printer.print(builder, node.Expression)
+		builder.WriteString(")")
+	case *ScriptSelector:
+		builder.WriteString("(")
+		printer.print(builder, node.Expression)
+		builder.WriteString(")")
+	case *LengthSelector:
+		builder.WriteString(".length")
+	case *Literal:
+		builder.WriteString(printer.literal(node.Value))
+	case *QueryExpression:
+		printer.print(builder, node.Query)
+	case *UnaryExpression:
+		builder.WriteString(node.Operator)
+		printer.printOperand(builder, node.Operand)
+	case *BinaryExpression:
+		printer.printOperand(builder, node.Left)
+		builder.WriteString(" ")
+		builder.WriteString(node.Operator)
+		builder.WriteString(" ")
+		printer.printOperand(builder, node.Right)
+	case *FunctionCall:
+		builder.WriteString(node.Name)
+		builder.WriteString("(")
+		for idx, argument := range node.Arguments {
+			if idx > 0 {
+				builder.WriteString(",")
+			}
+			printer.print(builder, argument)
+		}
+		builder.WriteString(")")
+	case *RawExpression:
+		builder.WriteString(node.Source)
+	}
+}
+
+// printOperand prints the operand of an operator, wrapping binary expressions in parentheses
+func (printer *Printer) printOperand(builder *strings.Builder, expression Expression) {
+	if _, ok := expression.(*BinaryExpression); ok {
+		builder.WriteString("(")
+		printer.print(builder, expression)
+		builder.WriteString(")")
+		return
+	}
+	printer.print(builder, expression)
+}
+
+// printSliceArgument prints the argument of a slice, wrapping expressions other than literals in parentheses
+func (printer *Printer) printSliceArgument(builder *strings.Builder, expression Expression) {
+	switch expression.(type) {
+	case nil, *Literal:
+		printer.print(builder, expression)
+	default:
+		builder.WriteString("(")
+		printer.print(builder, expression)
+		builder.WriteString(")")
+	}
+}
+
+func (printer *Printer) quoteName(name string) string {
+	if printer.QuoteName != nil {
+		return printer.QuoteName(name)
+	}
+	return Quote(name)
+}
+
+// literal returns the literal value, strings are quoted and other values are formatted as JSON
+func (printer *Printer) literal(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		if printer.QuoteString != nil {
+			return printer.QuoteString(value)
+		}
+		return Quote(value)
+	case nil:
+		return "null"
+	case json.Number:
+		return value.String()
+	case float64:
+		// numbers are not formatted using exponents as a + or - may be parsed as an operator
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case []interface{}, map[string]interface{}:
+		bytes, _ := json.Marshal(value)
+		return string(bytes)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Quote returns the string wrapped in single quotes, escaping the characters
+// that must be escaped in a string literal as defined by RFC 9535.
+func Quote(value string) string {
+	builder := strings.Builder{}
+	builder.WriteString("'")
+	for _, rne := range value {
+		switch rne {
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\'':
+			builder.WriteString(`\'`)
+		case '\b':
+			builder.WriteString(`\b`)
+		case '\f':
+			builder.WriteString(`\f`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			if rne < 0x20 {
+				builder.WriteString(fmt.Sprintf(`\u%04x`, rne))
+				continue
+			}
+			builder.WriteRune(rne)
+		}
+	}
+	builder.WriteString("'")
+	return builder.String()
+}
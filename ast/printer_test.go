@@ -0,0 +1,84 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Printer_Print(t *testing.T) {
+
+	query := &Query{
+		Segments: []*Segment{
+			{Selectors: []Selector{&NameSelector{Name: "it's"}}},
+			{Selectors: []Selector{&FilterSelector{Expression: &BinaryExpression{
+				Operator: "==",
+				Left:     &QueryExpression{Query: &Query{Relative: true, Segments: []*Segment{{Selectors: []Selector{&NameSelector{Name: "a"}}}}}},
+				Right:    &Literal{Value: "b's"},
+			}}}},
+		},
+	}
+
+	tests := []struct {
+		printer  *Printer
+		expected string
+	}{
+		{
+			printer:  &Printer{},
+			expected: `$['it\'s'][?(@['a'] == 'b\'s')]`,
+		},
+		{
+			printer: &Printer{
+				QuoteName: func(name string) string {
+					return `"` + name + `"`
+				},
+				QuoteString: func(value string) string {
+					return strings.ToUpper(value)
+				},
+			},
+			expected: `$["it's"][?(@["a"] == B'S)]`,
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, test.printer.Print(query))
+		})
+	}
+
+	t.Run("numbers", func(t *testing.T) {
+		printer := &Printer{}
+		assert.Equal(t, "1000000000000000000000", printer.Print(&Literal{Value: 1e21}))
+		assert.Equal(t, "0.0000001", printer.Print(&Literal{Value: 1e-7}))
+		assert.Equal(t, "-1.5", printer.Print(&Literal{Value: -1.5}))
+		assert.Equal(t, "2", printer.Print(&Literal{Value: int64(2)}))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		assert.Equal(t, "", (&Printer{}).Print(nil))
+	})
+}
+
+func Test_Quote(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "", expected: "''"},
+		{input: "abc", expected: "'abc'"},
+		{input: `it's "quoted"`, expected: `'it\'s "quoted"'`},
+		{input: `a\b`, expected: `'a\\b'`},
+		{input: "\b\f\n\r\t", expected: `'\b\f\n\r\t'`},
+		{input: "\u0001", expected: `'\u0001'`},
+		{input: "日本", expected: "'日本'"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, Quote(test.input))
+		})
+	}
+}
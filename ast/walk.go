@@ -0,0 +1,70 @@
+package ast
+
+// Visitor is used to walk the syntax tree, the Visit function is called for each node encountered by Walk.
+// If the visitor returned is not nil, Walk visits each of the children of the node with it, followed by a call of Visit(nil).
+type Visitor interface {
+	Visit(node Node) Visitor
+}
+
+// Walk traverses the syntax tree in depth-first order, starting by calling v.Visit(node)
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch node := node.(type) {
+	case *Query:
+		for _, segment := range node.Segments {
+			Walk(v, segment)
+		}
+	case *Segment:
+		for _, selector := range node.Selectors {
+			Walk(v, selector)
+		}
+	case *SliceSelector:
+		walkExpression(v, node.Start)
+		walkExpression(v, node.End)
+		walkExpression(v, node.Step)
+	case *FilterSelector:
+		walkExpression(v, node.Expression)
+	case *ScriptSelector:
+		walkExpression(v, node.Expression)
+	case *QueryExpression:
+		if node.Query != nil {
+			Walk(v, node.Query)
+		}
+	case *UnaryExpression:
+		walkExpression(v, node.Operand)
+	case *BinaryExpression:
+		walkExpression(v, node.Left)
+		walkExpression(v, node.Right)
+	case *FunctionCall:
+		for _, argument := range node.Arguments {
+			walkExpression(v, argument)
+		}
+	}
+
+	v.Visit(nil)
+}
+
+// walkExpression walks the expression if it is set
+func walkExpression(v Visitor, expression Expression) {
+	if expression != nil {
+		Walk(v, expression)
+	}
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses the syntax tree in depth-first order, starting by calling f(node).
+// If f returns true, Inspect is called for each of the children of the node, followed by a call of f(nil).
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
@@ -0,0 +1,101 @@
+package ast
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingVisitor struct {
+	visited []string
+}
+
+func (visitor *recordingVisitor) Visit(node Node) Visitor {
+	if node == nil {
+		visitor.visited = append(visitor.visited, "end")
+		return nil
+	}
+	visitor.visited = append(visitor.visited, fmt.Sprintf("%T", node))
+	return visitor
+}
+
+func Test_Walk(t *testing.T) {
+	query := &Query{
+		Segments: []*Segment{
+			{Selectors: []Selector{&NameSelector{Name: "a"}}},
+			{Selectors: []Selector{
+				&FilterSelector{Expression: &BinaryExpression{
+					Operator: "<",
+					Left:     &FunctionCall{Name: "length", Arguments: []Expression{&QueryExpression{Query: &Query{Relative: true}}}},
+					Right:    &Literal{Value: 1},
+				}},
+				&SliceSelector{Start: &Literal{Value: 1}},
+				&ScriptSelector{Expression: &UnaryExpression{Operator: "!", Operand: &RawExpression{Source: "x"}}},
+			}},
+		},
+	}
+
+	visitor := &recordingVisitor{}
+	Walk(visitor, query)
+
+	assert.Equal(t, []string{
+		"*ast.Query",
+		"*ast.Segment",
+		"*ast.NameSelector", "end",
+		"end",
+		"*ast.Segment",
+		"*ast.FilterSelector",
+		"*ast.BinaryExpression",
+		"*ast.FunctionCall",
+		"*ast.QueryExpression",
+		"*ast.Query", "end",
+		"end",
+		"end",
+		"*ast.Literal", "end",
+		"end",
+		"end",
+		"*ast.SliceSelector",
+		"*ast.Literal", "end",
+		"end",
+		"*ast.ScriptSelector",
+		"*ast.UnaryExpression",
+		"*ast.RawExpression", "end",
+		"end",
+		"end",
+		"end",
+		"end",
+	}, visitor.visited)
+}
+
+func Test_Inspect(t *testing.T) {
+	query := &Query{
+		Segments: []*Segment{
+			{Selectors: []Selector{&NameSelector{Name: "a"}, &NameSelector{Name: "b"}}},
+			{Selectors: []Selector{&FilterSelector{Expression: &QueryExpression{Query: &Query{
+				Relative: true,
+				Segments: []*Segment{{Selectors: []Selector{&NameSelector{Name: "c"}}}},
+			}}}}},
+		},
+	}
+
+	names := make([]string, 0)
+	Inspect(query, func(node Node) bool {
+		if name, ok := node.(*NameSelector); ok {
+			names = append(names, name.Name)
+		}
+		// do not inspect filter expressions
+		_, isFilter := node.(*FilterSelector)
+		return !isFilter
+	})
+	assert.Equal(t, []string{"a", "b"}, names)
+
+	names = make([]string, 0)
+	Inspect(query, func(node Node) bool {
+		if name, ok := node.(*NameSelector); ok {
+			names = append(names, name.Name)
+		}
+		return true
+	})
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"flag"
@@ -9,6 +10,7 @@ import (
 	"os"
 
 	"github.com/evilmonkeyinc/jsonpath"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -23,19 +25,28 @@ var (
 
 	errSelectorNotSpecified error = fmt.Errorf("selector not specified. expected -selector option or passed as argument")
 	errJSONDataNotSpecified error = fmt.Errorf("json data not specified. expected -jsondata, or -input options or passed as argument")
+	errUnsupportedFormat    error = fmt.Errorf("unsupported format. expected json or yaml")
 )
 
 const (
+	cmdExplain string = "explain"
+	cmdFormat  string = "fmt"
 	cmdHelp    string = "help"
 	cmdVersion string = "version"
 )
 
+const (
+	formatJSON string = "json"
+	formatYAML string = "yaml"
+)
+
 func main() {
 	flagset := flag.NewFlagSet("", flag.ContinueOnError)
 	selectorPtr := flagset.String("selector", "", "a valid JSONPath selector")
 	jsondataPtr := flagset.String("jsondata", "", "the json data to parse)")
 	inputPtr := flagset.String("input", "", "optional path to a file that includes the json data to query")
 	outputPtr := flagset.String("output", "", "optional path to a file that the result ")
+	formatPtr := flagset.String("format", formatJSON, "the format of the data and the result, json or yaml")
 
 	if err := flagset.Parse(os.Args[1:]); err != nil {
 		if !errors.Is(err, flag.ErrHelp) {
@@ -46,6 +57,12 @@ func main() {
 		return
 	}
 
+	format := *formatPtr
+	if format != formatJSON && format != formatYAML {
+		outputError(errUnsupportedFormat)
+		return
+	}
+
 	args := flagset.Args()
 	firstArg := ""
 	if len(args) > 0 {
@@ -59,8 +76,27 @@ func main() {
 	case cmdVersion:
 		fmt.Printf("version %s %s/%s\n", Version, OS, Arch)
 		break
-	default:
-		nextArg := 0
+	case cmdFormat:
+		selector := *selectorPtr
+		if selector == "" && len(args) > 1 {
+			selector = args[1]
+		}
+
+		if selector == "" {
+			outputError(errSelectorNotSpecified)
+			return
+		}
+
+		formatted, err := jsonpath.Format(selector)
+		if err != nil {
+			outputError(err)
+			return
+		}
+
+		fmt.Printf("%s\n", formatted)
+		break
+	case cmdExplain:
+		nextArg := 1
 
 		selector := *selectorPtr
 		if selector == "" && len(args) > nextArg {
@@ -79,46 +115,79 @@ func main() {
 			return
 		}
 
-		jsondata := *jsondataPtr
-		if jsondata == "" && len(args) > nextArg {
-			jsondata = args[nextArg]
+		jsondata, err := getJSONData(*jsondataPtr, *inputPtr, args, nextArg)
+		if err != nil {
+			outputError(err)
+			return
+		}
+
+		root, err := parseData(jsondata, format)
+		if err != nil {
+			outputError(err)
+			return
+		}
+
+		explanation, err := compiled.Explain(root)
+		if explanation != nil {
+			fmt.Print(explanation.String())
+		}
+		if err != nil {
+			outputError(err)
+			return
+		}
+		break
+	default:
+		nextArg := 0
+
+		selector := *selectorPtr
+		if selector == "" && len(args) > nextArg {
+			selector = args[nextArg]
 			nextArg++
 		}
 
-		if jsondata == "" && *inputPtr != "" {
-			bytes, err := loadFileContents(*inputPtr)
-			if err != nil {
-				outputError(err)
-				return
-			}
-			jsondata = string(bytes)
+		if selector == "" {
+			outputError(errSelectorNotSpecified)
+			return
 		}
-		if jsondata == "" {
-			outputError(errJSONDataNotSpecified)
+
+		compiled, err := jsonpath.Compile(selector)
+		if err != nil {
+			outputError(err)
+			return
+		}
+
+		jsondata, err := getJSONData(*jsondataPtr, *inputPtr, args, nextArg)
+		if err != nil {
+			outputError(err)
 			return
 		}
 
-		result, err := compiled.QueryString(jsondata)
+		var result interface{}
+		if format == formatYAML {
+			result, err = compiled.QueryYAML(jsondata)
+		} else {
+			result, err = compiled.QueryString(jsondata)
+		}
 		if err != nil {
 			outputError(err)
 			return
 		}
 
-		jsonOutput, err := json.Marshal(result)
+		output, err := formatResult(result, format)
 		if err != nil {
 			outputError(err)
 			return
 		}
 
 		if *outputPtr != "" {
-			if err := saveFileContents(*outputPtr, jsonOutput); err != nil {
+			if err := saveFileContents(*outputPtr, output); err != nil {
 				outputError(err)
 			}
 			os.Exit(0)
 			return
 		}
 
-		fmt.Printf("%s\n", string(jsonOutput))
+		fmt.Printf("%s\n", string(output))
 		break
 	}
 	os.Exit(0)
@@ -131,11 +200,64 @@ func printHelp(flagset *flag.FlagSet) {
 	fmt.Printf("\nExample:\n\n")
 	fmt.Printf(`  %s '$[*].key' '[{"key":"show this"},{"key":"and this"},{"other":"but not this"}]'`+"\n", Command)
 	fmt.Printf(`  > ["show this","and this"]` + "\n")
+	fmt.Printf(`  %s -format yaml '$.spec.containers[*].image' "$(cat pod.yaml)"`+"\n", Command)
+	fmt.Printf(`  > - nginx:1.25` + "\n")
+	fmt.Printf("\nCommands:\n\n")
+	fmt.Printf("  %s explain '[selector]' '[jsondata]'    print how each token of the selector was applied to the data\n", Command)
+	fmt.Printf("  %s fmt '[selector]'                     print the selector in its canonical form\n", Command)
+	fmt.Printf("  %s help                                 print this help\n", Command)
+	fmt.Printf("  %s version                              print the version\n", Command)
 	fmt.Printf("\nOptions:\n\n")
 	flagset.PrintDefaults()
 	os.Exit(0)
 }
 
+// getJSONData returns the json data from the jsondata option, the argument at the index, or the file of the input option
+func getJSONData(jsondata, input string, args []string, nextArg int) (string, error) {
+	if jsondata == "" && len(args) > nextArg {
+		jsondata = args[nextArg]
+	}
+
+	if jsondata == "" && input != "" {
+		bytes, err := loadFileContents(input)
+		if err != nil {
+			return "", err
+		}
+		jsondata = string(bytes)
+	}
+	if jsondata == "" {
+		return "", errJSONDataNotSpecified
+	}
+	return jsondata, nil
+}
+
+// parseData returns the data decoded from the format
+func parseData(data, format string) (interface{}, error) {
+	var root interface{}
+	if format == formatYAML {
+		if err := yaml.Unmarshal([]byte(data), &root); err != nil {
+			return nil, err
+		}
+		return root, nil
+	}
+	if err := json.Unmarshal([]byte(data), &root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// formatResult returns the result encoded in the format
+func formatResult(result interface{}, format string) ([]byte, error) {
+	if format == formatYAML {
+		output, err := yaml.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.TrimSuffix(output, []byte("\n")), nil
+	}
+	return json.Marshal(result)
+}
+
 func outputError(err error) {
 	fmt.Printf("failed: %s\n", err.Error())
 	os.Exit(1)
@@ -0,0 +1,126 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/evilmonkeyinc/jsonpath/token"
+)
+
+// Decode will apply the JSONPath query against the specified data and store the result in the value pointed to by target.
+//
+// Selectors that can only match a single value, made up of keys and indices, are decoded as that value, and selectors that can
+// match more than one value, such as those with wildcard, range, union, filter, or recursive tokens, are decoded as a list and
+// require a slice or array target. The result is stored directly if it can be assigned to the target, otherwise it is converted
+// using its JSON encoding so structs are decoded using their json tags and values such as time.Time using their JSON format.
+func (query *Selector) Decode(root interface{}, target interface{}) error {
+	if err := validateDecodeTarget(target); err != nil {
+		return err
+	}
+	result, err := query.Query(root)
+	if err != nil {
+		return err
+	}
+	return query.decode(result, target)
+}
+
+// DecodeString will apply the JSONPath query against the specified JSON data and store the result in the value pointed to by target.
+func (query *Selector) DecodeString(jsonData string, target interface{}) error {
+	if err := validateDecodeTarget(target); err != nil {
+		return err
+	}
+	result, err := query.QueryString(jsonData)
+	if err != nil {
+		return err
+	}
+	return query.decode(result, target)
+}
+
+// validateDecodeTarget returns an error if the target is not a non-nil pointer
+func validateDecodeTarget(target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() {
+		return getInvalidDecodeTargetError(reflect.TypeOf(target))
+	}
+	return nil
+}
+
+// decode stores the result of the query in the value pointed to by target, checking that the shape
+// of the result, a single value or a list, matches the target.
+func (query *Selector) decode(result, target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	targetType := targetValue.Elem().Type()
+
+	result, err := query.shapeResult(result, targetType)
+	if err != nil {
+		return err
+	}
+
+	element := targetValue.Elem()
+	if result == nil {
+		element.Set(reflect.Zero(targetType))
+		return nil
+	}
+	if resultValue := reflect.ValueOf(result); resultValue.Type().AssignableTo(targetType) {
+		element.Set(resultValue)
+		return nil
+	}
+
+	bytes, err := json.Marshal(result)
+	if err != nil {
+		return getDecodeResultError(query.selector, targetType, err)
+	}
+	element.Set(reflect.Zero(targetType))
+	if err := json.Unmarshal(bytes, target); err != nil {
+		return getDecodeResultError(query.selector, targetType, err)
+	}
+	return nil
+}
+
+// shapeResult returns the result of the query to store in a value of the target type, the single value
+// selected by a definite selector that always returns a list is returned from its list, and an error is returned if the
+// shape of the result does not match the target.
+func (query *Selector) shapeResult(result interface{}, targetType reflect.Type) (interface{}, error) {
+	if !token.IsDefinite(query.tokens) {
+		if !isListType(targetType) && targetType.Kind() != reflect.Interface {
+			return nil, getDecodeResultListError(query.selector, targetType)
+		}
+		return result, nil
+	}
+
+	if query.alwaysReturnList() {
+		// the result is a list of the selected values
+		values, _ := result.([]interface{})
+		if len(values) == 0 {
+			return nil, getDecodeResultNotFoundError(query.selector)
+		}
+		result = values[0]
+	}
+	if result != nil && isListType(targetType) {
+		if kind := reflect.TypeOf(result).Kind(); kind != reflect.Array && kind != reflect.Slice {
+			return nil, getDecodeResultSingleValueError(query.selector, targetType, kind)
+		}
+	}
+	return result, nil
+}
+
+// isEmptyResult returns true if the result is the empty list returned when a selector that can match
+// more than one value, or any selector that always returns a list, does not match a value
+func (query *Selector) isEmptyResult(result interface{}) bool {
+	if !query.alwaysReturnList() && token.IsDefinite(query.tokens) {
+		return false
+	}
+	values, ok := result.([]interface{})
+	return ok && len(values) == 0
+}
+
+// isListType returns true if the type is a slice or array, other than a byte slice which is decoded from a string
+func isListType(targetType reflect.Type) bool {
+	switch targetType.Kind() {
+	case reflect.Array:
+		return true
+	case reflect.Slice:
+		return targetType.Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
@@ -0,0 +1,324 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	goErr "errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/evilmonkeyinc/jsonpath/errors"
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/stretchr/testify/assert"
+)
+
+type decodeBook struct {
+	Author string  `json:"author"`
+	Title  string  `json:"title"`
+	Price  float64 `json:"price"`
+}
+
+func Test_Selector_Decode(t *testing.T) {
+	var data interface{}
+	json.Unmarshal([]byte(`{
+		"store": {
+			"book": [
+				{"author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95, "published": "1951-06-01T00:00:00Z"},
+				{"author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99, "published": "1952-09-01T00:00:00Z"}
+			],
+			"bicycle": {"color": "red", "price": 19.95}
+		},
+		"empty": null
+	}`), &data)
+
+	type input struct {
+		selector string
+		options  []Option
+		target   func() interface{}
+	}
+
+	type expected struct {
+		value interface{}
+		err   string
+	}
+
+	tests := []struct {
+		input    input
+		expected expected
+	}{
+		{
+			input: input{
+				selector: "$.store.book[0].title",
+				target:   func() interface{} { return new(string) },
+			},
+			expected: expected{
+				value: "Sayings of the Century",
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[1].price",
+				target:   func() interface{} { return new(float64) },
+			},
+			expected: expected{
+				value: 12.99,
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[1].price",
+				target:   func() interface{} { return new(int) },
+			},
+			expected: expected{
+				err: "unable to decode result of selector '$.store.book[1].price' into [int]. json: cannot unmarshal number 12.99 into Go value of type int",
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[0]",
+				target:   func() interface{} { return new(decodeBook) },
+			},
+			expected: expected{
+				value: decodeBook{Author: "Nigel Rees", Title: "Sayings of the Century", Price: 8.95},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[?(@.price > 10)][0]",
+				target:   func() interface{} { return new(decodeBook) },
+			},
+			expected: expected{
+				value: decodeBook{Author: "Evelyn Waugh", Title: "Sword of Honour", Price: 12.99},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[0].published",
+				target:   func() interface{} { return new(time.Time) },
+			},
+			expected: expected{
+				value: time.Date(1951, 6, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book",
+				target:   func() interface{} { return new([]decodeBook) },
+			},
+			expected: expected{
+				value: []decodeBook{
+					{Author: "Nigel Rees", Title: "Sayings of the Century", Price: 8.95},
+					{Author: "Evelyn Waugh", Title: "Sword of Honour", Price: 12.99},
+				},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[*].author",
+				target:   func() interface{} { return new([]string) },
+			},
+			expected: expected{
+				value: []string{"Nigel Rees", "Evelyn Waugh"},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[?(@.price < 10)].title",
+				target:   func() interface{} { return new([1]string) },
+			},
+			expected: expected{
+				value: [1]string{"Sayings of the Century"},
+			},
+		},
+		{
+			input: input{
+				selector: "$..price",
+				target:   func() interface{} { return new([]interface{}) },
+			},
+			expected: expected{
+				value: []interface{}{19.95, 8.95, 12.99},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[*].author",
+				target:   func() interface{} { return new(interface{}) },
+			},
+			expected: expected{
+				value: []interface{}{"Nigel Rees", "Evelyn Waugh"},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[*].author",
+				target:   func() interface{} { return new(string) },
+			},
+			expected: expected{
+				err: "unable to decode result of selector '$.store.book[*].author' into [string]. selector can match more than one value and returns a list",
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.bicycle.color",
+				target:   func() interface{} { return new([]string) },
+			},
+			expected: expected{
+				err: "unable to decode result of selector '$.store.bicycle.color' into [[]string]. selector returns a single value of kind [string]",
+			},
+		},
+		{
+			input: input{
+				selector: "$.empty",
+				target: func() interface{} {
+					value := "previous"
+					return &value
+				},
+			},
+			expected: expected{
+				value: "",
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.missing",
+				target:   func() interface{} { return new(string) },
+			},
+			expected: expected{
+				err: "key: invalid token key 'missing' not found",
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[0].title",
+				options:  []Option{Standard(RFC9535)},
+				target:   func() interface{} { return new(string) },
+			},
+			expected: expected{
+				value: "Sayings of the Century",
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[*].title",
+				options:  []Option{Standard(RFC9535)},
+				target:   func() interface{} { return new([]string) },
+			},
+			expected: expected{
+				value: []string{"Sayings of the Century", "Sword of Honour"},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[0].title",
+				options:  []Option{QueryOptions(&option.QueryOptions{AlwaysReturnList: true})},
+				target:   func() interface{} { return new(string) },
+			},
+			expected: expected{
+				value: "Sayings of the Century",
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.missing",
+				options:  []Option{Standard(RFC9535)},
+				target:   func() interface{} { return new(string) },
+			},
+			expected: expected{
+				err: "unable to decode result of selector '$.store.missing'. selector did not match a value",
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[0].title",
+				target:   func() interface{} { return nil },
+			},
+			expected: expected{
+				err: "invalid decode target. expected non-nil pointer got [nil]",
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[0].title",
+				target:   func() interface{} { return "" },
+			},
+			expected: expected{
+				err: "invalid decode target. expected non-nil pointer got [string]",
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.input.selector, test.input.options...)
+			assert.Nil(t, err)
+
+			target := test.input.target()
+			err = selector.Decode(data, target)
+			if test.expected.err != "" {
+				assert.EqualError(t, err, test.expected.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected.value, reflect.ValueOf(target).Elem().Interface())
+		})
+	}
+
+	t.Run("assignable", func(t *testing.T) {
+		selector, _ := Compile("$.store.book[0]")
+		var book *bookData
+		err := selector.Decode(sampleDataObject, &book)
+		assert.Nil(t, err)
+		assert.Same(t, sampleDataObject.Store.Book[0], book)
+	})
+	t.Run("error type", func(t *testing.T) {
+		selector, _ := Compile("$.store.book[*]")
+		var book decodeBook
+		err := selector.Decode(data, &book)
+		assert.True(t, goErr.Is(err, errors.ErrDecodeResult))
+	})
+}
+
+func Test_Selector_DecodeString(t *testing.T) {
+	selector, _ := Compile("$[*].count")
+
+	var counts []int
+	err := selector.DecodeString(`[{"count": 1}, {"count": 2}]`, &counts)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, counts)
+
+	err = selector.DecodeString(`[{"count": 1}`, &counts)
+	assert.EqualError(t, err, "invalid data. unexpected type or nil")
+
+	err = selector.DecodeString(`[]`, counts)
+	assert.EqualError(t, err, "invalid decode target. expected non-nil pointer got [[]int]")
+}
+
+func Test_QueryAs(t *testing.T) {
+	title, err := QueryAs[string]("$.store.book[0].title", sampleDataObject)
+	assert.Nil(t, err)
+	assert.Equal(t, "Sayings of the Century", title)
+
+	prices, err := QueryAs[[]float64]("$.store.book[?(@.price < 10)].price", sampleDataObject)
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{8.95, 8.99}, prices)
+
+	book, err := QueryAs[decodeBook]("$.store.book[?(@.price < 10)][0]", sampleDataObject)
+	assert.Nil(t, err)
+	assert.Equal(t, decodeBook{Author: "Nigel Rees", Title: "Sayings of the Century", Price: 8.95}, book)
+
+	_, err = QueryAs[string]("$.store.book[", sampleDataObject)
+	assert.EqualError(t, err, "invalid JSONPath selector '$.store.book[' invalid token. '[' does not match any token format")
+}
+
+func Test_QueryStringAs(t *testing.T) {
+	book, err := QueryStringAs[decodeBook]("$.book", `{"book": {"author": "Herman Melville", "title": "Moby Dick", "price": 8.99}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, decodeBook{Author: "Herman Melville", Title: "Moby Dick", Price: 8.99}, book)
+
+	_, err = QueryStringAs[decodeBook]("$.book", `{"book": 1}`)
+	assert.EqualError(t, err, "unable to decode result of selector '$.book' into [jsonpath.decodeBook]. json: cannot unmarshal number into Go value of type jsonpath.decodeBook")
+
+	_, err = QueryStringAs[string]("$.store.book[", `{}`)
+	assert.EqualError(t, err, "invalid JSONPath selector '$.store.book[' invalid token. '[' does not match any token format")
+}
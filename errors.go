@@ -3,15 +3,54 @@ package jsonpath
 import (
 	goErr "errors"
 	"fmt"
+	"reflect"
 
 	"github.com/evilmonkeyinc/jsonpath/errors"
+	"github.com/evilmonkeyinc/jsonpath/token"
 )
 
+// SyntaxError returned when a selector can not be compiled, describing the position of the error within the selector
+type SyntaxError = errors.SyntaxError
+
+// QueryError returned when a selector can not be applied to the queried data, describing the path at which the query failed
+type QueryError = errors.QueryError
+
+// SelectorSetError returned when one or more of the selectors of a set can not be applied to the queried data
+type SelectorSetError = errors.SelectorSetError
+
 var (
 	errDataIsUnexpectedTypeOrNil error = fmt.Errorf("unexpected type or nil")
+	errDataHasTrailingValue      error = fmt.Errorf("unexpected value after top-level value")
 	errOptionAlreadySet          error = fmt.Errorf("option already set")
 )
 
+func getDecodeResultError(selector string, target reflect.Type, reason error) error {
+	return fmt.Errorf("%w of selector '%s' into [%v]. %s", errors.ErrDecodeResult, selector, target, reason.Error())
+}
+
+func getDecodeResultKindError(selector string, target reflect.Type, got reflect.Kind) error {
+	return fmt.Errorf("%w of selector '%s' into [%v]. unexpected value of kind [%v]", errors.ErrDecodeResult, selector, target, got)
+}
+
+func getDecodeResultListError(selector string, target reflect.Type) error {
+	return fmt.Errorf("%w of selector '%s' into [%v]. selector can match more than one value and returns a list", errors.ErrDecodeResult, selector, target)
+}
+
+func getDecodeResultNotFoundError(selector string) error {
+	return fmt.Errorf("%w of selector '%s'. selector did not match a value", errors.ErrDecodeResult, selector)
+}
+
+func getDecodeResultSingleValueError(selector string, target reflect.Type, got reflect.Kind) error {
+	return fmt.Errorf("%w of selector '%s' into [%v]. selector returns a single value of kind [%v]", errors.ErrDecodeResult, selector, target, got)
+}
+
+func getInvalidDecodeTargetError(target reflect.Type) error {
+	if target == nil {
+		return fmt.Errorf("%w. expected non-nil pointer got [nil]", errors.ErrInvalidDecodeTarget)
+	}
+	return fmt.Errorf("%w. expected non-nil pointer got [%v]", errors.ErrInvalidDecodeTarget, target)
+}
+
 func getInvalidJSONData(reason error) error {
 	return fmt.Errorf("%w. %s", errors.ErrInvalidJSONData, reason.Error())
 }
@@ -21,8 +60,45 @@ func getInvalidJSONPathSelector(selector string) error {
 }
 
 func getInvalidJSONPathSelectorWithReason(selector string, reason error) error {
-	if goErr.Is(reason, errors.ErrInvalidJSONPathSelector) {
+	if goErr.Is(reason, errors.ErrInvalidJSONPathSelector) || goErr.Is(reason, errors.ErrLimitExceeded) {
 		return reason
 	}
-	return fmt.Errorf("%w '%s' %s", errors.ErrInvalidJSONPathSelector, selector, reason.Error())
+	err := fmt.Errorf("%w '%s' %s", errors.ErrInvalidJSONPathSelector, selector, reason.Error())
+	if syntaxError, ok := reason.(*errors.SyntaxError); ok {
+		wrapped := *syntaxError
+		wrapped.Err = err
+		return &wrapped
+	}
+	return err
+}
+
+func getInvalidPathComputedError(path token.Path) error {
+	return fmt.Errorf("%w %s. value is computed and is not a location in the data", errors.ErrInvalidPath, path)
+}
+
+func getInvalidUnmarshalTargetError(target reflect.Type) error {
+	if target == nil {
+		return fmt.Errorf("%w. expected non-nil pointer to struct got [nil]", errors.ErrInvalidDecodeTarget)
+	}
+	return fmt.Errorf("%w. expected non-nil pointer to struct got [%v]", errors.ErrInvalidDecodeTarget, target)
+}
+
+func getLimitExceededError(limit string, maximum int) error {
+	return fmt.Errorf("%w. %s exceeds maximum of %d", errors.ErrLimitExceeded, limit, maximum)
+}
+
+func getQueryCancelledError(reason error) error {
+	return fmt.Errorf("query cancelled. %w", reason)
+}
+
+func getSelectorNotFormattableError(selector string) error {
+	return fmt.Errorf("selector '%s' can not be formatted without changing its meaning", selector)
+}
+
+func getUnsupportedSpecificationError(specification Specification) error {
+	return fmt.Errorf("unsupported specification '%s'", specification)
+}
+
+func getUnmarshalFieldError(field string, reason error) error {
+	return fmt.Errorf("%w '%s'. %s", errors.ErrUnmarshalField, field, reason.Error())
 }
@@ -5,16 +5,30 @@ import (
 )
 
 var (
+	// ErrDecodeResult returned when the result of a query can not be decoded into the target value
+	ErrDecodeResult error = fmt.Errorf("unable to decode result")
+	// ErrInvalidDecodeTarget returned when the target value to decode the result of a query into is not a non-nil pointer
+	ErrInvalidDecodeTarget error = fmt.Errorf("invalid decode target")
 	// ErrInvalidExpression returned when an expression is invalid
 	ErrInvalidExpression error = fmt.Errorf("invalid expression")
 	// ErrInvalidJSONPathSelector returned when the JSONPath selector is invalid
 	ErrInvalidJSONPathSelector error = fmt.Errorf("invalid JSONPath selector")
 	// ErrInvalidJSONData returned when the JSON data is invalid
 	ErrInvalidJSONData error = fmt.Errorf("invalid data")
+	// ErrInvalidPath returned when a path location can not be modified
+	ErrInvalidPath error = fmt.Errorf("invalid path")
 	// ErrInvalidToken returned when a token is invalid
 	ErrInvalidToken error = fmt.Errorf("invalid token")
+	// ErrInvalidTokenKeyNotFound returned when a token is applied to a map or struct that does not include the key
+	ErrInvalidTokenKeyNotFound error = fmt.Errorf("%w key", ErrInvalidToken)
+	// ErrInvalidTokenOutOfRange returned when a token is applied to an array, slice, or string that does not include the index
+	ErrInvalidTokenOutOfRange error = fmt.Errorf("%w out of range", ErrInvalidToken)
 	// ErrInvalidTokenTarget returned when a token parses an invalid target
 	ErrInvalidTokenTarget error = fmt.Errorf("%w target", ErrInvalidToken)
+	// ErrLimitExceeded returned when a selector, expression, or query exceeds a resource limit
+	ErrLimitExceeded error = fmt.Errorf("limit exceeded")
+	// ErrUnmarshalField returned when the value selected for a field by its jsonpath struct tag can not be unmarshalled
+	ErrUnmarshalField error = fmt.Errorf("unable to unmarshal field")
 	// ErrUnexpectedExpressionResult returned when an expression unexpected result
 	ErrUnexpectedExpressionResult error = fmt.Errorf("unexpected expression result")
 	// ErrUnexpectedToken returned when an unexpected token string is parsed
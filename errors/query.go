@@ -0,0 +1,27 @@
+package errors
+
+import "reflect"
+
+// QueryError returned when a selector can not be applied to the queried data, describing where the query failed
+type QueryError struct {
+	// Err the error describing why the token could not be applied
+	Err error
+	// Path the normalized path of the value the token was applied to, for example $['store']['book']
+	Path string
+	// TokenType the type of the token that could not be applied, for example key or index
+	TokenType string
+	// Token the string representation of the token that could not be applied, for example ['author']
+	Token string
+	// Kind the kind of the value the token was applied to, reflect.Invalid if the value was nil
+	Kind reflect.Kind
+}
+
+// Error returns the message of the error describing why the token could not be applied
+func (err *QueryError) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap returns the error describing why the token could not be applied
+func (err *QueryError) Unwrap() error {
+	return err.Err
+}
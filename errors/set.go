@@ -0,0 +1,28 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SelectorSetError returned when one or more of the selectors of a set can not be applied to the queried data
+type SelectorSetError struct {
+	// Errors the error of each selector that could not be applied, by the name of the selector
+	Errors map[string]error
+}
+
+// Error returns the errors of the selectors ordered by the name of the selector
+func (err *SelectorSetError) Error() string {
+	names := make([]string, 0, len(err.Errors))
+	for name := range err.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := make([]string, len(names))
+	for idx, name := range names {
+		messages[idx] = fmt.Sprintf("selector '%s' failed. %s", name, err.Errors[name].Error())
+	}
+	return strings.Join(messages, ", ")
+}
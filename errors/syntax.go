@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// SyntaxError returned when a selector or expression can not be parsed, describing the position of the error
+type SyntaxError struct {
+	// Err the error describing why the selector could not be parsed
+	Err error
+	// Selector the selector, or expression, that could not be parsed
+	Selector string
+	// Offset the byte offset of the error within the selector
+	Offset int
+	// Token the text of the offending token
+	Token string
+	// Expected the alternatives that were expected at the offset, if known
+	Expected []string
+}
+
+// Error returns the message of the error describing why the selector could not be parsed
+func (err *SyntaxError) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap returns the error describing why the selector could not be parsed
+func (err *SyntaxError) Unwrap() error {
+	return err.Err
+}
+
+// Snippet returns the selector followed by a line with a caret marking the position of the error
+func (err *SyntaxError) Snippet() string {
+	offset := err.Offset
+	if offset < 0 {
+		offset = 0
+	} else if offset > len(err.Selector) {
+		offset = len(err.Selector)
+	}
+	column := utf8.RuneCountInString(err.Selector[:offset])
+	return err.Selector + "\n" + strings.Repeat(" ", column) + "^"
+}
@@ -1,7 +1,9 @@
 package jsonpath
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
 	goErr "errors"
@@ -103,3 +105,37 @@ func Test_getInvalidJSONPathSelectorWithReason(t *testing.T) {
 		})
 	}
 }
+
+func Test_getInvalidUnmarshalTargetError(t *testing.T) {
+	actual := getInvalidUnmarshalTargetError(reflect.TypeOf(""))
+	assert.EqualError(t, actual, "invalid decode target. expected non-nil pointer to struct got [string]")
+	assert.True(t, goErr.Is(actual, errors.ErrInvalidDecodeTarget))
+
+	actual = getInvalidUnmarshalTargetError(nil)
+	assert.EqualError(t, actual, "invalid decode target. expected non-nil pointer to struct got [nil]")
+}
+
+func Test_getLimitExceededError(t *testing.T) {
+	actual := getLimitExceededError("result count", 10)
+	assert.EqualError(t, actual, "limit exceeded. result count exceeds maximum of 10")
+	assert.True(t, goErr.Is(actual, errors.ErrLimitExceeded))
+
+	assert.Equal(t, actual, getInvalidJSONPathSelectorWithReason("$..*", actual))
+}
+
+func Test_getQueryCancelledError(t *testing.T) {
+	actual := getQueryCancelledError(context.Canceled)
+	assert.EqualError(t, actual, "query cancelled. context canceled")
+	assert.True(t, goErr.Is(actual, context.Canceled))
+}
+
+func Test_getUnsupportedSpecificationError(t *testing.T) {
+	actual := getUnsupportedSpecificationError("draft")
+	assert.EqualError(t, actual, "unsupported specification 'draft'")
+}
+
+func Test_getUnmarshalFieldError(t *testing.T) {
+	actual := getUnmarshalFieldError("Book.Title", fmt.Errorf("a reason"))
+	assert.EqualError(t, actual, "unable to unmarshal field 'Book.Title'. a reason")
+	assert.True(t, goErr.Is(actual, errors.ErrUnmarshalField))
+}
@@ -0,0 +1,98 @@
+package jsonpath
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/evilmonkeyinc/jsonpath/token"
+)
+
+// Explanation describes how each token of a selector was applied by a query
+type Explanation struct {
+	// Selector the compiled selector
+	Selector string
+	// Tokens how each token was applied, in the order they appear in the selector
+	Tokens []*token.TokenTrace
+	// Results the number of nodes returned by the query
+	Results int
+	// Duration the time taken to apply the query
+	Duration time.Duration
+}
+
+// String returns the explanation as a table with a row for each token,
+// followed by the errors of the failed filter evaluations.
+func (explanation *Explanation) String() string {
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, "selector: %s\n\n", explanation.Selector)
+
+	writer := tabwriter.NewWriter(builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "TOKEN\tTYPE\tINPUT\tOUTPUT\tTRUE\tFALSE\tERRORS\tDURATION")
+	for _, tokenTrace := range explanation.Tokens {
+		evaluations := []interface{}{"-", "-", "-"}
+		if tokenTrace.Matched+tokenTrace.Rejected+tokenTrace.Failed > 0 {
+			evaluations = []interface{}{tokenTrace.Matched, tokenTrace.Rejected, tokenTrace.Failed}
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%d\t%v\t%v\t%v\t%s\n",
+			tokenTrace.Token,
+			tokenTrace.Type,
+			tokenTrace.Input,
+			tokenTrace.Output,
+			evaluations[0], evaluations[1], evaluations[2],
+			tokenTrace.Duration,
+		)
+	}
+	writer.Flush()
+
+	for _, tokenTrace := range explanation.Tokens {
+		for idx, err := range tokenTrace.Errors {
+			if idx == 0 {
+				fmt.Fprintf(builder, "\nfailed evaluations of %s:\n", tokenTrace.Token)
+			}
+			if queryErr, ok := err.(*QueryError); ok {
+				fmt.Fprintf(builder, "  %s: %s\n", queryErr.Path, queryErr.Error())
+				continue
+			}
+			fmt.Fprintf(builder, "  %s\n", err.Error())
+		}
+		if hidden := tokenTrace.Failed - len(tokenTrace.Errors); hidden > 0 {
+			fmt.Fprintf(builder, "  and %d more\n", hidden)
+		}
+	}
+
+	fmt.Fprintf(builder, "\nresults: %d in %s\n", explanation.Results, explanation.Duration)
+	return builder.String()
+}
+
+// Explain will apply the JSONPath query against the specified JSON data and return an explanation of
+// how each token was applied, including the number of nodes each token was applied to and selected,
+// the filter evaluations that were true, false, or failed, and the time spent applying each token.
+//
+// The query is applied in the same way as QueryNodes. If the query fails the explanation
+// is returned along with the error to show how far the query progressed.
+func (query *Selector) Explain(root interface{}) (*Explanation, error) {
+	return query.ExplainContext(context.Background(), root)
+}
+
+// ExplainContext is the equivalent of Explain with a context, the query is stopped and the
+// context error returned if the context is cancelled or its deadline is exceeded.
+func (query *Selector) ExplainContext(ctx context.Context, root interface{}) (*Explanation, error) {
+	if len(query.tokens) == 0 {
+		return nil, getInvalidJSONPathSelector(query.selector)
+	}
+
+	trace := token.NewTrace(query.tokens)
+	started := time.Now()
+	nodes, err := query.QueryNodesContext(token.WithTrace(ctx, trace), root)
+	elapsed := time.Since(started)
+	trace.Finish(len(nodes), elapsed)
+
+	return &Explanation{
+		Selector: query.String(),
+		Tokens:   trace.Tokens,
+		Results:  len(nodes),
+		Duration: elapsed,
+	}, err
+}
@@ -0,0 +1,199 @@
+package jsonpath
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evilmonkeyinc/jsonpath/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Explanation_String(t *testing.T) {
+	explanation := &Explanation{
+		Selector: "$['book'][?(@.price < 10)]",
+		Tokens: []*token.TokenTrace{
+			{Token: "$", Type: "root", Input: 1, Output: 1, Duration: time.Microsecond},
+			{Token: "['book']", Type: "key", Input: 1, Output: 1, Duration: 2 * time.Microsecond},
+			{
+				Token:    "[?(@.price < 10)]",
+				Type:     "filter",
+				Input:    1,
+				Output:   1,
+				Matched:  1,
+				Rejected: 1,
+				Failed:   3,
+				Errors: []error{
+					&QueryError{Err: fmt.Errorf("key not found"), Path: "$['book'][2]"},
+					fmt.Errorf("other"),
+				},
+				Duration: 10 * time.Microsecond,
+			},
+		},
+		Results:  1,
+		Duration: 13 * time.Microsecond,
+	}
+
+	expected := "selector: $['book'][?(@.price < 10)]\n" +
+		"\n" +
+		"TOKEN              TYPE    INPUT  OUTPUT  TRUE  FALSE  ERRORS  DURATION\n" +
+		"$                  root    1      1       -     -      -       1µs\n" +
+		"['book']           key     1      1       -     -      -       2µs\n" +
+		"[?(@.price < 10)]  filter  1      1       1     1      3       10µs\n" +
+		"\n" +
+		"failed evaluations of [?(@.price < 10)]:\n" +
+		"  $['book'][2]: key not found\n" +
+		"  other\n" +
+		"  and 1 more\n" +
+		"\n" +
+		"results: 1 in 13µs\n"
+	assert.Equal(t, expected, explanation.String())
+}
+
+func Test_Selector_Explain(t *testing.T) {
+	data := map[string]interface{}{
+		"store": map[string]interface{}{
+			"book": []interface{}{
+				map[string]interface{}{"price": 8.95, "title": "Sayings of the Century"},
+				map[string]interface{}{"price": 12.99, "title": "Sword of Honour"},
+				map[string]interface{}{"title": "Moby Dick"},
+				map[string]interface{}{"price": 8.99, "title": "The Lord of the Rings"},
+			},
+		},
+	}
+
+	type expected struct {
+		selector string
+		tokens   []*token.TokenTrace
+		results  int
+		failed   []string
+		err      string
+	}
+
+	tests := []struct {
+		selector string
+		options  []Option
+		expected expected
+	}{
+		{
+			selector: "$.store.book[?(@.price < 10)].title",
+			expected: expected{
+				selector: "$['store']['book'][?(@.price < 10)]['title']",
+				tokens: []*token.TokenTrace{
+					{Token: "$", Type: "root", Input: 1, Output: 1},
+					{Token: "['store']", Type: "key", Input: 1, Output: 1},
+					{Token: "['book']", Type: "key", Input: 1, Output: 1},
+					{Token: "[?(@.price < 10)]", Type: "filter", Input: 1, Output: 2, Matched: 2, Rejected: 1, Failed: 1},
+					{Token: "['title']", Type: "key", Input: 2, Output: 2},
+				},
+				results: 2,
+				failed:  []string{"$['store']['book'][2]"},
+			},
+		},
+		{
+			selector: "$..book[?(@.price > 100)].title",
+			expected: expected{
+				selector: "$..['book'][?(@.price > 100)]['title']",
+				tokens: []*token.TokenTrace{
+					{Token: "$", Type: "root", Input: 1, Output: 1},
+					{Token: "..", Type: "recursive", Input: 1, Output: 14},
+					{Token: "['book']", Type: "key", Input: 14, Output: 1},
+					{Token: "[?(@.price > 100)]", Type: "filter", Input: 1, Output: 0, Rejected: 3, Failed: 1},
+					{Token: "['title']", Type: "key", Input: 0, Output: 0},
+				},
+				results: 0,
+				failed:  []string{"$['store']['book'][2]"},
+			},
+		},
+		{
+			selector: "$.store.book[?@.price < 10].title",
+			options:  []Option{Standard(RFC9535)},
+			expected: expected{
+				selector: "$['store']['book'][?@.price < 10]['title']",
+				tokens: []*token.TokenTrace{
+					{Token: "$", Type: "root", Input: 1, Output: 1},
+					{Token: "['store']", Type: "child", Input: 1, Output: 1},
+					{Token: "['book']", Type: "child", Input: 1, Output: 1},
+					{Token: "[?@.price < 10]", Type: "child", Input: 1, Output: 2, Matched: 2, Rejected: 2},
+					{Token: "['title']", Type: "child", Input: 2, Output: 2},
+				},
+				results: 2,
+			},
+		},
+		{
+			selector: "$.store.missing",
+			expected: expected{
+				selector: "$['store']['missing']",
+				tokens: []*token.TokenTrace{
+					{Token: "$", Type: "root", Input: 1, Output: 1},
+					{Token: "['store']", Type: "key", Input: 1, Output: 1},
+					{Token: "['missing']", Type: "key", Input: 1, Output: 0},
+				},
+				results: 0,
+				err:     "key: invalid token key 'missing' not found",
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector, test.options...)
+			assert.Nil(t, err)
+
+			explanation, err := selector.Explain(data)
+			if test.expected.err != "" {
+				assert.EqualError(t, err, test.expected.err)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			assert.Equal(t, test.expected.selector, explanation.Selector)
+			assert.Equal(t, test.expected.results, explanation.Results)
+
+			failed := make([]string, 0)
+			for _, tokenTrace := range explanation.Tokens {
+				for _, err := range tokenTrace.Errors {
+					failed = append(failed, err.(*QueryError).Path)
+				}
+				tokenTrace.Errors = nil
+				tokenTrace.Duration = 0
+			}
+			if test.expected.failed == nil {
+				test.expected.failed = []string{}
+			}
+			assert.Equal(t, test.expected.failed, failed)
+			assert.Equal(t, test.expected.tokens, explanation.Tokens)
+		})
+	}
+
+	t.Run("not compiled", func(t *testing.T) {
+		explanation, err := (&Selector{}).Explain(data)
+		assert.EqualError(t, err, "invalid JSONPath selector ''")
+		assert.Nil(t, explanation)
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		selector, _ := Compile("$..title")
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		explanation, err := selector.ExplainContext(ctx, data)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotNil(t, explanation)
+	})
+}
+
+func Test_Explain(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		explanation, err := Explain("$[?(@ > 1)]", []interface{}{1, 2, 3})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, explanation.Results)
+		assert.Equal(t, 2, explanation.Tokens[1].Matched)
+		assert.Equal(t, 1, explanation.Tokens[1].Rejected)
+	})
+	t.Run("invalid selector", func(t *testing.T) {
+		explanation, err := Explain("", []interface{}{})
+		assert.EqualError(t, err, "invalid JSONPath selector '' unexpected token '' at index 0")
+		assert.Nil(t, explanation)
+	})
+}
@@ -1,11 +1,16 @@
 package jsonpath
 
 import (
+	"strings"
+
+	"github.com/evilmonkeyinc/jsonpath/ast"
 	"github.com/evilmonkeyinc/jsonpath/script/standard"
 	"github.com/evilmonkeyinc/jsonpath/token"
 )
 
-// Compile will compile the JSONPath selector
+// Compile will compile the JSONPath selector.
+//
+// Errors caused by the syntax of the selector are returned as a *SyntaxError describing the position of the error.
 func Compile(selector string, options ...Option) (*Selector, error) {
 	jsonPath := &Selector{
 		selector: selector,
@@ -17,23 +22,103 @@ func Compile(selector string, options ...Option) (*Selector, error) {
 		}
 	}
 
+	if jsonPath.standard == RFC9535 {
+		return compileRFC9535(jsonPath)
+	}
+
 	// Set defaults if options were not used
 	if jsonPath.engine == nil {
 		jsonPath.engine = new(standard.ScriptEngine)
 	}
 
-	tokenStrings, err := token.Tokenize(jsonPath.selector)
+	tokens, err := token.ParseSelector(jsonPath.selector, jsonPath.engine, jsonPath.Options)
 	if err != nil {
 		return nil, getInvalidJSONPathSelectorWithReason(selector, err)
 	}
+	jsonPath.tokens = tokens
+
+	return jsonPath, nil
+}
+
+// Parse will compile the JSONPath selector and return its syntax tree, which can be inspected using ast.Walk or ast.Inspect.
+//
+// Errors are returned in the same way as Compile.
+func Parse(selector string, options ...Option) (*ast.Query, error) {
+	compiled, err := Compile(selector, options...)
+	if err != nil {
+		return nil, err
+	}
+	return compiled.Tree(), nil
+}
+
+// Format will compile the JSONPath selector and return it in a canonical form, using bracket notation
+// for all segments, consistently quoted names and strings, and normalized filter expressions.
+//
+// The formatted selector is compiled to ensure it is equivalent to the selector, an error is returned if it is not.
+func Format(selector string, options ...Option) (string, error) {
+	compiled, err := Compile(selector, options...)
+	if err != nil {
+		return "", err
+	}
+
+	tree := compiled.Tree()
+	printer := &ast.Printer{}
+	if compiled.standard != RFC9535 {
+		printer.QuoteName = quoteName
+		printer.QuoteString = quoteString
+	}
+	formatted := printer.Print(tree)
 
-	tokens := make([]token.Token, len(tokenStrings))
-	for idx, tokenString := range tokenStrings {
-		token, err := token.Parse(tokenString, jsonPath.engine, jsonPath.Options)
-		if err != nil {
-			return nil, getInvalidJSONPathSelectorWithReason(selector, err)
+	recompiled, err := Compile(formatted, options...)
+	if err != nil || recompiled.Tree().String() != tree.String() {
+		return "", getSelectorNotFormattableError(selector)
+	}
+	return formatted, nil
+}
+
+// quoteName returns the name single quoted, escaping backslashes and single quotes in the way they are parsed by the tokenizer
+func quoteName(name string) string {
+	return quoteWith(name, '\'')
+}
+
+// quoteString returns the string wrapped in quotes the standard script engine can parse,
+// strings that include single quotes but not double quotes are wrapped in double quotes.
+func quoteString(value string) string {
+	if strings.Contains(value, "'") && !strings.Contains(value, "\"") {
+		return quoteWith(value, '"')
+	}
+	return quoteWith(value, '\'')
+}
+
+// quoteWith returns the value wrapped in the quote character, escaping backslashes and the quote character
+func quoteWith(value string, quote byte) string {
+	builder := strings.Builder{}
+	builder.WriteByte(quote)
+	for idx := 0; idx < len(value); idx++ {
+		if char := value[idx]; char == '\\' || char == quote {
+			builder.WriteByte('\\')
 		}
-		tokens[idx] = token
+		builder.WriteByte(value[idx])
+	}
+	builder.WriteByte(quote)
+	return builder.String()
+}
+
+// compileRFC9535 compiles the selector using the strict RFC 9535 grammar, the standard script
+// engine is switched to RFC 9535 filter expressions, custom script engines are used as provided.
+func compileRFC9535(jsonPath *Selector) (*Selector, error) {
+	switch engine := jsonPath.engine.(type) {
+	case nil:
+		jsonPath.engine = &standard.ScriptEngine{RFC9535: true}
+	case *standard.ScriptEngine:
+		rfcEngine := *engine
+		rfcEngine.RFC9535 = true
+		jsonPath.engine = &rfcEngine
+	}
+
+	tokens, err := token.ParseRFC9535(jsonPath.selector, jsonPath.engine, jsonPath.Options)
+	if err != nil {
+		return nil, getInvalidJSONPathSelectorWithReason(jsonPath.selector, err)
 	}
 	jsonPath.tokens = tokens
 
@@ -57,3 +142,47 @@ func QueryString(selector string, jsonData string, options ...Option) (interface
 	}
 	return jsonPath.QueryString(jsonData)
 }
+
+// QueryYAML will return the result of the JSONPath selector applied against the specified YAML data.
+func QueryYAML(selector string, yamlData string, options ...Option) (interface{}, error) {
+	jsonPath, err := Compile(selector, options...)
+	if err != nil {
+		return nil, getInvalidJSONPathSelectorWithReason(selector, err)
+	}
+	return jsonPath.QueryYAML(yamlData)
+}
+
+// Explain will return an explanation of how each token of the JSONPath selector was applied against the specified JSON data.
+func Explain(selector string, jsonData interface{}, options ...Option) (*Explanation, error) {
+	jsonPath, err := Compile(selector, options...)
+	if err != nil {
+		return nil, getInvalidJSONPathSelectorWithReason(selector, err)
+	}
+	return jsonPath.Explain(jsonData)
+}
+
+// QueryAs will return the result of the JSONPath selector applied against the specified data, decoded as the type T.
+//
+// The result is decoded in the same way as the Decode function of the Selector.
+func QueryAs[T any](selector string, jsonData interface{}, options ...Option) (T, error) {
+	var result T
+	jsonPath, err := Compile(selector, options...)
+	if err != nil {
+		return result, getInvalidJSONPathSelectorWithReason(selector, err)
+	}
+	err = jsonPath.Decode(jsonData, &result)
+	return result, err
+}
+
+// QueryStringAs will return the result of the JSONPath selector applied against the specified JSON data, decoded as the type T.
+//
+// The result is decoded in the same way as the Decode function of the Selector.
+func QueryStringAs[T any](selector string, jsonData string, options ...Option) (T, error) {
+	var result T
+	jsonPath, err := Compile(selector, options...)
+	if err != nil {
+		return result, getInvalidJSONPathSelectorWithReason(selector, err)
+	}
+	err = jsonPath.DecodeString(jsonData, &result)
+	return result, err
+}
@@ -1,9 +1,17 @@
 package jsonpath
 
 import (
+	"encoding/json"
+	goErr "errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/evilmonkeyinc/jsonpath/ast"
+	"github.com/evilmonkeyinc/jsonpath/errors"
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/script/standard"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,6 +76,20 @@ func Benchmark_Selector(b *testing.B) {
 		}
 	})
 
+	b.Run("Selector.QueryBytes", func(b *testing.B) {
+		data := []byte(sampleDataString)
+		for _, selector := range compiledSelectors {
+			b.Run(fmt.Sprintf("%s", selector.selector), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_, err := selector.QueryBytes(data)
+					if err != nil {
+						b.Error()
+					}
+				}
+			})
+		}
+	})
+
 	b.Run("Selector.Query", func(b *testing.B) {
 		for _, selector := range compiledSelectors {
 			b.Run(fmt.Sprintf("%s", selector.selector), func(b *testing.B) {
@@ -517,6 +539,33 @@ func Test_Compile(t *testing.T) {
 				tokens: 2,
 			},
 		},
+		{
+			input: input{
+				selector: "$.store.book[?@.price < 10].title",
+				options:  []Option{Standard(RFC9535)},
+			},
+			expected: expected{
+				tokens: 5,
+			},
+		},
+		{
+			input: input{
+				selector: "$..book[(@.length-1)]",
+				options:  []Option{Standard(RFC9535)},
+			},
+			expected: expected{
+				err: "invalid JSONPath selector '$..book[(@.length-1)]' unexpected token '(' at index 8",
+			},
+		},
+		{
+			input: input{
+				selector: "$[?@.* == 1]",
+				options:  []Option{Standard(RFC9535)},
+			},
+			expected: expected{
+				err: "invalid JSONPath selector '$[?@.* == 1]' invalid expression. invalid argument. expected literal, singular query, or value function",
+			},
+		},
 		{
 			input: input{
 				selector: "this wont matter",
@@ -551,6 +600,350 @@ func Test_Compile(t *testing.T) {
 
 }
 
+func Test_Parse(t *testing.T) {
+
+	t.Run("tree", func(t *testing.T) {
+		actual, err := Parse("$.store.book[?(@.price < 10)].title")
+		assert.Nil(t, err)
+		assert.Equal(t, &ast.Query{
+			Segments: []*ast.Segment{
+				{Selectors: []ast.Selector{&ast.NameSelector{Name: "store"}}},
+				{Selectors: []ast.Selector{&ast.NameSelector{Name: "book"}}},
+				{Selectors: []ast.Selector{&ast.FilterSelector{
+					Expression: &ast.BinaryExpression{
+						Operator: "<",
+						Left: &ast.QueryExpression{Query: &ast.Query{
+							Relative: true,
+							Segments: []*ast.Segment{{Selectors: []ast.Selector{&ast.NameSelector{Name: "price"}}}},
+						}},
+						Right: &ast.Literal{Value: float64(10)},
+					},
+				}}},
+				{Selectors: []ast.Selector{&ast.NameSelector{Name: "title"}}},
+			},
+		}, actual)
+	})
+
+	t.Run("rfc9535", func(t *testing.T) {
+		actual, err := Parse("$..book[?@.isbn && length(@.title) > 5]['title','author']", Standard(RFC9535))
+		assert.Nil(t, err)
+		assert.Equal(t, "$..['book'][?(@['isbn'] && (length(@['title']) > 5))]['title','author']", actual.String())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		actual, err := Parse("$x")
+		assert.Nil(t, actual)
+		var syntaxError *SyntaxError
+		assert.True(t, goErr.As(err, &syntaxError))
+	})
+
+	t.Run("selector", func(t *testing.T) {
+		selector, _ := Compile("$..author")
+		assert.Equal(t, "$..['author']", selector.Tree().String())
+	})
+}
+
+func Test_Format(t *testing.T) {
+
+	type input struct {
+		selector string
+		options  []Option
+	}
+
+	type expected struct {
+		formatted string
+		err       string
+	}
+
+	tests := []struct {
+		input    input
+		expected expected
+	}{
+		{
+			input:    input{selector: "$.store.book[*].author"},
+			expected: expected{formatted: "$['store']['book'][*]['author']"},
+		},
+		{
+			input:    input{selector: `$..["author"]`},
+			expected: expected{formatted: "$..['author']"},
+		},
+		{
+			input:    input{selector: "$.store.book[ -1: ]"},
+			expected: expected{formatted: "$['store']['book'][-1:]"},
+		},
+		{
+			input:    input{selector: "$.store.book[?(@.price<10&&@.category=='fiction')].title"},
+			expected: expected{formatted: "$['store']['book'][?((@['price'] < 10) && (@['category'] == 'fiction'))]['title']"},
+		},
+		{
+			input:    input{selector: "$.a[?(@.b - @.c - 1 > 0)]"},
+			expected: expected{formatted: "$['a'][?((@['b'] - (@['c'] - 1)) > 0)]"},
+		},
+		{
+			input:    input{selector: "$.a[(@.length-1)].b.length"},
+			expected: expected{formatted: "$['a'][(@.length - 1)]['b'].length"},
+		},
+		{
+			input:    input{selector: `$["it's"]`},
+			expected: expected{formatted: `$['it\'s']`},
+		},
+		{
+			input:    input{selector: `$[?(@.name == "it's")]`},
+			expected: expected{formatted: `$[?(@['name'] == "it's")]`},
+		},
+		{
+			input:    input{selector: "$[?(@.x == 1e21)]"},
+			expected: expected{formatted: "$[?(@['x'] == 1000000000000000000000)]"},
+		},
+		{
+			input:    input{selector: `$["it's \"q\"\n"][?@.a == 'b' || @.c]`, options: []Option{Standard(RFC9535)}},
+			expected: expected{formatted: `$['it\'s "q"\n'][?((@['a'] == 'b') || @['c'])]`},
+		},
+		{
+			input:    input{selector: "$..book[?length(@.title)>5]", options: []Option{Standard(RFC9535)}},
+			expected: expected{formatted: "$..['book'][?(length(@['title']) > 5)]"},
+		},
+		{
+			input:    input{selector: "$x"},
+			expected: expected{err: "invalid JSONPath selector '$x' unexpected token 'x' at index 1"},
+		},
+		{
+			input:    input{selector: `$['a\\']`},
+			expected: expected{formatted: `$['a\\']`},
+		},
+		{
+			input:    input{selector: `$["it's \"x\""][?(@.a == "it's \"x\"")]`},
+			expected: expected{formatted: `$['it\'s "x"'][?(@['a'] == 'it\'s "x"')]`},
+		},
+		{
+			input:    input{selector: `$['a\b'][?(@.a == 'a\\b')]`},
+			expected: expected{formatted: `$['a\\b'][?(@['a'] == 'a\\b')]`},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			formatted, err := Format(test.input.selector, test.input.options...)
+			if test.expected.err != "" {
+				assert.EqualError(t, err, test.expected.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected.formatted, formatted)
+
+			// formatting is stable
+			again, err := Format(formatted, test.input.options...)
+			assert.Nil(t, err)
+			assert.Equal(t, formatted, again)
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		selectors := []string{
+			`$['\\']`,
+			`$['it\'s']`,
+			`$["it's"].b`,
+			`$['it\'s "x"']`,
+			`$['a\b','\\']`,
+			`$[?(@.a == 'it\'s "x"')]`,
+			`$[?(@.a == "it's \"x\"")]`,
+			`$[?(@.a == '\\')]`,
+		}
+		for _, selector := range selectors {
+			formatted, err := Format(selector)
+			assert.Nil(t, err, selector)
+
+			expected, _ := Compile(selector)
+			actual, err := Compile(formatted)
+			assert.Nil(t, err, formatted)
+			assert.Equal(t, expected.Tree(), actual.Tree(), selector)
+		}
+	})
+
+	t.Run("key with quote", func(t *testing.T) {
+		data := map[string]interface{}{`it's "x"\`: "value"}
+		formatted, err := Format(`$["it's \"x\"\\"]`)
+		assert.Nil(t, err)
+		assert.Equal(t, `$['it\'s "x"\\']`, formatted)
+
+		actual, err := Query(formatted, data)
+		assert.Nil(t, err)
+		assert.Equal(t, "value", actual)
+	})
+}
+
+func Test_Compile_SyntaxError(t *testing.T) {
+
+	type expected struct {
+		offset   int
+		token    string
+		expected []string
+		snippet  string
+	}
+
+	tests := []struct {
+		selector string
+		options  []Option
+		expected expected
+	}{
+		{
+			selector: "",
+			expected: expected{offset: 0, token: "", expected: []string{"$", "@"}, snippet: "\n^"},
+		},
+		{
+			selector: "$x",
+			expected: expected{offset: 1, token: "x", expected: []string{".", "["}, snippet: "$x\n ^"},
+		},
+		{
+			selector: "$.store[",
+			expected: expected{offset: 8, token: "", expected: []string{"]"}, snippet: "$.store[\n        ^"},
+		},
+		{
+			selector: "$.store.book[]",
+			expected: expected{offset: 13, token: "]", snippet: "$.store.book[]\n             ^"},
+		},
+		{
+			selector: "$.a.a[?@.b]",
+			expected: expected{offset: 7, token: "@", expected: []string{"("}, snippet: "$.a.a[?@.b]\n       ^"},
+		},
+		{
+			selector: "$.a[?(@.x == $.b[)]",
+			expected: expected{offset: 17, token: "", expected: []string{"]"}, snippet: "$.a[?(@.x == $.b[)]\n                 ^"},
+		},
+		{
+			selector: "$.a[?(@.x == nope(@.y))]",
+			expected: expected{offset: 13, token: "nope", snippet: "$.a[?(@.x == nope(@.y))]\n             ^"},
+		},
+		{
+			selector: "$.é[?@.b == $.c[]",
+			options:  []Option{Standard(RFC9535)},
+			expected: expected{offset: 17, token: "]", snippet: "$.é[?@.b == $.c[]\n                ^"},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector, test.options...)
+			assert.Nil(t, selector)
+			assert.True(t, goErr.Is(err, errors.ErrInvalidJSONPathSelector))
+
+			var syntaxError *SyntaxError
+			if !assert.True(t, goErr.As(err, &syntaxError)) {
+				return
+			}
+			assert.Equal(t, test.selector, syntaxError.Selector)
+			assert.Equal(t, test.expected.offset, syntaxError.Offset)
+			assert.Equal(t, test.expected.token, syntaxError.Token)
+			assert.Equal(t, test.expected.expected, syntaxError.Expected)
+			assert.Equal(t, test.expected.snippet, syntaxError.Snippet())
+		})
+	}
+
+	t.Run("query", func(t *testing.T) {
+		_, err := Query("$x", sampleDataObject)
+		var syntaxError *SyntaxError
+		assert.True(t, goErr.As(err, &syntaxError))
+		assert.EqualError(t, err, "invalid JSONPath selector '$x' unexpected token 'x' at index 1")
+	})
+}
+
+func Test_Query_QueryError(t *testing.T) {
+
+	data := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": "value",
+			"c": []interface{}{1, 2},
+		},
+	}
+
+	type expected struct {
+		err       string
+		path      string
+		tokenType string
+		token     string
+		kind      reflect.Kind
+	}
+
+	tests := []struct {
+		selector string
+		expected expected
+	}{
+		{
+			selector: "$.a.x",
+			expected: expected{
+				err:       "key: invalid token key 'x' not found",
+				path:      "$['a']",
+				tokenType: "key",
+				token:     "['x']",
+				kind:      reflect.Map,
+			},
+		},
+		{
+			selector: "$.a.b.x",
+			expected: expected{
+				err:       "key: invalid token target. expected [map] got [string]",
+				path:      "$['a']['b']",
+				tokenType: "key",
+				token:     "['x']",
+				kind:      reflect.String,
+			},
+		},
+		{
+			selector: "$.a.c[5]",
+			expected: expected{
+				err:       "index: invalid token out of range",
+				path:      "$['a']['c']",
+				tokenType: "index",
+				token:     "[5]",
+				kind:      reflect.Slice,
+			},
+		},
+		{
+			selector: "$.a.c[0:2][5]",
+			expected: expected{
+				err:       "index: invalid token out of range",
+				path:      "$['a']['c'][0:2]",
+				tokenType: "index",
+				token:     "[5]",
+				kind:      reflect.Slice,
+			},
+		},
+		{
+			selector: "$.a.c[(@.length+1)]",
+			expected: expected{
+				err:       "index: invalid token out of range",
+				path:      "$['a']['c']",
+				tokenType: "script",
+				token:     "[(@.length+1)]",
+				kind:      reflect.Slice,
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector)
+			assert.Nil(t, err)
+
+			_, queryErr := selector.Query(data)
+			_, nodesErr := selector.QueryNodes(data)
+
+			for _, err := range []error{queryErr, nodesErr} {
+				assert.EqualError(t, err, test.expected.err)
+
+				var queryError *QueryError
+				if !assert.True(t, goErr.As(err, &queryError)) {
+					continue
+				}
+				assert.Equal(t, test.expected.path, queryError.Path)
+				assert.Equal(t, test.expected.tokenType, queryError.TokenType)
+				assert.Equal(t, test.expected.token, queryError.Token)
+				assert.Equal(t, test.expected.kind, queryError.Kind)
+			}
+		})
+	}
+}
+
 func Test_QueryString(t *testing.T) {
 
 	type input struct {
@@ -685,6 +1078,109 @@ func Test_QueryString(t *testing.T) {
 	}
 }
 
+func Test_QueryYAML(t *testing.T) {
+
+	type input struct {
+		selector string
+		yamlData string
+	}
+
+	type expected struct {
+		value interface{}
+		err   string
+	}
+
+	tests := []struct {
+		input    input
+		expected expected
+	}{
+		{
+			input: input{
+				selector: "$.expensive",
+			},
+			expected: expected{
+				err: "invalid data. unexpected type or nil",
+			},
+		},
+		{
+			input: input{
+				selector: "invalid",
+				yamlData: "expensive: 10",
+			},
+			expected: expected{
+				err: "invalid JSONPath selector 'invalid' unexpected token 'i' at index 0",
+			},
+		},
+		{
+			input: input{
+				selector: "$.expensive",
+				yamlData: "other: 10",
+			},
+			expected: expected{
+				err: "key: invalid token key 'expensive' not found",
+			},
+		},
+		{
+			input: input{
+				selector: "$.expensive",
+				yamlData: "expensive: [10",
+			},
+			expected: expected{
+				err: "invalid data. yaml: line 1: did not find expected ',' or ']'",
+			},
+		},
+		{
+			input: input{
+				selector: "$.expensive",
+				yamlData: "expensive: 10",
+			},
+			expected: expected{
+				value: 10,
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[?(@.price < 10)].title",
+				yamlData: "store:\n  book:\n    - title: Sayings of the Century\n      price: 8.95\n    - title: Sword of Honour\n      price: 12.99\n",
+			},
+			expected: expected{
+				value: []interface{}{"Sayings of the Century"},
+			},
+		},
+		{
+			input: input{
+				selector: "$.codes['200']",
+				yamlData: "codes:\n  200: OK\n  404: Not Found\n",
+			},
+			expected: expected{
+				value: "OK",
+			},
+		},
+		{
+			input: input{
+				selector: "$.length",
+				yamlData: "- 1\n- 2\n- 3\n",
+			},
+			expected: expected{
+				value: int64(3),
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			value, err := QueryYAML(test.input.selector, test.input.yamlData)
+
+			if test.expected.err != "" {
+				assert.EqualError(t, err, test.expected.err)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.EqualValues(t, test.expected.value, value)
+		})
+	}
+}
+
 func Test_Query(t *testing.T) {
 
 	type input struct {
@@ -800,3 +1296,330 @@ func Test_Query(t *testing.T) {
 		})
 	}
 }
+
+func Test_RFC9535(t *testing.T) {
+
+	tests := []struct {
+		selector string
+		expected []interface{}
+	}{
+		{
+			selector: "$.store.book[*].author",
+			expected: []interface{}{"Nigel Rees", "Evelyn Waugh", "Herman Melville", "J. R. R. Tolkien"},
+		},
+		{
+			selector: "$.store..price",
+			expected: []interface{}{19.95, 8.95, 12.99, 8.99, 22.99},
+		},
+		{
+			selector: "$..book[-1].title",
+			expected: []interface{}{"The Lord of the Rings"},
+		},
+		{
+			selector: "$..book[::-2].title",
+			expected: []interface{}{"The Lord of the Rings", "Sword of Honour"},
+		},
+		{
+			selector: "$..book[?@.isbn].title",
+			expected: []interface{}{"Moby Dick", "The Lord of the Rings"},
+		},
+		{
+			selector: "$..book[?@.price < $.expensive].title",
+			expected: []interface{}{"Sayings of the Century", "Moby Dick"},
+		},
+		{
+			selector: "$..book[?@.category == 'reference' || @.price > 20, 0].title",
+			expected: []interface{}{"Sayings of the Century", "The Lord of the Rings", "Sayings of the Century"},
+		},
+		{
+			selector: "$.store.book[?!@.isbn]['author', 'missing']",
+			expected: []interface{}{"Nigel Rees", "Evelyn Waugh"},
+		},
+		{
+			selector: "$.missing",
+			expected: []interface{}{},
+		},
+		{
+			selector: "$.store.book[10]",
+			expected: []interface{}{},
+		},
+		{
+			selector: "$.expensive[0]",
+			expected: []interface{}{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.selector, func(t *testing.T) {
+			selector, err := Compile(test.selector, Standard(RFC9535))
+			assert.Nil(t, err)
+
+			actual, err := selector.QueryString(sampleDataString)
+			assert.Nil(t, err)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("root", func(t *testing.T) {
+		selector, err := Compile("$", Standard(RFC9535))
+		assert.Nil(t, err)
+
+		actual, err := selector.Query("root")
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"root"}, actual)
+	})
+
+	t.Run("script engine", func(t *testing.T) {
+		engine := &standard.ScriptEngine{}
+		selector, err := Compile("$[?@ > 1]", Standard(RFC9535), ScriptEngine(engine))
+		assert.Nil(t, err)
+		assert.False(t, engine.RFC9535)
+
+		actual, err := selector.Query([]interface{}{1, 2, 3})
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{2, 3}, actual)
+	})
+}
+
+func Test_CustomFunctions(t *testing.T) {
+
+	engine := &standard.ScriptEngine{}
+	err := engine.RegisterFunction("isCheap", standard.Function{
+		Parameters: []standard.FunctionType{standard.ValueType},
+		Result:     standard.LogicalType,
+		Evaluate: func(arguments []interface{}) (interface{}, error) {
+			price, ok := arguments[0].(float64)
+			return ok && price < 10, nil
+		},
+	})
+	assert.Nil(t, err)
+	err = engine.RegisterFunction("lastIndex", standard.Function{
+		Parameters: []standard.FunctionType{standard.ValueType},
+		Result:     standard.ValueType,
+		Evaluate: func(arguments []interface{}) (interface{}, error) {
+			if array, ok := arguments[0].([]interface{}); ok {
+				return len(array) - 1, nil
+			}
+			return standard.Nothing, nil
+		},
+	})
+	assert.Nil(t, err)
+
+	tests := []struct {
+		selector string
+		options  []Option
+		expected interface{}
+	}{
+		{
+			selector: "$..book[?(isCheap(@.price))].title",
+			options:  []Option{ScriptEngine(engine)},
+			expected: []interface{}{"Sayings of the Century", "Moby Dick"},
+		},
+		{
+			selector: "$.store.book[(lastIndex(@))].title",
+			options:  []Option{ScriptEngine(engine)},
+			expected: "The Lord of the Rings",
+		},
+		{
+			selector: "$..book[?isCheap(@.price)].title",
+			options:  []Option{ScriptEngine(engine), Standard(RFC9535)},
+			expected: []interface{}{"Sayings of the Century", "Moby Dick"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.selector, func(t *testing.T) {
+			selector, err := Compile(test.selector, test.options...)
+			assert.Nil(t, err)
+
+			actual, err := selector.QueryString(sampleDataString)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := Compile("$..book[?isCheap(@.price)]", Standard(RFC9535))
+		assert.EqualError(t, err, "invalid JSONPath selector '$..book[?isCheap(@.price)]' invalid expression. unknown function 'isCheap'")
+	})
+}
+
+func Test_UseNumber(t *testing.T) {
+
+	data := `{"limit": 18446744073709551615, "users": [{"id": 9007199254740993, "name": "first"}, {"id": 9007199254740992, "name": "second"}, {"id": 12.50, "name": "third"}]}`
+	useNumber := QueryOptions(&option.QueryOptions{UseNumber: true})
+
+	tests := []struct {
+		selector string
+		options  []Option
+		expected interface{}
+	}{
+		{
+			selector: "$.users[?(@.id == 9007199254740993)].name",
+			options:  []Option{},
+			expected: []interface{}{"first", "second"},
+		},
+		{
+			selector: "$.users[?(@.id == 9007199254740993)].name",
+			options:  []Option{useNumber},
+			expected: []interface{}{"first"},
+		},
+		{
+			selector: "$.users[?(@.id > 9007199254740992)].name",
+			options:  []Option{useNumber},
+			expected: []interface{}{"first"},
+		},
+		{
+			selector: "$.users[?(@.id + 1 == 9007199254740994)].name",
+			options:  []Option{useNumber},
+			expected: []interface{}{"first"},
+		},
+		{
+			selector: "$.users[?(@.id == 12.5)].name",
+			options:  []Option{useNumber},
+			expected: []interface{}{"third"},
+		},
+		{
+			selector: "$.users[?(@.id < $.limit)].name",
+			options:  []Option{useNumber},
+			expected: []interface{}{"first", "second", "third"},
+		},
+		{
+			selector: "$.users[?@.id == 9007199254740993].name",
+			options:  []Option{useNumber, Standard(RFC9535)},
+			expected: []interface{}{"first"},
+		},
+		{
+			selector: "$.users[0].id",
+			options:  []Option{useNumber},
+			expected: json.Number("9007199254740993"),
+		},
+		{
+			selector: "$.users[(@[2].id - 11.5)].name",
+			options:  []Option{useNumber},
+			expected: "second",
+		},
+		{
+			selector: "$.users[0,(@[2].id - 11.5)].name",
+			options:  []Option{useNumber},
+			expected: []interface{}{"first", "second"},
+		},
+		{
+			selector: "$.users[(@[2].id - 11.5):].name",
+			options:  []Option{useNumber},
+			expected: []interface{}{"second", "third"},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector, test.options...)
+			assert.Nil(t, err)
+
+			actual, err := selector.QueryString(data)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+
+			actual, err = selector.QueryReader(strings.NewReader(data))
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("scalar", func(t *testing.T) {
+		selector, _ := Compile("$", useNumber)
+		actual, err := selector.QueryString("9007199254740993")
+		assert.Nil(t, err)
+		assert.Equal(t, json.Number("9007199254740993"), actual)
+
+		actual, err = selector.QueryString("1.0.0")
+		assert.EqualError(t, err, "invalid data. unexpected type or nil")
+		assert.Nil(t, actual)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		selector, _ := Compile("$.users", useNumber)
+		actual, err := selector.QueryString(`{"users": [}`)
+		assert.EqualError(t, err, "invalid data. invalid character '}' looking for beginning of value")
+		assert.Nil(t, actual)
+	})
+}
+
+func Test_Limits(t *testing.T) {
+
+	limits := func(limits option.Limits) Option {
+		return QueryOptions(&option.QueryOptions{Limits: limits})
+	}
+
+	tests := []struct {
+		selector string
+		options  []Option
+		err      string
+	}{
+		{
+			selector: "$.store.book[0].author",
+			options:  []Option{limits(option.Limits{MaxSelectorLength: 10})},
+			err:      "limit exceeded. selector length exceeds maximum of 10",
+		},
+		{
+			selector: "$.store.book[0].author",
+			options:  []Option{limits(option.Limits{MaxTokens: 4})},
+			err:      "limit exceeded. token count exceeds maximum of 4",
+		},
+		{
+			selector: "$.store.book[0].author",
+			options:  []Option{limits(option.Limits{MaxTokens: 4}), Standard(RFC9535)},
+			err:      "limit exceeded. token count exceeds maximum of 4",
+		},
+		{
+			selector: "$..author",
+			options:  []Option{limits(option.Limits{MaxRecursionDepth: 2})},
+			err:      "limit exceeded. recursion depth exceeds maximum of 2",
+		},
+		{
+			selector: "$..*",
+			options:  []Option{limits(option.Limits{MaxResults: 10}), Standard(RFC9535)},
+			err:      "limit exceeded. result count exceeds maximum of 10",
+		},
+		{
+			selector: "$.store.book[?((((@.price > 10))))]",
+			options:  []Option{limits(option.Limits{MaxExpressionDepth: 2})},
+			err:      "limit exceeded. expression depth exceeds maximum of 2",
+		},
+		{
+			selector: "$.store.book[?(@.author =~ '(Nigel|Evelyn){2,10}')]",
+			options:  []Option{limits(option.Limits{MaxRegexComplexity: 20})},
+			err:      "limit exceeded. regex complexity exceeds maximum of 20",
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, err := Query(test.selector, sampleDataObject, test.options...)
+			assert.EqualError(t, err, test.err)
+			assert.True(t, goErr.Is(err, errors.ErrLimitExceeded))
+			assert.Nil(t, actual)
+		})
+	}
+
+	t.Run("compile", func(t *testing.T) {
+		selector, err := Compile("$[?(@.a =~ '(x+x+)+y{1,100}')]", limits(option.Limits{MaxRegexComplexity: 5}))
+		assert.EqualError(t, err, "limit exceeded. regex complexity exceeds maximum of 5")
+		assert.True(t, goErr.Is(err, errors.ErrLimitExceeded))
+		assert.Nil(t, selector)
+	})
+
+	t.Run("within limits", func(t *testing.T) {
+		actual, err := Query("$..book[?(@.author =~ 'Nigel.*')].title", sampleDataObject, limits(option.Limits{
+			MaxSelectorLength:  100,
+			MaxTokens:          5,
+			MaxRecursionDepth:  5,
+			MaxResults:         10,
+			MaxExpressionDepth: 2,
+			MaxRegexComplexity: 20,
+		}))
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"Sayings of the Century"}, actual)
+	})
+}
@@ -0,0 +1,20 @@
+package option
+
+// Limits represents the resource limits enforced when compiling and querying a selector,
+// which should be set when selectors are supplied by untrusted users.
+//
+// A limit of zero, the default, is not enforced.
+type Limits struct {
+	// MaxSelectorLength the maximum length, in bytes, of a selector.
+	MaxSelectorLength int
+	// MaxTokens the maximum number of tokens, or RFC 9535 segments, in a selector.
+	MaxTokens int
+	// MaxRecursionDepth the maximum depth below the current value that recursive descent will descend to.
+	MaxRecursionDepth int
+	// MaxResults the maximum number of values or nodes that can be matched by a query or a recursive descent.
+	MaxResults int
+	// MaxExpressionDepth the maximum nesting of brackets and parentheses in a script or filter expression.
+	MaxExpressionDepth int
+	// MaxRegexComplexity the maximum number of instructions in the compiled regular expression of the =~ operator.
+	MaxRegexComplexity int
+}
@@ -26,4 +26,30 @@ type QueryOptions struct {
 
 	// FailUnionOnInvalidIdentifier force union tokens to fail on missing or invalid keys or invalid index.
 	FailUnionOnInvalidIdentifier bool
+
+	// DefaultPathLeafToNull return nil for a missing key or index at the end of a selector rather than an error,
+	// and keep the nil results of the tokens that follow wildcard, range, union, and filter tokens.
+	DefaultPathLeafToNull bool
+	// RequireProperties force wildcard, range, union, and filter tokens to fail when a key that follows them is missing,
+	// rather than skipping the elements that do not include the key.
+	RequireProperties bool
+	// SuppressExceptions return nil, or an empty list, rather than an error when a query can not be applied to the data.
+	SuppressExceptions bool
+
+	// CaseInsensitiveKeys match the keys of maps and the fields of structs to the keys of key and union tokens regardless of case.
+	CaseInsensitiveKeys bool
+	// NormalizeKeys match the keys of maps and the fields of structs to the keys of key and union tokens after Unicode NFC normalization.
+	NormalizeKeys bool
+
+	// AlwaysReturnList return the list of matched values from Query, even when the selector can only match a single value.
+	AlwaysReturnList bool
+
+	// UseNumber decode numbers in JSON data as json.Number rather than float64 to preserve their precision.
+	UseNumber bool
+	// PreserveKeyOrder decode objects in JSON data as ordered.Object rather than map[string]interface{} to preserve the order
+	// of their keys, so wildcard, recursive, and other tokens select their members in the order they appear in the data.
+	PreserveKeyOrder bool
+
+	// Limits the resource limits enforced when compiling and querying selectors.
+	Limits Limits
 }
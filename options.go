@@ -5,6 +5,17 @@ import (
 	"github.com/evilmonkeyinc/jsonpath/script"
 )
 
+// Specification represents a JSONPath specification that a selector can be compiled against
+type Specification string
+
+const (
+	// Goessner the original JSONPath proposal, along with the extensions supported by this library, this is the default
+	Goessner Specification = "goessner"
+	// RFC9535 the JSONPath standard as published in RFC 9535, only the standard syntax is accepted
+	// and queries always return a list of the selected values
+	RFC9535 Specification = "rfc9535"
+)
+
 // OptionFunction function that can be used as a compile or query option
 type OptionFunction func(selector *Selector) error
 
@@ -39,3 +50,20 @@ func QueryOptions(options *option.QueryOptions) Option {
 		return errOptionAlreadySet
 	})
 }
+
+// Standard allows you to set the JSONPath specification the selector is compiled and evaluated against
+func Standard(specification Specification) Option {
+	return OptionFunction(func(selector *Selector) error {
+		switch specification {
+		case Goessner, RFC9535:
+		default:
+			return getUnsupportedSpecificationError(specification)
+		}
+
+		if selector.standard == "" {
+			selector.standard = specification
+			return nil
+		}
+		return errOptionAlreadySet
+	})
+}
@@ -93,3 +93,32 @@ func Test_QueryOptions(t *testing.T) {
 		assert.NotEqual(t, input2, selector.Options)
 	})
 }
+
+func Test_Standard(t *testing.T) {
+	t.Run("first", func(t *testing.T) {
+		option := Standard(RFC9535)
+		selector := &Selector{}
+
+		err := option.Apply(selector)
+		assert.Nil(t, err)
+		assert.Equal(t, RFC9535, selector.standard)
+	})
+	t.Run("second", func(t *testing.T) {
+		selector := &Selector{}
+
+		err := Standard(RFC9535).Apply(selector)
+		assert.Nil(t, err)
+
+		err = Standard(Goessner).Apply(selector)
+		assert.EqualError(t, err, "option already set")
+
+		assert.Equal(t, RFC9535, selector.standard)
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		selector := &Selector{}
+
+		err := Standard("draft").Apply(selector)
+		assert.EqualError(t, err, "unsupported specification 'draft'")
+		assert.Equal(t, Specification(""), selector.standard)
+	})
+}
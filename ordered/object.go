@@ -0,0 +1,199 @@
+package ordered
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Member is a key and value of an Object
+type Member struct {
+	Key   string
+	Value interface{}
+}
+
+// Object is a JSON object that keeps its members in the order they were added, or the order
+// they appear in the JSON data it was decoded from, rather than the sorted order of a map.
+//
+// The zero value is an empty object ready to use.
+type Object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// NewObject returns an object with the members in the order they are passed, a member with
+// the same key as an earlier member replaces its value but keeps its position.
+func NewObject(members ...Member) *Object {
+	object := &Object{
+		keys:   make([]string, 0, len(members)),
+		values: make(map[string]interface{}, len(members)),
+	}
+	for _, member := range members {
+		object.Set(member.Key, member.Value)
+	}
+	return object
+}
+
+// Len returns the number of members of the object
+func (object *Object) Len() int {
+	if object == nil {
+		return 0
+	}
+	return len(object.keys)
+}
+
+// Keys returns the keys of the members of the object in order
+func (object *Object) Keys() []string {
+	if object == nil {
+		return []string{}
+	}
+	keys := make([]string, len(object.keys))
+	copy(keys, object.keys)
+	return keys
+}
+
+// Members returns the members of the object in order
+func (object *Object) Members() []Member {
+	members := make([]Member, object.Len())
+	for idx := range members {
+		key := object.keys[idx]
+		members[idx] = Member{Key: key, Value: object.values[key]}
+	}
+	return members
+}
+
+// Get returns the value of the member with the key, and if the object has the member
+func (object *Object) Get(key string) (interface{}, bool) {
+	if object == nil {
+		return nil, false
+	}
+	value, ok := object.values[key]
+	return value, ok
+}
+
+// Set sets the value of the member with the key, a new member is added after the existing members
+func (object *Object) Set(key string, value interface{}) {
+	if object.values == nil {
+		object.values = make(map[string]interface{})
+	}
+	if _, ok := object.values[key]; !ok {
+		object.keys = append(object.keys, key)
+	}
+	object.values[key] = value
+}
+
+// Delete removes the member with the key, returning true if the object had the member
+func (object *Object) Delete(key string) bool {
+	if object == nil {
+		return false
+	}
+	if _, ok := object.values[key]; !ok {
+		return false
+	}
+	delete(object.values, key)
+	for idx, existing := range object.keys {
+		if existing == key {
+			object.keys = append(object.keys[:idx], object.keys[idx+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// MarshalJSON returns the JSON encoding of the object with its members in order
+func (object *Object) MarshalJSON() ([]byte, error) {
+	if object == nil {
+		return []byte("null"), nil
+	}
+
+	buffer := &bytes.Buffer{}
+	buffer.WriteByte('{')
+	for idx, key := range object.keys {
+		if idx > 0 {
+			buffer.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedValue, err := json.Marshal(object.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(encodedKey)
+		buffer.WriteByte(':')
+		buffer.Write(encodedValue)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object, nested objects are decoded as ordered objects
+func (object *Object) UnmarshalJSON(data []byte) error {
+	value, err := Decode(json.NewDecoder(bytes.NewReader(data)))
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		// null leaves the object unchanged, in the same way as other values
+		return nil
+	}
+	decoded, ok := value.(*Object)
+	if !ok {
+		return fmt.Errorf("json: cannot unmarshal %T into Go value of type ordered.Object", value)
+	}
+	*object = *decoded
+	return nil
+}
+
+// Decode reads the next JSON value from the decoder in the same way as decoding into an empty interface,
+// except objects are decoded as ordered objects. Numbers are decoded as json.Number if UseNumber is set
+// on the decoder.
+func Decode(decoder *json.Decoder) (interface{}, error) {
+	next, err := decoder.Token()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return decodeToken(decoder, next)
+}
+
+func decodeToken(decoder *json.Decoder, next json.Token) (interface{}, error) {
+	switch next {
+	case json.Delim('{'):
+		object := NewObject()
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyToken.(string)
+			value, err := Decode(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object.Set(key, value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return object, nil
+	case json.Delim('['):
+		array := make([]interface{}, 0)
+		for decoder.More() {
+			value, err := Decode(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return array, nil
+	}
+	return next, nil
+}
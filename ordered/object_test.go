@@ -0,0 +1,230 @@
+package ordered
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewObject(t *testing.T) {
+	object := NewObject(
+		Member{Key: "b", Value: 1},
+		Member{Key: "a", Value: 2},
+		Member{Key: "b", Value: 3},
+	)
+	assert.Equal(t, 2, object.Len())
+	assert.Equal(t, []string{"b", "a"}, object.Keys())
+	assert.Equal(t, []Member{{Key: "b", Value: 3}, {Key: "a", Value: 2}}, object.Members())
+}
+
+func Test_Object(t *testing.T) {
+	t.Run("zero value", func(t *testing.T) {
+		object := &Object{}
+		assert.Equal(t, 0, object.Len())
+		assert.Equal(t, []string{}, object.Keys())
+
+		object.Set("key", "value")
+		value, ok := object.Get("key")
+		assert.True(t, ok)
+		assert.Equal(t, "value", value)
+	})
+	t.Run("nil", func(t *testing.T) {
+		var object *Object
+		assert.Equal(t, 0, object.Len())
+		assert.Equal(t, []string{}, object.Keys())
+		assert.Equal(t, []Member{}, object.Members())
+		_, ok := object.Get("key")
+		assert.False(t, ok)
+		assert.False(t, object.Delete("key"))
+	})
+	t.Run("set", func(t *testing.T) {
+		object := NewObject(Member{Key: "c", Value: 1}, Member{Key: "b", Value: 2})
+		object.Set("c", 3)
+		object.Set("a", 4)
+		assert.Equal(t, []Member{{Key: "c", Value: 3}, {Key: "b", Value: 2}, {Key: "a", Value: 4}}, object.Members())
+	})
+	t.Run("delete", func(t *testing.T) {
+		object := NewObject(Member{Key: "c", Value: 1}, Member{Key: "b", Value: 2}, Member{Key: "a", Value: 3})
+		assert.True(t, object.Delete("b"))
+		assert.False(t, object.Delete("b"))
+		assert.Equal(t, []string{"c", "a"}, object.Keys())
+
+		_, ok := object.Get("b")
+		assert.False(t, ok)
+
+		object.Set("b", 4)
+		assert.Equal(t, []string{"c", "a", "b"}, object.Keys())
+	})
+	t.Run("keys copy", func(t *testing.T) {
+		object := NewObject(Member{Key: "a", Value: 1})
+		keys := object.Keys()
+		keys[0] = "changed"
+		assert.Equal(t, []string{"a"}, object.Keys())
+	})
+}
+
+func Test_Object_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		input    interface{}
+		expected string
+	}{
+		{
+			input:    NewObject(),
+			expected: `{}`,
+		},
+		{
+			input:    (*Object)(nil),
+			expected: `null`,
+		},
+		{
+			input: NewObject(
+				Member{Key: "z", Value: "one"},
+				Member{Key: "a", Value: []interface{}{1, NewObject(Member{Key: "y", Value: true}, Member{Key: "b", Value: nil})}},
+				Member{Key: "quote\"", Value: 1.5},
+			),
+			expected: `{"z":"one","a":[1,{"y":true,"b":null}],"quote\"":1.5}`,
+		},
+		{
+			input:    map[string]interface{}{"object": NewObject(Member{Key: "b", Value: 1}, Member{Key: "a", Value: 2})},
+			expected: `{"object":{"b":1,"a":2}}`,
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, err := json.Marshal(test.input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		_, err := json.Marshal(NewObject(Member{Key: "func", Value: func() {}}))
+		assert.EqualError(t, err, "json: error calling MarshalJSON for type *ordered.Object: json: unsupported type: func()")
+	})
+}
+
+func Test_Object_UnmarshalJSON(t *testing.T) {
+	t.Run("object", func(t *testing.T) {
+		object := Object{}
+		err := json.Unmarshal([]byte(`{"b": {"d": 1, "c": 2}, "a": [true]}`), &object)
+		assert.Nil(t, err)
+		assert.Equal(t, *NewObject(
+			Member{Key: "b", Value: NewObject(Member{Key: "d", Value: float64(1)}, Member{Key: "c", Value: float64(2)})},
+			Member{Key: "a", Value: []interface{}{true}},
+		), object)
+	})
+	t.Run("field", func(t *testing.T) {
+		target := struct {
+			Object *Object `json:"object"`
+		}{}
+		err := json.Unmarshal([]byte(`{"object": {"b": 1, "a": 2}}`), &target)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"b", "a"}, target.Object.Keys())
+	})
+	t.Run("null", func(t *testing.T) {
+		object := NewObject(Member{Key: "a", Value: 1})
+		err := json.Unmarshal([]byte(`null`), object)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a"}, object.Keys())
+	})
+	t.Run("not object", func(t *testing.T) {
+		object := Object{}
+		err := json.Unmarshal([]byte(`[1]`), &object)
+		assert.EqualError(t, err, "json: cannot unmarshal []interface {} into Go value of type ordered.Object")
+	})
+}
+
+func Test_Decode(t *testing.T) {
+	type input struct {
+		data      string
+		useNumber bool
+	}
+
+	type expected struct {
+		value interface{}
+		err   string
+	}
+
+	tests := []struct {
+		input    input
+		expected expected
+	}{
+		{
+			input: input{data: `{"b": 1, "a": {"d": "x", "c": null}}`},
+			expected: expected{
+				value: NewObject(
+					Member{Key: "b", Value: float64(1)},
+					Member{Key: "a", Value: NewObject(Member{Key: "d", Value: "x"}, Member{Key: "c", Value: nil})},
+				),
+			},
+		},
+		{
+			input: input{data: `[{"b": 1, "a": 2}, [], "three", false]`},
+			expected: expected{
+				value: []interface{}{
+					NewObject(Member{Key: "b", Value: float64(1)}, Member{Key: "a", Value: float64(2)}),
+					[]interface{}{},
+					"three",
+					false,
+				},
+			},
+		},
+		{
+			input: input{data: `{"b": 9007199254740993}`, useNumber: true},
+			expected: expected{
+				value: NewObject(Member{Key: "b", Value: json.Number("9007199254740993")}),
+			},
+		},
+		{
+			input: input{data: `1.5`},
+			expected: expected{
+				value: 1.5,
+			},
+		},
+		{
+			input: input{data: ``},
+			expected: expected{
+				err: "unexpected EOF",
+			},
+		},
+		{
+			input: input{data: `{"b": 1`},
+			expected: expected{
+				err: "unexpected end of JSON input",
+			},
+		},
+		{
+			input: input{data: `{"b": 1,}`},
+			expected: expected{
+				err: "invalid character ',' looking for beginning of value",
+			},
+		},
+		{
+			input: input{data: `[1,]`},
+			expected: expected{
+				err: "invalid character ',' looking for beginning of value",
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			decoder := json.NewDecoder(strings.NewReader(test.input.data))
+			if test.input.useNumber {
+				decoder.UseNumber()
+			}
+			actual, err := Decode(decoder)
+			if test.expected.err != "" {
+				assert.EqualError(t, err, test.expected.err)
+				assert.Nil(t, actual)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected.value, actual)
+		})
+	}
+}
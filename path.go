@@ -0,0 +1,69 @@
+package jsonpath
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/evilmonkeyinc/jsonpath/token"
+)
+
+// getModifiablePaths returns the unique paths of the nodes ordered so that they can be safely modified
+// one at a time, deepest paths first and the elements of the same collection in reverse order.
+// Nodes of missing keys and indices are skipped as they have no location to modify, and an error is returned
+// for nodes with computed values.
+func getModifiablePaths(nodes []*token.Node) ([]token.Path, error) {
+	paths := make([]token.Path, 0)
+	found := make(map[string]bool)
+	for _, node := range nodes {
+		if node.Computed {
+			return nil, getInvalidPathComputedError(node.Path)
+		}
+		if node.Missing {
+			continue
+		}
+		key := node.Path.String()
+		if found[key] {
+			continue
+		}
+		found[key] = true
+		paths = append(paths, node.Path)
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		one, two := paths[i], paths[j]
+		if len(one) != len(two) {
+			return len(one) > len(two)
+		}
+		for idx := range one {
+			if compare := comparePathElements(one[idx], two[idx]); compare != 0 {
+				return compare > 0
+			}
+		}
+		return false
+	})
+
+	return paths, nil
+}
+
+func comparePathElements(one, two interface{}) int {
+	if oneInt, ok := one.(int); ok {
+		if twoInt, ok := two.(int); ok {
+			switch {
+			case oneInt < twoInt:
+				return -1
+			case oneInt > twoInt:
+				return 1
+			}
+			return 0
+		}
+	}
+
+	oneString, twoString := fmt.Sprint(one), fmt.Sprint(two)
+	switch {
+	case oneString < twoString:
+		return -1
+	case oneString > twoString:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,36 @@
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getModifiablePaths(t *testing.T) {
+	nodes := []*token.Node{
+		{Path: token.Path{"a"}},
+		{Path: token.Path{"a", 2}},
+		{Path: token.Path{"a", 10}},
+		{Path: token.Path{"b", "c"}},
+		{Path: token.Path{"a", 2}},
+		{Path: token.Path{}},
+		{Path: token.Path{"a", 2, "d"}},
+		{Path: token.Path{"a", "e"}, Missing: true},
+	}
+
+	paths, err := getModifiablePaths(nodes)
+	assert.Nil(t, err)
+	assert.Equal(t, []token.Path{
+		{"a", 2, "d"},
+		{"b", "c"},
+		{"a", 10},
+		{"a", 2},
+		{"a"},
+		{},
+	}, paths)
+
+	paths, err = getModifiablePaths(append(nodes, &token.Node{Path: token.Path{"a", "length"}, Computed: true}))
+	assert.EqualError(t, err, "invalid path $['a']['length']. value is computed and is not a location in the data")
+	assert.Nil(t, paths)
+}
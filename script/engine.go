@@ -1,6 +1,11 @@
 package script
 
-import "github.com/evilmonkeyinc/jsonpath/option"
+import (
+	"context"
+
+	"github.com/evilmonkeyinc/jsonpath/ast"
+	"github.com/evilmonkeyinc/jsonpath/option"
+)
 
 // Engine represents a script engine used by the JSONPath query parser
 type Engine interface {
@@ -15,3 +20,17 @@ type CompiledExpression interface {
 	// Evaluate return the result of the expression evaluation
 	Evaluate(root, current interface{}) (interface{}, error)
 }
+
+// CompiledExpressionContext is implemented by compiled expressions that support cancellation,
+// the context of a query is passed to the expression when it is evaluated.
+type CompiledExpressionContext interface {
+	// EvaluateContext return the result of the expression evaluation, or the context error if it is done
+	EvaluateContext(ctx context.Context, root, current interface{}) (interface{}, error)
+}
+
+// CompiledExpressionTree is implemented by compiled expressions that can describe their syntax tree,
+// expressions that do not implement it are described as an *ast.RawExpression.
+type CompiledExpressionTree interface {
+	// Tree returns the syntax tree of the expression
+	Tree() ast.Expression
+}
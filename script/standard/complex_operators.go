@@ -12,6 +12,8 @@ import (
 
 type regexOperator struct {
 	arg1, arg2 interface{}
+	// maxComplexity the maximum complexity of the regular expression, not enforced if zero
+	maxComplexity int
 }
 
 func (op *regexOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
@@ -33,6 +35,17 @@ func (op *regexOperator) Evaluate(parameters map[string]interface{}) (interface{
 		pattern = pattern[1 : len(pattern)-1]
 	}
 
+	if _, isLiteral := getQuotedLiteral(op.arg2); !isLiteral && op.maxComplexity > 0 {
+		// the complexity of a literal pattern is checked when the expression is compiled
+		complexity, err := getRegexComplexity(pattern)
+		if err != nil {
+			return nil, errInvalidArgumentExpectedRegex
+		}
+		if complexity > op.maxComplexity {
+			return nil, getLimitExceededError("regex complexity", op.maxComplexity)
+		}
+	}
+
 	regex, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, errInvalidArgumentExpectedRegex
@@ -41,22 +54,25 @@ func (op *regexOperator) Evaluate(parameters map[string]interface{}) (interface{
 	return regex.Match([]byte(b)), nil
 }
 
-func newSelectorOperator(selector string, engine script.Engine, options *option.QueryOptions) (*selectorOperator, error) {
-	tokens := make([]token.Token, 0)
+// checkComplexity returns an error if the pattern is a quoted literal that exceeds the maximum complexity,
+// an invalid pattern is left to fail when the expression is evaluated.
+func (op *regexOperator) checkComplexity() error {
+	pattern, isLiteral := getQuotedLiteral(op.arg2)
+	if !isLiteral || op.maxComplexity <= 0 {
+		return nil
+	}
+	if complexity, err := getRegexComplexity(pattern); err == nil && complexity > op.maxComplexity {
+		return getLimitExceededError("regex complexity", op.maxComplexity)
+	}
+	return nil
+}
 
-	split, err := token.Tokenize(selector)
+func newSelectorOperator(selector string, engine script.Engine, options *option.QueryOptions) (*selectorOperator, error) {
+	tokens, err := token.ParseSelector(selector, engine, options)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, str := range split {
-		token, err := token.Parse(str, engine, options)
-		if err != nil {
-			return nil, err
-		}
-		tokens = append(tokens, token)
-	}
-
 	return &selectorOperator{
 		selector: selector,
 		tokens:   tokens,
@@ -77,7 +93,7 @@ func (op *selectorOperator) Evaluate(parameters map[string]interface{}) (interfa
 		next = op.tokens[1:]
 	}
 
-	value, err := op.tokens[0].Apply(root, current, next)
+	value, err := op.tokens[0].Apply(getContext(parameters), root, current, next)
 	if err != nil {
 		return nil, err
 	}
@@ -90,14 +106,31 @@ func (op *selectorOperator) Evaluate(parameters map[string]interface{}) (interfa
 	return value, nil
 }
 
+// nodes returns the nodes selected by the selector, a selector that fails to match selects no nodes
+func (op *selectorOperator) nodes(parameters map[string]interface{}) ([]*token.Node, error) {
+	root := parameters["$"]
+	current := parameters["@"]
+
+	ctx := getContext(parameters)
+	nodes, err := op.tokens[0].ApplyNodes(ctx, root, &token.Node{Path: token.Path{}, Value: current}, op.tokens[1:])
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return []*token.Node{}, nil
+	}
+	return nodes, nil
+}
+
 type inOperator struct {
 	arg1, arg2 interface{}
 }
 
 func (op *inOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
 	var item interface{} = op.arg1
-	if numValue, err := getNumber(op.arg1, parameters); err == nil {
-		item = numValue
+	itemNumber, err := getPreciseNumber(op.arg1, parameters)
+	if err == nil {
+		item = itemNumber.float
 	} else if strValue, err := getString(op.arg1, parameters); err == nil {
 		item = strValue
 	} else if boolValue, err := getBoolean(op.arg1, parameters); err == nil {
@@ -113,6 +146,13 @@ func (op *inOperator) Evaluate(parameters map[string]interface{}) (interface{},
 		if element == item {
 			return true, nil
 		}
+		if _, isString := element.(string); itemNumber != nil && !isString {
+			if elementNumber, ok := newNumber(element); ok {
+				if comparison, ok := compareNumbers(itemNumber, elementNumber); ok && comparison == 0 {
+					return true, nil
+				}
+			}
+		}
 	}
 
 	return false, nil
@@ -1,6 +1,7 @@
 package standard
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -85,6 +86,42 @@ func Test_regexOperator(t *testing.T) {
 				value: true,
 			},
 		},
+		{
+			input: operatorTestInput{
+				operator: &regexOperator{
+					arg1:          "aaa",
+					arg2:          `a+`,
+					maxComplexity: 10,
+				},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &regexOperator{
+					arg1:          "aaa",
+					arg2:          `(a|b){20}`,
+					maxComplexity: 10,
+				},
+			},
+			expected: operatorTestExpected{
+				err: "limit exceeded. regex complexity exceeds maximum of 10",
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &regexOperator{
+					arg1:          "string",
+					arg2:          `\`,
+					maxComplexity: 10,
+				},
+			},
+			expected: operatorTestExpected{
+				err: "invalid argument. expected a valid regexp",
+			},
+		},
 	}
 	batchOperatorTests(t, tests)
 }
@@ -214,6 +251,42 @@ func Test_inOperator(t *testing.T) {
 				value: true,
 			},
 		},
+		{
+			input: operatorTestInput{
+				operator:  &inOperator{arg1: "9007199254740993", arg2: []interface{}{json.Number("9007199254740992"), json.Number("9007199254740993")}},
+				paramters: map[string]interface{}{},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator:  &inOperator{arg1: "9007199254740993", arg2: []interface{}{json.Number("9007199254740992"), "9007199254740993"}},
+				paramters: map[string]interface{}{},
+			},
+			expected: operatorTestExpected{
+				value: false,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator:  &inOperator{arg1: json.Number("9007199254740992"), arg2: `[9007199254740993]`},
+				paramters: map[string]interface{}{},
+			},
+			expected: operatorTestExpected{
+				value: false,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator:  &inOperator{arg1: json.Number("9007199254740993"), arg2: `[1, 9007199254740993]`},
+				paramters: map[string]interface{}{},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
 		{
 			input: operatorTestInput{
 				operator:  &inOperator{arg1: "one", arg2: `{"1":"one","2":"two"}`},
@@ -1,11 +1,11 @@
 package standard
 
 import (
-	"encoding/json"
 	"strings"
 
 	"github.com/evilmonkeyinc/jsonpath/option"
 	"github.com/evilmonkeyinc/jsonpath/script"
+	"github.com/evilmonkeyinc/jsonpath/token"
 )
 
 // TODO : add support for bitwise operators | &^ ^ &  << >> after + and -
@@ -19,14 +19,57 @@ var defaultTokens []string = []string{
 
 // ScriptEngine standard implementation of the script engine interface
 type ScriptEngine struct {
+	// RFC9535 when true expressions are parsed and evaluated as RFC 9535 filter expressions
+	RFC9535 bool
+
+	functions map[string]*Function
+}
+
+// RegisterFunction registers a custom function that can be called within expressions using the name,
+// registration is not safe for concurrent use so functions should be registered before the engine is used.
+func (engine *ScriptEngine) RegisterFunction(name string, function Function) error {
+	if !functionNamePattern.MatchString(name) {
+		return getInvalidFunctionNameError(name)
+	}
+	if _, ok := engine.getFunction(name); ok {
+		return getFunctionAlreadyRegisteredError(name)
+	}
+	if function.Evaluate == nil {
+		return errInvalidFunctionEvaluateNil
+	}
+	for _, functionType := range append([]FunctionType{function.Result}, function.Parameters...) {
+		if functionType < ValueType || functionType > NodesType {
+			return errInvalidFunctionUnsupportedType
+		}
+	}
+
+	registered := Function{
+		Parameters: append([]FunctionType{}, function.Parameters...),
+		Result:     function.Result,
+		Evaluate:   function.Evaluate,
+	}
+	if engine.functions == nil {
+		engine.functions = make(map[string]*Function)
+	}
+	engine.functions[name] = &registered
+	return nil
 }
 
 // Compile returns a compiled expression that can be evaluated multiple times
 func (engine *ScriptEngine) Compile(expression string, options *option.QueryOptions) (script.CompiledExpression, error) {
-	tokens := defaultTokens
-	operator, err := engine.buildOperators(expression, tokens, options)
+	if maximum := token.Limits(options).MaxExpressionDepth; maximum > 0 && getExpressionDepth(expression) > maximum {
+		return nil, getLimitExceededError("expression depth", maximum)
+	}
+
+	var operator operator
+	var err error
+	if engine.RFC9535 {
+		operator, err = engine.compileRFC9535(expression, options)
+	} else {
+		operator, err = engine.buildOperators(expression, defaultTokens, options)
+	}
 	if err != nil {
-		return nil, err
+		return nil, token.RelocateSyntaxError(err, expression, 0)
 	}
 
 	return &compiledExpression{
@@ -50,16 +93,32 @@ func (engine *ScriptEngine) Evaluate(root, current interface{}, expression strin
 	return evaluation, nil
 }
 
+// buildOperators returns the operator represented by the expression, errors are returned as a syntax error
+// positioned within the expression.
 func (engine *ScriptEngine) buildOperators(expression string, tokens []string, options *option.QueryOptions) (operator, error) {
+	operator, err := engine.buildOperator(expression, tokens, options)
+	if err != nil {
+		return nil, token.RelocateSyntaxError(err, expression, 0)
+	}
+	return operator, nil
+}
+
+func (engine *ScriptEngine) buildOperator(expression string, tokens []string, options *option.QueryOptions) (operator, error) {
 	nextToken := tokens[0]
 	expression = strings.TrimSpace(expression)
 	if expression == "" {
 		return nil, nil
 	}
-	if strings.HasPrefix(expression, "(") && strings.HasSuffix(expression, ")") {
-		expression = strings.TrimSpace(expression[1 : len(expression)-1])
+	if name, arguments, ok := splitFunctionCall(expression); ok {
+		operator, err := engine.buildFunctionOperator(name, arguments, options)
+		if err != nil && !isSyntaxError(err) {
+			return nil, token.NewSyntaxError(err, expression, 0, name)
+		}
+		return operator, err
+	}
+	if isWrappedInParentheses(expression) {
 		// since we were in brackets, we need to try all the tokens again
-		tokens = append([]string{""}, defaultTokens...)
+		return engine.buildOperators(expression[1:len(expression)-1], defaultTokens, options)
 	}
 
 	idx := findUnquotedOperators(expression, nextToken)
@@ -84,7 +143,7 @@ func (engine *ScriptEngine) buildOperators(expression string, tokens []string, o
 
 	rightside, err := engine.parseArgument(rightsideString, tokens, options)
 	if err != nil {
-		return nil, err
+		return nil, token.RelocateSyntaxError(err, expression, idx+len(nextToken))
 	}
 
 	// check left for more tokens, or use raw string as input
@@ -117,10 +176,15 @@ func (engine *ScriptEngine) buildOperators(expression string, tokens []string, o
 		}
 		return selector, nil
 	case "=~":
-		return &regexOperator{
-			arg1: leftside,
-			arg2: rightside,
-		}, nil
+		regex := &regexOperator{
+			arg1:          leftside,
+			arg2:          rightside,
+			maxComplexity: token.Limits(options).MaxRegexComplexity,
+		}
+		if err := regex.checkComplexity(); err != nil {
+			return nil, err
+		}
+		return regex, nil
 	case "not in":
 		return &notInOperator{
 			arg1: leftside,
@@ -219,6 +283,88 @@ func (engine *ScriptEngine) buildOperators(expression string, tokens []string, o
 	return nil, errUnsupportedOperator
 }
 
+func (engine *ScriptEngine) buildFunctionOperator(name string, arguments []string, options *option.QueryOptions) (operator, error) {
+	function, ok := engine.getFunction(name)
+	if !ok {
+		return nil, getUnknownFunctionError(name)
+	}
+	if len(arguments) != len(function.Parameters) {
+		return nil, getInvalidFunctionArgumentCountError(name, len(function.Parameters), len(arguments))
+	}
+
+	args := make([]interface{}, len(arguments))
+	for idx, argument := range arguments {
+		arg, err := engine.parseArgument(argument, defaultTokens, options)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkFunctionArgument(function.Parameters[idx], arg); err != nil {
+			return nil, err
+		}
+		args[idx] = arg
+	}
+
+	return &functionOperator{
+		name:      name,
+		function:  function,
+		arguments: args,
+	}, nil
+}
+
+// checkFunctionArgument returns an error if the argument is not well-typed for the parameter,
+// using the same rules as the arguments of functions in RFC 9535 expressions
+func checkFunctionArgument(parameter FunctionType, argument interface{}) error {
+	nested, isFunction := argument.(*functionOperator)
+	_, isQuery := argument.(nodesOperator)
+
+	switch parameter {
+	case ValueType:
+		if isFunction && nested.function.Result != ValueType {
+			return errInvalidArgumentExpectedValue
+		}
+		if selector, ok := argument.(*selectorOperator); ok && !token.IsDefinite(selector.tokens) {
+			return errInvalidArgumentExpectedValue
+		}
+		if isLogicalOperator(argument) {
+			return errInvalidArgumentExpectedValue
+		}
+	case NodesType:
+		if !isQuery && (!isFunction || nested.function.Result != NodesType) {
+			return errInvalidArgumentExpectedNodes
+		}
+	case LogicalType:
+		if isFunction && nested.function.Result == ValueType {
+			return errInvalidArgumentExpectedLogical
+		}
+		if !isQuery && !isFunction && !isLogicalOperator(argument) {
+			return errInvalidArgumentExpectedLogical
+		}
+	}
+	return nil
+}
+
+// isLogicalOperator returns true if the operator is a comparison or logical operator that evaluates to a boolean
+func isLogicalOperator(argument interface{}) bool {
+	switch argument.(type) {
+	case *andOperator, *orOperator, *notOperator,
+		*equalsOperator, *notEqualsOperator,
+		*lessThanOperator, *lessThanOrEqualOperator,
+		*greaterThanOperator, *greaterThanOrEqualOperator,
+		*regexOperator, *inOperator, *notInOperator:
+		return true
+	}
+	return false
+}
+
+// getFunction returns the standard or registered function that can be called using the name
+func (engine *ScriptEngine) getFunction(name string) (*Function, bool) {
+	if function, ok := standardFunctions[name]; ok {
+		return function, true
+	}
+	function, ok := engine.functions[name]
+	return function, ok
+}
+
 func (engine *ScriptEngine) parseArgument(argument string, tokens []string, options *option.QueryOptions) (interface{}, error) {
 	if op, err := engine.buildOperators(argument, tokens, options); err != nil {
 		return nil, err
@@ -231,15 +377,10 @@ func (engine *ScriptEngine) parseArgument(argument string, tokens []string, opti
 		return nil, nil
 	}
 
-	var arg interface{} = argument
-	if strings.HasPrefix(argument, "[") && strings.HasSuffix(argument, "]") {
-		val := make([]interface{}, 0)
-		if err := json.Unmarshal([]byte(argument), &val); err == nil {
-			arg = val
-		}
-	} else if strings.HasPrefix(argument, "{") && strings.HasSuffix(argument, "}") {
-		val := make(map[string]interface{})
-		if err := json.Unmarshal([]byte(argument), &val); err == nil {
+	var arg interface{} = getStringLiteral(argument)
+	if (strings.HasPrefix(argument, "[") && strings.HasSuffix(argument, "]")) ||
+		(strings.HasPrefix(argument, "{") && strings.HasSuffix(argument, "}")) {
+		if val, err := decodeLiteral(argument); err == nil {
 			arg = val
 		}
 	}
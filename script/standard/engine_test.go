@@ -2,8 +2,10 @@ package standard
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/evilmonkeyinc/jsonpath/errors"
 	"github.com/evilmonkeyinc/jsonpath/option"
 	"github.com/evilmonkeyinc/jsonpath/script"
 	"github.com/stretchr/testify/assert"
@@ -84,6 +86,33 @@ func Test_ScriptEngine_Compile(t *testing.T) {
 				err: "invalid token. '[]' does not match any token format",
 			},
 		},
+		{
+			input: input{
+				expression: "((@.a[0] + 1) * 2) == 4",
+				options:    &option.QueryOptions{Limits: option.Limits{MaxExpressionDepth: 2}},
+			},
+			expected: expected{
+				err: "limit exceeded. expression depth exceeds maximum of 2",
+			},
+		},
+		{
+			input: input{
+				expression: "@.a =~ '(x+x+)+y{1,100}'",
+				options:    &option.QueryOptions{Limits: option.Limits{MaxRegexComplexity: 5}},
+			},
+			expected: expected{
+				err: "limit exceeded. regex complexity exceeds maximum of 5",
+			},
+		},
+		{
+			input: input{
+				expression: `@.a =~ "(x+x+)+y{1,100}"`,
+				options:    &option.QueryOptions{Limits: option.Limits{MaxRegexComplexity: 5}},
+			},
+			expected: expected{
+				err: "limit exceeded. regex complexity exceeds maximum of 5",
+			},
+		},
 	}
 
 	for idx, test := range tests {
@@ -227,6 +256,125 @@ func Test_ScriptEngine_Evaluate(t *testing.T) {
 				err: "invalid token. '[]' does not match any token format",
 			},
 		},
+		{
+			input: input{
+				expression: "length(@.name) == 11",
+				current: map[string]interface{}{
+					"name": "hello world",
+				},
+			},
+			expected: expected{
+				value: true,
+			},
+		},
+		{
+			input: input{
+				expression: "count(@.*)",
+				current: map[string]interface{}{
+					"a": 1,
+					"b": 2,
+				},
+			},
+			expected: expected{
+				value: 2,
+			},
+		},
+		{
+			input: input{
+				expression: "match(@.name, 'hello.*') && !search(@.name, '[0-9]')",
+				current: map[string]interface{}{
+					"name": "hello world",
+				},
+			},
+			expected: expected{
+				value: true,
+			},
+		},
+		{
+			input: input{
+				expression: "value(@.name)",
+				current: map[string]interface{}{
+					"name": "hello world",
+				},
+			},
+			expected: expected{
+				value: "'hello world'",
+			},
+		},
+		{
+			input: input{
+				expression: "value(@.other)",
+				current: map[string]interface{}{
+					"name": "hello world",
+				},
+			},
+			expected: expected{
+				value: nil,
+			},
+		},
+		{
+			input: input{
+				expression: "length(@.name, 1)",
+			},
+			expected: expected{
+				err: "invalid argument. function 'length' expects 1 arguments got 2",
+			},
+		},
+		{
+			input: input{
+				expression: "count('a')",
+			},
+			expected: expected{
+				err: "invalid argument. expected query",
+			},
+		},
+		{
+			input: input{
+				expression: "now()",
+			},
+			expected: expected{
+				err: "unknown function 'now'",
+			},
+		},
+		{
+			input: input{
+				current:    map[string]interface{}{"a": `it's "x"\`},
+				expression: `@.a == 'it\'s "x"\\'`,
+			},
+			expected: expected{
+				value: true,
+			},
+		},
+		{
+			input: input{
+				current:    map[string]interface{}{"a": "aaa"},
+				expression: "@.a =~ 'a+'",
+				options:    &option.QueryOptions{Limits: option.Limits{MaxRegexComplexity: 10}},
+			},
+			expected: expected{
+				value: true,
+			},
+		},
+		{
+			input: input{
+				current:    map[string]interface{}{"a": "aaa"},
+				expression: "@.a =~ '(a|b){20}'",
+				options:    &option.QueryOptions{Limits: option.Limits{MaxRegexComplexity: 10}},
+			},
+			expected: expected{
+				err: "limit exceeded. regex complexity exceeds maximum of 10",
+			},
+		},
+		{
+			input: input{
+				current:    map[string]interface{}{"a": "aaa", "pattern": "(a|b){20}"},
+				expression: "@.a =~ @.pattern",
+				options:    &option.QueryOptions{Limits: option.Limits{MaxRegexComplexity: 10}},
+			},
+			expected: expected{
+				err: "limit exceeded. regex complexity exceeds maximum of 10",
+			},
+		},
 	}
 
 	for idx, test := range tests {
@@ -764,6 +912,32 @@ func Test_ScriptEngine_buildOperators(t *testing.T) {
 	}
 }
 
+func Test_ScriptEngine_Compile_SyntaxError(t *testing.T) {
+
+	tests := []struct {
+		expression string
+		offset     int
+		token      string
+	}{
+		{expression: "@.a == nope(@.b)", offset: 7, token: "nope"},
+		{expression: "@.a == $.b[", offset: 11, token: ""},
+	}
+
+	engine := &ScriptEngine{}
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			_, err := engine.Compile(test.expression, nil)
+			syntaxError, ok := err.(*errors.SyntaxError)
+			if !assert.True(t, ok) {
+				return
+			}
+			assert.Equal(t, test.expression, syntaxError.Selector)
+			assert.Equal(t, test.offset, syntaxError.Offset)
+			assert.Equal(t, test.token, syntaxError.Token)
+		})
+	}
+}
+
 func Test_ScriptEngine_parseArgument(t *testing.T) {
 
 	engine := &ScriptEngine{}
@@ -870,6 +1044,18 @@ func Test_Evaluate(t *testing.T) {
 			expression: "true && true || false",
 			expected:   true,
 		},
+		{
+			expression: "(1 == 2) || (2 == 2)",
+			expected:   true,
+		},
+		{
+			expression: "(10 - 4) - 2",
+			expected:   float64(4),
+		},
+		{
+			expression: "(2 + 1) * (3 - 1)",
+			expected:   float64(6),
+		},
 		{
 			expression: "true == true",
 			expected:   true,
@@ -1002,3 +1188,137 @@ func Test_Evaluate(t *testing.T) {
 	}
 
 }
+
+func Test_ScriptEngine_RegisterFunction(t *testing.T) {
+
+	isValidSku := Function{
+		Parameters: []FunctionType{ValueType},
+		Result:     LogicalType,
+		Evaluate: func(arguments []interface{}) (interface{}, error) {
+			sku, ok := arguments[0].(string)
+			return ok && strings.HasPrefix(sku, "SKU-"), nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		function Function
+		expected string
+	}{
+		{name: "isValidSku", function: isValidSku},
+		{name: "is_valid_sku2", function: isValidSku},
+		{name: "", function: isValidSku, expected: "invalid function. '' is not a valid function name"},
+		{name: "IsValidSku", function: isValidSku, expected: "invalid function. 'IsValidSku' is not a valid function name"},
+		{name: "is-valid", function: isValidSku, expected: "invalid function. 'is-valid' is not a valid function name"},
+		{name: "length", function: isValidSku, expected: "invalid function. 'length' is already registered"},
+		{name: "valid", function: Function{Parameters: []FunctionType{ValueType}, Result: LogicalType}, expected: "invalid function. evaluate is nil"},
+		{name: "valid", function: Function{Parameters: []FunctionType{FunctionType(5)}, Result: LogicalType, Evaluate: isValidSku.Evaluate}, expected: "invalid function. unsupported type"},
+		{name: "valid", function: Function{Result: FunctionType(-1), Evaluate: isValidSku.Evaluate}, expected: "invalid function. unsupported type"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			engine := &ScriptEngine{}
+			err := engine.RegisterFunction(test.name, test.function)
+			if test.expected == "" {
+				assert.Nil(t, err)
+				_, ok := engine.getFunction(test.name)
+				assert.True(t, ok)
+			} else {
+				assert.EqualError(t, err, test.expected)
+				assert.Nil(t, engine.functions)
+			}
+		})
+	}
+
+	t.Run("duplicate", func(t *testing.T) {
+		engine := &ScriptEngine{}
+		assert.Nil(t, engine.RegisterFunction("isValidSku", isValidSku))
+		assert.EqualError(t, engine.RegisterFunction("isValidSku", isValidSku), "invalid function. 'isValidSku' is already registered")
+	})
+
+	t.Run("evaluate", func(t *testing.T) {
+		daysSince := Function{
+			Parameters: []FunctionType{ValueType},
+			Result:     ValueType,
+			Evaluate: func(arguments []interface{}) (interface{}, error) {
+				created, ok := arguments[0].(float64)
+				if !ok {
+					return Nothing, nil
+				}
+				return 100 - created, nil
+			},
+		}
+		current := map[string]interface{}{
+			"sku":     "SKU-1",
+			"created": float64(90),
+		}
+
+		for _, rfc9535 := range []bool{false, true} {
+			engine := &ScriptEngine{RFC9535: rfc9535}
+			assert.Nil(t, engine.RegisterFunction("isValidSku", isValidSku))
+			assert.Nil(t, engine.RegisterFunction("daysSince", daysSince))
+
+			actual, err := engine.Evaluate(nil, current, "isValidSku(@.sku) && daysSince(@.created) < 30", nil)
+			assert.Nil(t, err)
+			assert.Equal(t, true, actual)
+
+			actual, err = engine.Evaluate(nil, current, "daysSince(@.other) == 10", nil)
+			assert.Nil(t, err)
+			assert.Equal(t, false, actual)
+
+			_, err = engine.Evaluate(nil, current, "isValidSku(@.sku, @.created)", nil)
+			assert.EqualError(t, err, "invalid argument. function 'isValidSku' expects 1 arguments got 2")
+
+			_, err = engine.Evaluate(nil, current, "isvalidsku(@.sku)", nil)
+			assert.EqualError(t, err, "unknown function 'isvalidsku'")
+
+			_, ok := (&ScriptEngine{RFC9535: rfc9535}).getFunction("isValidSku")
+			assert.False(t, ok)
+		}
+	})
+
+	t.Run("argument types", func(t *testing.T) {
+		isTrue := Function{
+			Parameters: []FunctionType{LogicalType},
+			Result:     LogicalType,
+			Evaluate: func(arguments []interface{}) (interface{}, error) {
+				return arguments[0], nil
+			},
+		}
+
+		tests := []struct {
+			expression string
+			err        string
+		}{
+			{expression: "isTrue(@.sku)"},
+			{expression: "isTrue(@.created > 10 && @.sku)"},
+			{expression: "isTrue(isValidSku(@.sku))"},
+			{expression: "isTrue('SKU-1')", err: "invalid argument. expected logical expression"},
+			{expression: "isTrue(1)", err: "invalid argument. expected logical expression"},
+			{expression: "isTrue(length(@.sku))", err: "invalid argument. expected logical expression"},
+			{expression: "isValidSku(@.sku)"},
+			{expression: "isValidSku(length(@.sku))"},
+			{expression: "isValidSku(@.sku == 'SKU-1')", err: "invalid argument. expected literal, singular query, or value function"},
+			{expression: "isValidSku(@.tags[*])", err: "invalid argument. expected literal, singular query, or value function"},
+			{expression: "isValidSku(isTrue(@.sku))", err: "invalid argument. expected literal, singular query, or value function"},
+		}
+
+		for _, rfc9535 := range []bool{false, true} {
+			engine := &ScriptEngine{RFC9535: rfc9535}
+			assert.Nil(t, engine.RegisterFunction("isValidSku", isValidSku))
+			assert.Nil(t, engine.RegisterFunction("isTrue", isTrue))
+
+			for idx, test := range tests {
+				t.Run(fmt.Sprintf("%v %d", rfc9535, idx), func(t *testing.T) {
+					_, err := engine.Compile(test.expression, nil)
+					if test.err == "" {
+						assert.Nil(t, err)
+					} else {
+						assert.EqualError(t, err, test.err)
+					}
+				})
+			}
+		}
+	})
+}
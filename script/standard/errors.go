@@ -8,6 +8,10 @@ import (
 
 var (
 	errUnsupportedOperator               error = fmt.Errorf("unsupported operator")
+	errUnknownFunction                   error = fmt.Errorf("unknown function")
+	errInvalidFunction                   error = fmt.Errorf("invalid function")
+	errInvalidFunctionEvaluateNil        error = fmt.Errorf("%w. evaluate is nil", errInvalidFunction)
+	errInvalidFunctionUnsupportedType    error = fmt.Errorf("%w. unsupported type", errInvalidFunction)
 	errInvalidArgument                   error = fmt.Errorf("invalid argument")
 	errInvalidArgumentNil                error = fmt.Errorf("%w. is nil", errInvalidArgument)
 	errInvalidArgumentExpectedInteger    error = fmt.Errorf("%w. expected integer", errInvalidArgument)
@@ -15,8 +19,46 @@ var (
 	errInvalidArgumentExpectedBoolean    error = fmt.Errorf("%w. expected boolean", errInvalidArgument)
 	errInvalidArgumentExpectedRegex      error = fmt.Errorf("%w. expected a valid regexp", errInvalidArgument)
 	errInvalidArgumentExpectedCollection error = fmt.Errorf("%w. expected array, map, or slice", errInvalidArgument)
+	errInvalidArgumentExpectedTestable   error = fmt.Errorf("%w. expected query or logical function", errInvalidArgument)
+	errInvalidArgumentExpectedNodes      error = fmt.Errorf("%w. expected query", errInvalidArgument)
+	errInvalidArgumentExpectedValue      error = fmt.Errorf("%w. expected literal, singular query, or value function", errInvalidArgument)
+	errInvalidArgumentExpectedLogical    error = fmt.Errorf("%w. expected logical expression", errInvalidArgument)
+	errInvalidArgumentDivisionByZero     error = fmt.Errorf("%w. division by zero", errInvalidArgument)
 )
 
 func getInvalidExpressionEmptyError() error {
 	return fmt.Errorf("%w. is empty", errors.ErrInvalidExpression)
 }
+
+func getUnexpectedTokenError(found string, index int) error {
+	return fmt.Errorf("%w '%s' at index %d", errors.ErrUnexpectedToken, found, index)
+}
+
+func getUnknownFunctionError(name string) error {
+	return fmt.Errorf("%w '%s'", errUnknownFunction, name)
+}
+
+func getInvalidFunctionArgumentCountError(name string, expected, got int) error {
+	return fmt.Errorf("%w. function '%s' expects %d arguments got %d", errInvalidArgument, name, expected, got)
+}
+
+func getInvalidFunctionNameError(name string) error {
+	return fmt.Errorf("%w. '%s' is not a valid function name", errInvalidFunction, name)
+}
+
+func getFunctionAlreadyRegisteredError(name string) error {
+	return fmt.Errorf("%w. '%s' is already registered", errInvalidFunction, name)
+}
+
+func getUnexpectedFunctionResultError(name string, expected FunctionType) error {
+	return fmt.Errorf("%w. function '%s' did not return %s", errors.ErrUnexpectedExpressionResult, name, expected)
+}
+
+func getLimitExceededError(limit string, maximum int) error {
+	return fmt.Errorf("%w. %s exceeds maximum of %d", errors.ErrLimitExceeded, limit, maximum)
+}
+
+func isSyntaxError(err error) bool {
+	_, ok := err.(*errors.SyntaxError)
+	return ok
+}
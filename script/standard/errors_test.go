@@ -15,4 +15,43 @@ func Test_error(t *testing.T) {
 		assert.EqualError(t, actual, "invalid expression. is empty")
 		assert.True(t, goErr.Is(actual, errors.ErrInvalidExpression))
 	})
+	t.Run("getUnexpectedTokenError", func(t *testing.T) {
+		actual := getUnexpectedTokenError("=", 4)
+		assert.EqualError(t, actual, "unexpected token '=' at index 4")
+		assert.True(t, goErr.Is(actual, errors.ErrUnexpectedToken))
+	})
+	t.Run("getUnknownFunctionError", func(t *testing.T) {
+		actual := getUnknownFunctionError("now")
+		assert.EqualError(t, actual, "unknown function 'now'")
+		assert.True(t, goErr.Is(actual, errUnknownFunction))
+	})
+	t.Run("getInvalidFunctionArgumentCountError", func(t *testing.T) {
+		actual := getInvalidFunctionArgumentCountError("match", 2, 1)
+		assert.EqualError(t, actual, "invalid argument. function 'match' expects 2 arguments got 1")
+		assert.True(t, goErr.Is(actual, errInvalidArgument))
+	})
+	t.Run("getInvalidFunctionNameError", func(t *testing.T) {
+		actual := getInvalidFunctionNameError("Now")
+		assert.EqualError(t, actual, "invalid function. 'Now' is not a valid function name")
+		assert.True(t, goErr.Is(actual, errInvalidFunction))
+	})
+	t.Run("getFunctionAlreadyRegisteredError", func(t *testing.T) {
+		actual := getFunctionAlreadyRegisteredError("now")
+		assert.EqualError(t, actual, "invalid function. 'now' is already registered")
+		assert.True(t, goErr.Is(actual, errInvalidFunction))
+	})
+	t.Run("getUnexpectedFunctionResultError", func(t *testing.T) {
+		actual := getUnexpectedFunctionResultError("now", LogicalType)
+		assert.EqualError(t, actual, "unexpected expression result. function 'now' did not return logical")
+		assert.True(t, goErr.Is(actual, errors.ErrUnexpectedExpressionResult))
+	})
+	t.Run("getLimitExceededError", func(t *testing.T) {
+		actual := getLimitExceededError("expression depth", 4)
+		assert.EqualError(t, actual, "limit exceeded. expression depth exceeds maximum of 4")
+		assert.True(t, goErr.Is(actual, errors.ErrLimitExceeded))
+	})
+	t.Run("isSyntaxError", func(t *testing.T) {
+		assert.True(t, isSyntaxError(&errors.SyntaxError{Err: getUnexpectedTokenError("=", 4)}))
+		assert.False(t, isSyntaxError(getUnexpectedTokenError("=", 4)))
+	})
 }
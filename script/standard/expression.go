@@ -1,6 +1,14 @@
 package standard
 
-import "github.com/evilmonkeyinc/jsonpath/option"
+import (
+	"context"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+)
+
+// contextParameter the parameter holding the context of the evaluation,
+// it can not be referenced by an expression as an unquoted $ always starts a selector.
+const contextParameter string = "$context"
 
 type compiledExpression struct {
 	expression   string
@@ -10,6 +18,15 @@ type compiledExpression struct {
 }
 
 func (compiled *compiledExpression) Evaluate(root, current interface{}) (interface{}, error) {
+	return compiled.EvaluateContext(context.Background(), root, current)
+}
+
+// EvaluateContext return the result of the expression evaluation, or the context error if it is done
+func (compiled *compiledExpression) EvaluateContext(ctx context.Context, root, current interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	expression := compiled.expression
 	if expression == "" {
 		return nil, getInvalidExpressionEmptyError()
@@ -19,6 +36,8 @@ func (compiled *compiledExpression) Evaluate(root, current interface{}) (interfa
 		"@":    current,
 		"nil":  nil,
 		"null": nil,
+
+		contextParameter: ctx,
 	}
 
 	if compiled.rootOperator == nil {
@@ -26,7 +45,7 @@ func (compiled *compiledExpression) Evaluate(root, current interface{}) (interfa
 			return val, nil
 		}
 
-		if number, err := getNumber(expression, parameters); err == nil {
+		if number, ok := getNumberLiteral(expression); ok {
 			return number, nil
 		} else if boolean, err := getBoolean(expression, parameters); err == nil {
 			return boolean, nil
@@ -39,6 +58,17 @@ func (compiled *compiledExpression) Evaluate(root, current interface{}) (interfa
 	if err != nil {
 		return nil, err
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	return value, nil
 }
+
+// getContext returns the context of the evaluation from the parameters
+func getContext(parameters map[string]interface{}) context.Context {
+	if ctx, ok := parameters[contextParameter].(context.Context); ok {
+		return ctx
+	}
+	return context.Background()
+}
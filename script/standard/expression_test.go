@@ -1,9 +1,11 @@
 package standard
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/evilmonkeyinc/jsonpath/script"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -158,3 +160,36 @@ func Test_compiledExpression_Evaluate(t *testing.T) {
 		})
 	}
 }
+
+func Test_compiledExpression_EvaluateContext(t *testing.T) {
+
+	engine := &ScriptEngine{}
+	compiled, err := engine.Compile("@.price < 10", nil)
+	assert.Nil(t, err)
+
+	contextual, ok := compiled.(script.CompiledExpressionContext)
+	assert.True(t, ok)
+
+	t.Run("background", func(t *testing.T) {
+		actual, err := contextual.EvaluateContext(context.Background(), nil, map[string]interface{}{"price": 8.95})
+		assert.Nil(t, err)
+		assert.Equal(t, true, actual)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		actual, err := contextual.EvaluateContext(ctx, nil, map[string]interface{}{"price": 8.95})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, actual)
+	})
+}
+
+func Test_getContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.Equal(t, ctx, getContext(map[string]interface{}{contextParameter: ctx}))
+	assert.Equal(t, context.Background(), getContext(map[string]interface{}{}))
+}
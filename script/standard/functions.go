@@ -0,0 +1,351 @@
+package standard
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/evilmonkeyinc/jsonpath/ordered"
+	"github.com/evilmonkeyinc/jsonpath/token"
+)
+
+// FunctionType represents the RFC 9535 types of function parameters and results
+type FunctionType int
+
+const (
+	// ValueType a single value, or Nothing
+	ValueType FunctionType = iota
+	// LogicalType true or false
+	LogicalType
+	// NodesType a list of nodes selected by a query, passed to the function as []*token.Node
+	NodesType
+)
+
+// String returns the RFC 9535 name of the type
+func (functionType FunctionType) String() string {
+	switch functionType {
+	case ValueType:
+		return "value"
+	case LogicalType:
+		return "logical"
+	case NodesType:
+		return "nodes"
+	}
+	return "unknown"
+}
+
+// Nothing is returned by a ValueType function to indicate that it has no result,
+// unlike nil which represents the JSON null value.
+var Nothing interface{} = nothing
+
+// Function represents a function that can be called within an expression
+type Function struct {
+	// Parameters the types of the arguments passed to Evaluate
+	Parameters []FunctionType
+	// Result the type of the value returned by Evaluate
+	Result FunctionType
+	// Evaluate returns the result of the function for the arguments
+	Evaluate func(arguments []interface{}) (interface{}, error)
+}
+
+// standardFunctions the function extensions defined by RFC 9535
+var standardFunctions = map[string]*Function{
+	"length": {
+		Parameters: []FunctionType{ValueType},
+		Result:     ValueType,
+		Evaluate:   lengthFunction,
+	},
+	"count": {
+		Parameters: []FunctionType{NodesType},
+		Result:     ValueType,
+		Evaluate:   countFunction,
+	},
+	"match": {
+		Parameters: []FunctionType{ValueType, ValueType},
+		Result:     LogicalType,
+		Evaluate:   matchFunction,
+	},
+	"search": {
+		Parameters: []FunctionType{ValueType, ValueType},
+		Result:     LogicalType,
+		Evaluate:   searchFunction,
+	},
+	"value": {
+		Parameters: []FunctionType{NodesType},
+		Result:     ValueType,
+		Evaluate:   valueFunction,
+	},
+}
+
+// lengthFunction returns the number of characters in a string, elements in an array, or members of an object
+func lengthFunction(arguments []interface{}) (interface{}, error) {
+	if str, ok := arguments[0].(string); ok {
+		return utf8.RuneCountInString(str), nil
+	}
+	if arguments[0] == nil {
+		return nothing, nil
+	}
+
+	if object, ok := arguments[0].(*ordered.Object); ok {
+		return object.Len(), nil
+	}
+
+	objValue := reflect.ValueOf(arguments[0])
+	switch objValue.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map:
+		return objValue.Len(), nil
+	}
+	return nothing, nil
+}
+
+// countFunction returns the number of nodes in the node list
+func countFunction(arguments []interface{}) (interface{}, error) {
+	return len(arguments[0].([]*token.Node)), nil
+}
+
+// matchFunction returns true if the whole string matches the I-Regexp pattern
+func matchFunction(arguments []interface{}) (interface{}, error) {
+	return regexpFunction(arguments, true), nil
+}
+
+// searchFunction returns true if any substring matches the I-Regexp pattern
+func searchFunction(arguments []interface{}) (interface{}, error) {
+	return regexpFunction(arguments, false), nil
+}
+
+// valueFunction returns the value of the only node in the node list, or nothing
+func valueFunction(arguments []interface{}) (interface{}, error) {
+	nodes := arguments[0].([]*token.Node)
+	if len(nodes) != 1 {
+		return nothing, nil
+	}
+	return nodes[0].Value, nil
+}
+
+func regexpFunction(arguments []interface{}, fullMatch bool) bool {
+	input, ok := arguments[0].(string)
+	if !ok {
+		return false
+	}
+	pattern, ok := arguments[1].(string)
+	if !ok {
+		return false
+	}
+
+	expression, ok := translateIRegexp(pattern)
+	if !ok {
+		return false
+	}
+	if fullMatch {
+		expression = "^(?:" + expression + ")$"
+	}
+
+	regex, err := regexp.Compile(expression)
+	if err != nil {
+		return false
+	}
+	return regex.MatchString(input)
+}
+
+// translateIRegexp converts an I-Regexp (RFC 9485) pattern to the equivalent golang regexp pattern,
+// returning false if the pattern uses syntax that is not part of I-Regexp.
+func translateIRegexp(pattern string) (string, bool) {
+	builder := strings.Builder{}
+	inClass := false
+	afterQuantifier := false
+
+	for idx := 0; idx < len(pattern); idx++ {
+		char := pattern[idx]
+		isQuantifier := !inClass && strings.ContainsRune("*+?}", rune(char))
+		if isQuantifier && afterQuantifier && char != '}' {
+			// lazy and possessive quantifiers are not supported
+			return "", false
+		}
+		afterQuantifier = isQuantifier
+
+		switch {
+		case char == '\\':
+			if idx+1 >= len(pattern) {
+				return "", false
+			}
+			escaped := pattern[idx+1]
+			if !strings.ContainsRune(`()*+-.?[\]^{|}nrtpP`, rune(escaped)) {
+				return "", false
+			}
+			builder.WriteByte(char)
+			builder.WriteByte(escaped)
+			idx++
+			if escaped == 'p' || escaped == 'P' {
+				// copy the character property, for example \p{Lu}
+				end := strings.IndexByte(pattern[idx:], '}')
+				if end < 0 {
+					return "", false
+				}
+				builder.WriteString(pattern[idx+1 : idx+end+1])
+				idx += end
+			}
+		case inClass:
+			if char == ']' {
+				inClass = false
+			}
+			builder.WriteByte(char)
+		case char == '[':
+			inClass = true
+			builder.WriteByte(char)
+			// a leading caret or closing bracket is part of the class
+			if idx+1 < len(pattern) && pattern[idx+1] == '^' {
+				builder.WriteByte('^')
+				idx++
+			}
+			if idx+1 < len(pattern) && pattern[idx+1] == ']' {
+				builder.WriteString(`\]`)
+				idx++
+			}
+		case char == '.':
+			// I-Regexp dot matches any character except line breaks
+			builder.WriteString(`[^\n\r]`)
+		case char == '^' || char == '$':
+			return "", false
+		case char == '(' && idx+1 < len(pattern) && pattern[idx+1] == '?':
+			// groups are always capturing, there are no group modifiers
+			return "", false
+		default:
+			builder.WriteByte(char)
+		}
+	}
+
+	if inClass {
+		return "", false
+	}
+	return builder.String(), true
+}
+
+// nodesOperator is implemented by operators that evaluate a query to a list of nodes
+type nodesOperator interface {
+	nodes(parameters map[string]interface{}) ([]*token.Node, error)
+}
+
+type functionOperator struct {
+	name      string
+	function  *Function
+	arguments []interface{}
+	rfc9535   bool
+}
+
+func (op *functionOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
+	arguments := make([]interface{}, len(op.arguments))
+	for idx, parameter := range op.function.Parameters {
+		argument, err := op.getArgument(parameter, op.arguments[idx], parameters)
+		if err != nil {
+			return nil, err
+		}
+		arguments[idx] = argument
+	}
+
+	result, err := op.function.Evaluate(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.function.Result {
+	case LogicalType:
+		if _, ok := result.(bool); !ok {
+			return nil, getUnexpectedFunctionResultError(op.name, op.function.Result)
+		}
+	case NodesType:
+		if _, ok := result.([]*token.Node); !ok {
+			return nil, getUnexpectedFunctionResultError(op.name, op.function.Result)
+		}
+	}
+
+	if !op.rfc9535 {
+		// match the representation of values returned by selectors
+		switch typed := result.(type) {
+		case nothingValue:
+			return nil, nil
+		case string:
+			return fmt.Sprintf("'%s'", typed), nil
+		}
+	}
+	return result, nil
+}
+
+// getArgument evaluates the argument as the parameter type
+func (op *functionOperator) getArgument(parameter FunctionType, argument interface{}, parameters map[string]interface{}) (interface{}, error) {
+	switch parameter {
+	case NodesType:
+		if query, ok := argument.(nodesOperator); ok {
+			return query.nodes(parameters)
+		}
+		if function, ok := argument.(*functionOperator); ok && function.function.Result == NodesType {
+			return function.Evaluate(parameters)
+		}
+		return nil, errInvalidArgumentExpectedNodes
+	case LogicalType:
+		if query, ok := argument.(nodesOperator); ok {
+			nodes, err := query.nodes(parameters)
+			if err != nil {
+				return nil, err
+			}
+			return len(nodes) > 0, nil
+		}
+		if function, ok := argument.(*functionOperator); ok && function.function.Result == NodesType {
+			nodes, err := function.Evaluate(parameters)
+			if err != nil {
+				return nil, err
+			}
+			return len(nodes.([]*token.Node)) > 0, nil
+		}
+		return getBoolean(argument, parameters)
+	default:
+		if query, ok := argument.(nodesOperator); ok {
+			nodes, err := query.nodes(parameters)
+			if err != nil {
+				return nil, err
+			}
+			if len(nodes) != 1 {
+				return nothing, nil
+			}
+			return nodes[0].Value, nil
+		}
+		if function, ok := argument.(*functionOperator); ok && !function.rfc9535 {
+			// use the raw result of functions rather than the selector representation
+			nested := *function
+			nested.rfc9535 = true
+			return nested.Evaluate(parameters)
+		}
+		if sub, ok := argument.(operator); ok {
+			return sub.Evaluate(parameters)
+		}
+		if str, ok := argument.(string); ok {
+			return getFunctionLiteral(str), nil
+		}
+		return argument, nil
+	}
+}
+
+// getFunctionLiteral returns the value represented by a literal function argument
+func getFunctionLiteral(argument string) interface{} {
+	if len(argument) > 1 {
+		if (strings.HasPrefix(argument, "'") && strings.HasSuffix(argument, "'")) ||
+			(strings.HasPrefix(argument, `"`) && strings.HasSuffix(argument, `"`)) {
+			return argument[1 : len(argument)-1]
+		}
+	}
+
+	switch argument {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "nil", "null":
+		return nil
+	}
+
+	if number, ok := getNumberLiteral(argument); ok {
+		return number
+	}
+	return argument
+}
@@ -0,0 +1,346 @@
+package standard
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/ordered"
+	"github.com/evilmonkeyinc/jsonpath/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_lengthFunction(t *testing.T) {
+	tests := []struct {
+		input    interface{}
+		expected interface{}
+	}{
+		{input: "abc", expected: 3},
+		{input: "béé", expected: 3},
+		{input: []interface{}{1, 2}, expected: 2},
+		{input: [1]int{1}, expected: 1},
+		{input: map[string]interface{}{"a": 1}, expected: 1},
+		{input: ordered.NewObject(ordered.Member{Key: "b", Value: 1}, ordered.Member{Key: "a", Value: 2}), expected: 2},
+		{input: 10, expected: nothing},
+		{input: true, expected: nothing},
+		{input: nil, expected: nothing},
+		{input: nothing, expected: nothing},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, err := lengthFunction([]interface{}{test.input})
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_countFunction(t *testing.T) {
+	actual, err := countFunction([]interface{}{[]*token.Node{}})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, actual)
+
+	actual, err = countFunction([]interface{}{[]*token.Node{{Value: 1}, {Value: nil}}})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, actual)
+}
+
+func Test_valueFunction(t *testing.T) {
+	actual, err := valueFunction([]interface{}{[]*token.Node{}})
+	assert.Nil(t, err)
+	assert.Equal(t, nothing, actual)
+
+	actual, err = valueFunction([]interface{}{[]*token.Node{{Value: "one"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, "one", actual)
+
+	actual, err = valueFunction([]interface{}{[]*token.Node{{Value: 1}, {Value: 2}}})
+	assert.Nil(t, err)
+	assert.Equal(t, nothing, actual)
+}
+
+func Test_matchFunction_searchFunction(t *testing.T) {
+	tests := []struct {
+		input, pattern interface{}
+		match, search  bool
+	}{
+		{input: "1974-05-01", pattern: "1974-05-..", match: true, search: true},
+		{input: "1974-05-01", pattern: "05", match: false, search: true},
+		{input: "ab\ncd", pattern: "b.c", match: false, search: false},
+		{input: "abc", pattern: "[^a]c", match: false, search: true},
+		{input: "a]", pattern: "a[]]", match: true, search: true},
+		{input: "Abc", pattern: `\p{Lu}\p{Ll}+`, match: true, search: true},
+		{input: "abc", pattern: "^abc$", match: false, search: false},
+		{input: "abc", pattern: "(", match: false, search: false},
+		{input: 1, pattern: "1", match: false, search: false},
+		{input: "1", pattern: 1, match: false, search: false},
+		{input: nothing, pattern: "a", match: false, search: false},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, err := matchFunction([]interface{}{test.input, test.pattern})
+			assert.Nil(t, err)
+			assert.Equal(t, test.match, actual, "match")
+
+			actual, err = searchFunction([]interface{}{test.input, test.pattern})
+			assert.Nil(t, err)
+			assert.Equal(t, test.search, actual, "search")
+		})
+	}
+}
+
+func Test_translateIRegexp(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected string
+		valid    bool
+	}{
+		{pattern: "abc", expected: "abc", valid: true},
+		{pattern: "a.c", expected: `a[^\n\r]c`, valid: true},
+		{pattern: "a[.]c", expected: "a[.]c", valid: true},
+		{pattern: `a\.c`, expected: `a\.c`, valid: true},
+		{pattern: "[^]a]", expected: `[^\]a]`, valid: true},
+		{pattern: "a{2,3}b*c+d?", expected: "a{2,3}b*c+d?", valid: true},
+		{pattern: `\*?`, expected: `\*?`, valid: true},
+		{pattern: `(a|b)`, expected: `(a|b)`, valid: true},
+		{pattern: `\d`, valid: false},
+		{pattern: `a\`, valid: false},
+		{pattern: "^a", valid: false},
+		{pattern: "a$", valid: false},
+		{pattern: "(?:a)", valid: false},
+		{pattern: "a*?", valid: false},
+		{pattern: "a{2}?", valid: false},
+		{pattern: "a++", valid: false},
+		{pattern: "[a", valid: false},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, ok := translateIRegexp(test.pattern)
+			assert.Equal(t, test.valid, ok)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_functionOperator(t *testing.T) {
+	current := map[string]interface{}{
+		"name": "value",
+		"tags": []interface{}{"a", "b"},
+	}
+	parameters := map[string]interface{}{"$": current, "@": current}
+
+	selector := func(query string) *selectorOperator {
+		op, err := newSelectorOperator(query, &ScriptEngine{}, nil)
+		assert.Nil(t, err)
+		return op
+	}
+
+	tests := []*operatorTest{
+		{
+			input: operatorTestInput{
+				operator: &functionOperator{
+					function:  standardFunctions["length"],
+					arguments: []interface{}{selector("@.tags")},
+				},
+				paramters: parameters,
+			},
+			expected: operatorTestExpected{
+				value: 2,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &functionOperator{
+					function:  standardFunctions["length"],
+					arguments: []interface{}{selector("@.missing")},
+				},
+				paramters: parameters,
+			},
+			expected: operatorTestExpected{
+				value: nil,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &functionOperator{
+					function:  standardFunctions["value"],
+					arguments: []interface{}{selector("@.name")},
+				},
+				paramters: parameters,
+			},
+			expected: operatorTestExpected{
+				value: "'value'",
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &functionOperator{
+					function:  standardFunctions["value"],
+					arguments: []interface{}{selector("@.name")},
+					rfc9535:   true,
+				},
+				paramters: parameters,
+			},
+			expected: operatorTestExpected{
+				value: "value",
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &functionOperator{
+					function: standardFunctions["length"],
+					arguments: []interface{}{&functionOperator{
+						function:  standardFunctions["value"],
+						arguments: []interface{}{selector("@.name")},
+					}},
+				},
+				paramters: parameters,
+			},
+			expected: operatorTestExpected{
+				value: 5,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &functionOperator{
+					function:  standardFunctions["count"],
+					arguments: []interface{}{selector("@.tags[*]")},
+				},
+				paramters: parameters,
+			},
+			expected: operatorTestExpected{
+				value: 2,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &functionOperator{
+					function:  standardFunctions["count"],
+					arguments: []interface{}{"'literal'"},
+				},
+				paramters: parameters,
+			},
+			expected: operatorTestExpected{
+				err: "invalid argument. expected query",
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &functionOperator{
+					function:  standardFunctions["match"],
+					arguments: []interface{}{selector("@.name"), "'val.*'"},
+				},
+				paramters: parameters,
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &functionOperator{
+					function: &Function{
+						Parameters: []FunctionType{LogicalType},
+						Result:     LogicalType,
+						Evaluate: func(arguments []interface{}) (interface{}, error) {
+							return arguments[0], nil
+						},
+					},
+					arguments: []interface{}{selector("@.tags")},
+				},
+				paramters: parameters,
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &functionOperator{
+					function: &Function{
+						Parameters: []FunctionType{LogicalType},
+						Result:     LogicalType,
+						Evaluate: func(arguments []interface{}) (interface{}, error) {
+							return nil, fmt.Errorf("function error")
+						},
+					},
+					arguments: []interface{}{"true"},
+				},
+				paramters: parameters,
+			},
+			expected: operatorTestExpected{
+				err: "function error",
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &functionOperator{
+					name: "invalid",
+					function: &Function{
+						Parameters: []FunctionType{},
+						Result:     LogicalType,
+						Evaluate: func(arguments []interface{}) (interface{}, error) {
+							return "true", nil
+						},
+					},
+					arguments: []interface{}{},
+				},
+				paramters: parameters,
+			},
+			expected: operatorTestExpected{
+				err: "unexpected expression result. function 'invalid' did not return logical",
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &functionOperator{
+					name: "invalid",
+					function: &Function{
+						Parameters: []FunctionType{},
+						Result:     NodesType,
+						Evaluate: func(arguments []interface{}) (interface{}, error) {
+							return []interface{}{}, nil
+						},
+					},
+					arguments: []interface{}{},
+				},
+				paramters: parameters,
+			},
+			expected: operatorTestExpected{
+				err: "unexpected expression result. function 'invalid' did not return nodes",
+			},
+		},
+	}
+	batchOperatorTests(t, tests)
+}
+
+func Test_FunctionType_String(t *testing.T) {
+	assert.Equal(t, "value", ValueType.String())
+	assert.Equal(t, "logical", LogicalType.String())
+	assert.Equal(t, "nodes", NodesType.String())
+	assert.Equal(t, "unknown", FunctionType(5).String())
+}
+
+func Test_getFunctionLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{input: "'quoted'", expected: "quoted"},
+		{input: `"quoted"`, expected: "quoted"},
+		{input: "true", expected: true},
+		{input: "false", expected: false},
+		{input: "nil", expected: nil},
+		{input: "null", expected: nil},
+		{input: "1.5", expected: 1.5},
+		{input: "raw", expected: "raw"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, getFunctionLiteral(test.input))
+		})
+	}
+}
@@ -1,5 +1,199 @@
 package standard
 
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/evilmonkeyinc/jsonpath/ordered"
+	"github.com/evilmonkeyinc/jsonpath/token"
+)
+
+var functionCallPattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*\(`)
+var functionNamePattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+
+// splitFunctionCall returns the function name and arguments if the whole expression is a single function call
+func splitFunctionCall(expression string) (string, []string, bool) {
+	location := functionCallPattern.FindStringIndex(expression)
+	if location == nil {
+		return "", nil, false
+	}
+	name := expression[:location[1]-1]
+
+	arguments := make([]string, 0)
+	depth := 0
+	start := location[1]
+	var quote byte
+	escaped := false
+
+	for idx := location[1] - 1; idx < len(expression); idx++ {
+		char := expression[idx]
+		if quote != 0 {
+			if escaped {
+				escaped = false
+			} else if char == '\\' {
+				escaped = true
+			} else if char == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch char {
+		case '\'', '"':
+			quote = char
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+			if depth == 0 {
+				if idx != len(expression)-1 {
+					// the function call is only part of the expression
+					return "", nil, false
+				}
+				if argument := strings.TrimSpace(expression[start:idx]); argument != "" || len(arguments) > 0 {
+					arguments = append(arguments, argument)
+				}
+				return name, arguments, true
+			}
+		case ',':
+			if depth == 1 {
+				arguments = append(arguments, strings.TrimSpace(expression[start:idx]))
+				start = idx + 1
+			}
+		}
+	}
+	return "", nil, false
+}
+
+// getExpressionDepth returns the maximum nesting of unquoted brackets and parentheses in the expression
+func getExpressionDepth(expression string) int {
+	depth, maximum := 0, 0
+	var quote byte
+	escaped := false
+
+	for idx := 0; idx < len(expression); idx++ {
+		char := expression[idx]
+		if quote != 0 {
+			if escaped {
+				escaped = false
+			} else if char == '\\' {
+				escaped = true
+			} else if char == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch char {
+		case '\'', '"':
+			quote = char
+		case '(', '[':
+			depth++
+			if depth > maximum {
+				maximum = depth
+			}
+		case ')', ']':
+			depth--
+		}
+	}
+	return maximum
+}
+
+// isWrappedInParentheses returns true if the expression starts with an unquoted opening parenthesis
+// that is closed by the last character of the expression, such as (@.a == 1) but not (@.a) && (@.b)
+func isWrappedInParentheses(expression string) bool {
+	if !strings.HasPrefix(expression, "(") || !strings.HasSuffix(expression, ")") {
+		return false
+	}
+
+	depth := 0
+	var quote byte
+	escaped := false
+
+	for idx := 0; idx < len(expression); idx++ {
+		char := expression[idx]
+		if quote != 0 {
+			if escaped {
+				escaped = false
+			} else if char == '\\' {
+				escaped = true
+			} else if char == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch char {
+		case '\'', '"':
+			quote = char
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return idx == len(expression)-1
+			}
+		}
+	}
+	return false
+}
+
+// getQuotedLiteral returns the content of the argument if it is a single or double quoted string literal
+func getQuotedLiteral(argument interface{}) (string, bool) {
+	str, ok := argument.(string)
+	if !ok || len(str) < 2 {
+		return "", false
+	}
+	if (strings.HasPrefix(str, "'") && strings.HasSuffix(str, "'")) ||
+		(strings.HasPrefix(str, `"`) && strings.HasSuffix(str, `"`)) {
+		return str[1 : len(str)-1], true
+	}
+	return "", false
+}
+
+// getStringLiteral returns the quoted string literal with its escaped backslashes and quotes replaced,
+// wrapped in single quotes, other escape sequences are kept as they are and any other argument is returned unchanged.
+func getStringLiteral(argument string) string {
+	if len(argument) < 2 || !strings.Contains(argument, "\\") {
+		return argument
+	}
+	quote := argument[0]
+	if (quote != '\'' && quote != '"') || argument[len(argument)-1] != quote {
+		return argument
+	}
+
+	builder := strings.Builder{}
+	builder.WriteByte('\'')
+	content := argument[1 : len(argument)-1]
+	for idx := 0; idx < len(content); idx++ {
+		char := content[idx]
+		if char == '\\' && idx+1 < len(content) {
+			if next := content[idx+1]; next == '\\' || next == quote {
+				builder.WriteByte(next)
+				idx++
+				continue
+			}
+		}
+		builder.WriteByte(char)
+	}
+	builder.WriteByte('\'')
+	return builder.String()
+}
+
+// getRegexComplexity returns the number of instructions in the compiled program of the regular expression
+func getRegexComplexity(pattern string) (int, error) {
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return 0, err
+	}
+	program, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return 0, err
+	}
+	return len(program.Inst), nil
+}
+
 func findUnquotedOperators(source string, operator string) int {
 	inSingleQuotes := false
 	inDoubleQuotes := false
@@ -15,7 +209,17 @@ func findUnquotedOperators(source string, operator string) int {
 		return roundBracketOpen > roundBracketClose
 	}
 
+	escaped := false
 	for idx, rne := range source {
+		if escaped {
+			escaped = false
+			continue
+		}
+		if rne == '\\' && (inSingleQuotes || inDoubleQuotes) {
+			escaped = true
+			continue
+		}
+
 		switch rne {
 		case '(':
 			if inSingleQuotes || inDoubleQuotes || inSquareBrackets() {
@@ -77,3 +281,12 @@ func findUnquotedOperators(source string, operator string) int {
 
 	return -1
 }
+
+// getObjectValues returns the members of an ordered object as a map so it is compared in the same way as a map,
+// any other value is returned unchanged
+func getObjectValues(value interface{}) interface{} {
+	if object, ok := value.(*ordered.Object); ok && object != nil {
+		return token.ObjectValues(object)
+	}
+	return value
+}
@@ -2,6 +2,7 @@ package standard
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,6 +33,13 @@ func batchOperatorTests(t *testing.T, tests []*operatorTest) {
 				assert.EqualError(t, err, test.expected.err)
 			}
 
+			if expected, ok := test.expected.value.(*big.Float); ok {
+				// big floats are equal if they have the same value regardless of their representation
+				if assert.IsType(t, expected, actual) {
+					assert.Zero(t, expected.Cmp(actual.(*big.Float)), "expected %v, actual %v", expected, actual)
+				}
+				return
+			}
 			assert.Equal(t, test.expected.value, actual)
 		})
 	}
@@ -55,6 +63,13 @@ func Test_findUnquotedOperators(t *testing.T) {
 			},
 			expected: -1,
 		},
+		{
+			input: input{
+				source:    `'it\'s' == 'a\\'`,
+				subString: "==",
+			},
+			expected: 8,
+		},
 		{
 			input: input{
 				source:    "||",
@@ -149,3 +164,138 @@ func Test_findUnquotedOperators(t *testing.T) {
 	}
 
 }
+
+func Test_splitFunctionCall(t *testing.T) {
+	type expected struct {
+		name      string
+		arguments []string
+		ok        bool
+	}
+
+	tests := []struct {
+		input    string
+		expected expected
+	}{
+		{input: "length(@.a)", expected: expected{name: "length", arguments: []string{"@.a"}, ok: true}},
+		{input: "match(@.a, 'a,b)')", expected: expected{name: "match", arguments: []string{"@.a", "'a,b)'"}, ok: true}},
+		{input: "count(@[0,1])", expected: expected{name: "count", arguments: []string{"@[0,1]"}, ok: true}},
+		{input: "length(value(@.a, 1))", expected: expected{name: "length", arguments: []string{"value(@.a, 1)"}, ok: true}},
+		{input: "now()", expected: expected{name: "now", arguments: []string{}, ok: true}},
+		{input: "length(@.a) > length(@.b)", expected: expected{}},
+		{input: "length(@.a", expected: expected{}},
+		{input: "Length(@.a)", expected: expected{}},
+		{input: "@.length", expected: expected{}},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			name, arguments, ok := splitFunctionCall(test.input)
+			assert.Equal(t, test.expected.ok, ok)
+			assert.Equal(t, test.expected.name, name)
+			assert.Equal(t, test.expected.arguments, arguments)
+		})
+	}
+}
+
+func Test_getExpressionDepth(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{input: "", expected: 0},
+		{input: "@.a == 1", expected: 0},
+		{input: "@[0] == 1", expected: 1},
+		{input: "(@.a[0] + 1) * 2", expected: 2},
+		{input: "length(@.a) > length(@.b)", expected: 1},
+		{input: "@.a == '((['", expected: 0},
+		{input: "@.a == \"it\\\"s ((\"", expected: 0},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, getExpressionDepth(test.input))
+		})
+	}
+}
+
+func Test_isWrappedInParentheses(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{input: "", expected: false},
+		{input: "@.a == 1", expected: false},
+		{input: "(@.a == 1)", expected: true},
+		{input: "((@.a + 1) * 2)", expected: true},
+		{input: "(@.a == 1) && (@.b == 2)", expected: false},
+		{input: "(@.a - 1) - 2", expected: false},
+		{input: "(@.a == ')(')", expected: true},
+		{input: "(@.a == \"it\\\"s )(\")", expected: true},
+		{input: "(@.a", expected: false},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, isWrappedInParentheses(test.input))
+		})
+	}
+}
+
+func Test_getStringLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "'value'", expected: "'value'"},
+		{input: `"it's"`, expected: `"it's"`},
+		{input: `'it\'s'`, expected: `'it's'`},
+		{input: `"it's \"x\""`, expected: `'it's "x"'`},
+		{input: `'a\\'`, expected: `'a\'`},
+		{input: `'a\b\"'`, expected: `'a\b\"'`},
+		{input: `@.a\b`, expected: `@.a\b`},
+		{input: `'a\b"`, expected: `'a\b"`},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, getStringLiteral(test.input))
+		})
+	}
+}
+
+func Test_getQuotedLiteral(t *testing.T) {
+	tests := []struct {
+		input    interface{}
+		expected string
+		ok       bool
+	}{
+		{input: "'a+'", expected: "a+", ok: true},
+		{input: `"a+"`, expected: "a+", ok: true},
+		{input: "''", expected: "", ok: true},
+		{input: "@.pattern", ok: false},
+		{input: "'", ok: false},
+		{input: `'a+"`, ok: false},
+		{input: 1, ok: false},
+		{input: nil, ok: false},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, ok := getQuotedLiteral(test.input)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_getRegexComplexity(t *testing.T) {
+	small, err := getRegexComplexity("a")
+	assert.Nil(t, err)
+
+	large, err := getRegexComplexity("(a|b){20}")
+	assert.Nil(t, err)
+	assert.Greater(t, large, small)
+
+	_, err = getRegexComplexity("(")
+	assert.NotNil(t, err)
+}
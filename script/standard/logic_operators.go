@@ -41,17 +41,13 @@ type lessThanOperator struct {
 }
 
 func (op *lessThanOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
-	first, err := getNumber(op.arg1, parameters)
+	first, second, err := getPreciseNumbers(op.arg1, op.arg2, parameters)
 	if err != nil {
 		return nil, err
 	}
 
-	second, err := getNumber(op.arg2, parameters)
-	if err != nil {
-		return nil, err
-	}
-
-	return first < second, nil
+	comparison, ok := compareNumbers(first, second)
+	return ok && comparison < 0, nil
 }
 
 type lessThanOrEqualOperator struct {
@@ -59,17 +55,13 @@ type lessThanOrEqualOperator struct {
 }
 
 func (op *lessThanOrEqualOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
-	first, err := getNumber(op.arg1, parameters)
-	if err != nil {
-		return nil, err
-	}
-
-	second, err := getNumber(op.arg2, parameters)
+	first, second, err := getPreciseNumbers(op.arg1, op.arg2, parameters)
 	if err != nil {
 		return nil, err
 	}
 
-	return first <= second, nil
+	comparison, ok := compareNumbers(first, second)
+	return ok && comparison <= 0, nil
 }
 
 type greaterThanOperator struct {
@@ -77,17 +69,13 @@ type greaterThanOperator struct {
 }
 
 func (op *greaterThanOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
-	first, err := getNumber(op.arg1, parameters)
+	first, second, err := getPreciseNumbers(op.arg1, op.arg2, parameters)
 	if err != nil {
 		return nil, err
 	}
 
-	second, err := getNumber(op.arg2, parameters)
-	if err != nil {
-		return nil, err
-	}
-
-	return first > second, nil
+	comparison, ok := compareNumbers(first, second)
+	return ok && comparison > 0, nil
 }
 
 type greaterThanOrEqualOperator struct {
@@ -95,17 +83,13 @@ type greaterThanOrEqualOperator struct {
 }
 
 func (op *greaterThanOrEqualOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
-	first, err := getNumber(op.arg1, parameters)
-	if err != nil {
-		return nil, err
-	}
-
-	second, err := getNumber(op.arg2, parameters)
+	first, second, err := getPreciseNumbers(op.arg1, op.arg2, parameters)
 	if err != nil {
 		return nil, err
 	}
 
-	return first >= second, nil
+	comparison, ok := compareNumbers(first, second)
+	return ok && comparison >= 0, nil
 }
 
 type equalsOperator struct {
@@ -114,10 +98,9 @@ type equalsOperator struct {
 
 func (op *equalsOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
 
-	if firstNumber, err := getNumber(op.arg1, parameters); err == nil {
-		if secondNumber, err := getNumber(op.arg2, parameters); err == nil {
-			return firstNumber == secondNumber, nil
-		}
+	if first, second, err := getPreciseNumbers(op.arg1, op.arg2, parameters); err == nil {
+		comparison, ok := compareNumbers(first, second)
+		return ok && comparison == 0, nil
 	}
 
 	first, err := getString(op.arg1, parameters)
@@ -139,10 +122,9 @@ type notEqualsOperator struct {
 
 func (op *notEqualsOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
 
-	if firstNumber, err := getNumber(op.arg1, parameters); err == nil {
-		if secondNumber, err := getNumber(op.arg2, parameters); err == nil {
-			return firstNumber != secondNumber, nil
-		}
+	if first, second, err := getPreciseNumbers(op.arg1, op.arg2, parameters); err == nil {
+		comparison, ok := compareNumbers(first, second)
+		return !ok || comparison != 0, nil
 	}
 
 	first, err := getString(op.arg1, parameters)
@@ -1,6 +1,10 @@
 package standard
 
-import "testing"
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
 
 func Test_andOperator(t *testing.T) {
 	tests := []*operatorTest{
@@ -159,6 +163,17 @@ func Test_lessThanOperator(t *testing.T) {
 				value: false,
 			},
 		},
+		{
+			input: operatorTestInput{
+				operator: &lessThanOperator{
+					arg1: json.Number("9007199254740992"),
+					arg2: "9007199254740993",
+				},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
 	}
 	batchOperatorTests(t, tests)
 }
@@ -270,6 +285,17 @@ func Test_greaterThanOperator(t *testing.T) {
 				value: false,
 			},
 		},
+		{
+			input: operatorTestInput{
+				operator: &greaterThanOperator{
+					arg1: uint64(18446744073709551615),
+					arg2: json.Number("18446744073709551614"),
+				},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
 	}
 	batchOperatorTests(t, tests)
 }
@@ -437,6 +463,72 @@ func Test_equalsOperator(t *testing.T) {
 				value: false,
 			},
 		},
+		{
+			input: operatorTestInput{
+				operator: &equalsOperator{
+					arg1: json.Number("9007199254740993"),
+					arg2: "9007199254740992",
+				},
+			},
+			expected: operatorTestExpected{
+				value: false,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &equalsOperator{
+					arg1: json.Number("9007199254740993"),
+					arg2: int64(9007199254740993),
+				},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &equalsOperator{
+					arg1: json.Number("8.95"),
+					arg2: "8.95",
+				},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &equalsOperator{
+					arg1: json.Number("8.95"),
+					arg2: float64(8.95),
+				},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &equalsOperator{
+					arg1: big.NewFloat(0.5),
+					arg2: json.Number("0.5"),
+				},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &equalsOperator{
+					arg1: &plusOperator{arg1: json.Number("0.1"), arg2: json.Number("0.2")},
+					arg2: "0.3",
+				},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
 	}
 	batchOperatorTests(t, tests)
 }
@@ -509,6 +601,17 @@ func Test_notEqualsOperator(t *testing.T) {
 				value: false,
 			},
 		},
+		{
+			input: operatorTestInput{
+				operator: &notEqualsOperator{
+					arg1: json.Number("9007199254740993"),
+					arg2: "9007199254740992",
+				},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
 	}
 	batchOperatorTests(t, tests)
 }
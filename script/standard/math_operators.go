@@ -1,23 +1,24 @@
 package standard
 
-import "math"
+import (
+	"math"
+	"math/big"
+)
 
 type plusOperator struct {
 	arg1, arg2 interface{}
 }
 
 func (op *plusOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
-	first, err := getNumber(op.arg1, parameters)
+	first, second, err := getPreciseNumbers(op.arg1, op.arg2, parameters)
 	if err != nil {
 		return nil, err
 	}
 
-	second, err := getNumber(op.arg2, parameters)
-	if err != nil {
-		return nil, err
+	if useExact(first, second) {
+		return roundNumber(new(big.Rat).Add(first.exact, second.exact)), nil
 	}
-
-	return first + second, nil
+	return first.float + second.float, nil
 }
 
 type subtractOperator struct {
@@ -25,17 +26,15 @@ type subtractOperator struct {
 }
 
 func (op *subtractOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
-	first, err := getNumber(op.arg1, parameters)
+	first, second, err := getPreciseNumbers(op.arg1, op.arg2, parameters)
 	if err != nil {
 		return nil, err
 	}
 
-	second, err := getNumber(op.arg2, parameters)
-	if err != nil {
-		return nil, err
+	if useExact(first, second) {
+		return roundNumber(new(big.Rat).Sub(first.exact, second.exact)), nil
 	}
-
-	return first - second, nil
+	return first.float - second.float, nil
 }
 
 type multiplyOperator struct {
@@ -43,17 +42,15 @@ type multiplyOperator struct {
 }
 
 func (op *multiplyOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
-	first, err := getNumber(op.arg1, parameters)
+	first, second, err := getPreciseNumbers(op.arg1, op.arg2, parameters)
 	if err != nil {
 		return nil, err
 	}
 
-	second, err := getNumber(op.arg2, parameters)
-	if err != nil {
-		return nil, err
+	if useExact(first, second) {
+		return roundNumber(new(big.Rat).Mul(first.exact, second.exact)), nil
 	}
-
-	return first * second, nil
+	return first.float * second.float, nil
 }
 
 type divideOperator struct {
@@ -61,17 +58,16 @@ type divideOperator struct {
 }
 
 func (op *divideOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
-	first, err := getNumber(op.arg1, parameters)
+	first, second, err := getPreciseNumbers(op.arg1, op.arg2, parameters)
 	if err != nil {
 		return nil, err
 	}
 
-	second, err := getNumber(op.arg2, parameters)
-	if err != nil {
-		return nil, err
+	// division by zero results in an infinite or NaN float
+	if useExact(first, second) && second.exact.Sign() != 0 {
+		return roundNumber(new(big.Rat).Quo(first.exact, second.exact)), nil
 	}
-
-	return first / second, nil
+	return first.float / second.float, nil
 }
 
 type modulusOperator struct {
@@ -79,17 +75,29 @@ type modulusOperator struct {
 }
 
 func (op *modulusOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
-	first, err := getInteger(op.arg1, parameters)
+	first, second, err := getPreciseNumbers(op.arg1, op.arg2, parameters)
 	if err != nil {
+		if err == errInvalidArgumentExpectedNumber {
+			return nil, errInvalidArgumentExpectedInteger
+		}
 		return nil, err
 	}
 
-	second, err := getInteger(op.arg2, parameters)
-	if err != nil {
-		return nil, err
+	for _, num := range []*number{first, second} {
+		if num.exact == nil || !num.exact.IsInt() {
+			return nil, errInvalidArgumentExpectedInteger
+		}
+	}
+	dividend, divisor := first.exact.Num(), second.exact.Num()
+	if divisor.Sign() == 0 {
+		return nil, errInvalidArgumentDivisionByZero
 	}
 
-	return first % second, nil
+	if !first.precise && !second.precise && dividend.IsInt64() && divisor.IsInt64() {
+		return dividend.Int64() % divisor.Int64(), nil
+	}
+	remainder := new(big.Int).Rem(dividend, divisor)
+	return new(big.Float).SetPrec(numberPrecision).SetInt(remainder), nil
 }
 
 type powerOfOperator struct {
@@ -97,15 +105,38 @@ type powerOfOperator struct {
 }
 
 func (op *powerOfOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
-	first, err := getNumber(op.arg1, parameters)
+	first, second, err := getPreciseNumbers(op.arg1, op.arg2, parameters)
 	if err != nil {
 		return nil, err
 	}
 
-	second, err := getNumber(op.arg2, parameters)
-	if err != nil {
-		return nil, err
+	if useExact(first, second) && second.exact.IsInt() && second.exact.Num().IsInt64() {
+		return powerOf(roundNumber(first.exact), second.exact.Num().Int64()), nil
+	}
+	return math.Pow(first.float, second.float), nil
+}
+
+// powerOf returns the base raised to the integer exponent, using exponentiation by squaring
+func powerOf(base *big.Float, exponent int64) *big.Float {
+	negative := exponent < 0
+	magnitude := uint64(exponent)
+	if negative {
+		magnitude = uint64(-exponent)
 	}
 
-	return math.Pow(first, second), nil
+	result := new(big.Float).SetPrec(numberPrecision).SetInt64(1)
+	for ; magnitude > 0; magnitude >>= 1 {
+		if magnitude&1 == 1 {
+			result.Mul(result, base)
+		}
+		base = new(big.Float).Mul(base, base)
+	}
+
+	if negative {
+		if result.Sign() == 0 {
+			return result.SetInf(false)
+		}
+		return result.Quo(new(big.Float).SetPrec(numberPrecision).SetInt64(1), result)
+	}
+	return result
 }
@@ -1,6 +1,11 @@
 package standard
 
-import "testing"
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+)
 
 func Test_plusOperator(t *testing.T) {
 	tests := []*operatorTest{
@@ -37,6 +42,39 @@ func Test_plusOperator(t *testing.T) {
 				value: float64(3),
 			},
 		},
+		{
+			input: operatorTestInput{
+				operator: &plusOperator{
+					arg1: json.Number("9007199254740993"),
+					arg2: "1",
+				},
+			},
+			expected: operatorTestExpected{
+				value: preciseNumber("9007199254740994"),
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &plusOperator{
+					arg1: json.Number("0.1"),
+					arg2: json.Number("0.2"),
+				},
+			},
+			expected: operatorTestExpected{
+				value: preciseNumber("0.3"),
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &plusOperator{
+					arg1: json.Number("0.5"),
+					arg2: float64(1),
+				},
+			},
+			expected: operatorTestExpected{
+				value: float64(1.5),
+			},
+		},
 	}
 	batchOperatorTests(t, tests)
 }
@@ -76,6 +114,17 @@ func Test_subtractOperator(t *testing.T) {
 				value: float64(-1),
 			},
 		},
+		{
+			input: operatorTestInput{
+				operator: &subtractOperator{
+					arg1: uint64(math.MaxUint64),
+					arg2: "1",
+				},
+			},
+			expected: operatorTestExpected{
+				value: preciseNumber("18446744073709551614"),
+			},
+		},
 	}
 	batchOperatorTests(t, tests)
 }
@@ -115,6 +164,17 @@ func Test_multiplyOperator(t *testing.T) {
 				value: float64(4),
 			},
 		},
+		{
+			input: operatorTestInput{
+				operator: &multiplyOperator{
+					arg1: json.Number("9007199254740993"),
+					arg2: "2",
+				},
+			},
+			expected: operatorTestExpected{
+				value: preciseNumber("18014398509481986"),
+			},
+		},
 	}
 	batchOperatorTests(t, tests)
 }
@@ -154,6 +214,28 @@ func Test_divideOperator(t *testing.T) {
 				value: float64(2),
 			},
 		},
+		{
+			input: operatorTestInput{
+				operator: &divideOperator{
+					arg1: json.Number("1"),
+					arg2: "3",
+				},
+			},
+			expected: operatorTestExpected{
+				value: roundNumber(big.NewRat(1, 3)),
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &divideOperator{
+					arg1: json.Number("1"),
+					arg2: "0",
+				},
+			},
+			expected: operatorTestExpected{
+				value: math.Inf(1),
+			},
+		},
 	}
 	batchOperatorTests(t, tests)
 }
@@ -193,6 +275,39 @@ func Test_modulusOperator(t *testing.T) {
 				value: int64(1),
 			},
 		},
+		{
+			input: operatorTestInput{
+				operator: &modulusOperator{
+					arg1: json.Number("9007199254740993"),
+					arg2: "2",
+				},
+			},
+			expected: operatorTestExpected{
+				value: preciseNumber("1"),
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &modulusOperator{
+					arg1: "3",
+					arg2: "0",
+				},
+			},
+			expected: operatorTestExpected{
+				err: "invalid argument. division by zero",
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &modulusOperator{
+					arg1: "3.5",
+					arg2: "2",
+				},
+			},
+			expected: operatorTestExpected{
+				err: "invalid argument. expected integer",
+			},
+		},
 	}
 	batchOperatorTests(t, tests)
 }
@@ -232,6 +347,44 @@ func Test_powerOfOperator(t *testing.T) {
 				value: float64(9),
 			},
 		},
+		{
+			input: operatorTestInput{
+				operator: &powerOfOperator{
+					arg1: json.Number("3"),
+					arg2: "40",
+				},
+			},
+			expected: operatorTestExpected{
+				value: preciseNumber("12157665459056928801"),
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &powerOfOperator{
+					arg1: json.Number("2"),
+					arg2: "-2",
+				},
+			},
+			expected: operatorTestExpected{
+				value: preciseNumber("0.25"),
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &powerOfOperator{
+					arg1: json.Number("0"),
+					arg2: "-1",
+				},
+			},
+			expected: operatorTestExpected{
+				value: new(big.Float).SetPrec(numberPrecision).SetInf(false),
+			},
+		},
 	}
 	batchOperatorTests(t, tests)
 }
+
+func preciseNumber(value string) *big.Float {
+	number, _, _ := new(big.Float).SetPrec(numberPrecision).Parse(value, 10)
+	return number
+}
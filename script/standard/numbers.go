@@ -0,0 +1,242 @@
+package standard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// numberPrecision the precision, in bits, of the *big.Float values returned when calculating with precise numbers
+	numberPrecision uint = 512
+	// maxExactExponent the largest binary exponent of a number whose exact value is used,
+	// larger numbers are only used as a float64 to limit the memory required to hold their exact value.
+	maxExactExponent int = 4096
+	// maxFloatInteger the largest integer that can be represented by a float64 without losing precision
+	maxFloatInteger = 1 << 53
+)
+
+// number represents a numeric argument along with its exact value
+type number struct {
+	// float the value as a float64
+	float float64
+	// exact the exact value, nil if the value is not finite
+	exact *big.Rat
+	// binary the value is a binary floating point number, such as a float64
+	binary bool
+	// precise the value is of a type that preserves precision, such as json.Number or *big.Float,
+	// or can not be represented as a float64 without losing precision
+	precise bool
+}
+
+// newNumber returns the number represented by the value, false is returned if the value is not a number
+func newNumber(value interface{}) (*number, bool) {
+	switch typed := value.(type) {
+	case nil:
+		return nil, false
+	case json.Number:
+		return parseNumber(typed.String(), true)
+	case *big.Float:
+		if typed == nil {
+			return nil, false
+		}
+		float, _ := typed.Float64()
+		num := &number{float: float, precise: true}
+		if exponent := typed.MantExp(nil); !typed.IsInf() && exponent <= maxExactExponent && exponent >= -maxExactExponent {
+			num.exact, _ = typed.Rat(nil)
+		}
+		return num, true
+	case string:
+		return parseNumber(typed, false)
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		intVal := reflected.Int()
+		num := &number{float: float64(intVal), exact: new(big.Rat).SetInt64(intVal)}
+		num.precise = intVal > maxFloatInteger || intVal < -maxFloatInteger
+		return num, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uintVal := reflected.Uint()
+		num := &number{float: float64(uintVal), exact: new(big.Rat).SetInt(new(big.Int).SetUint64(uintVal))}
+		num.precise = uintVal > maxFloatInteger
+		return num, true
+	case reflect.Float32, reflect.Float64:
+		float := reflected.Float()
+		num := &number{float: float, binary: true}
+		if !math.IsInf(float, 0) && !math.IsNaN(float) {
+			num.exact = new(big.Rat).SetFloat64(float)
+		}
+		return num, true
+	}
+
+	return parseNumber(fmt.Sprintf("%v", value), false)
+}
+
+// parseNumber returns the number represented by the string,
+// integers that can not be represented as a float64 without losing precision are always precise.
+func parseNumber(str string, precise bool) (*number, bool) {
+	float, err := strconv.ParseFloat(str, 64)
+	if err != nil && !(precise && errors.Is(err, strconv.ErrRange)) {
+		return nil, false
+	}
+
+	num := &number{float: float, precise: precise}
+	if hasLargeExponent(str) {
+		return num, true
+	}
+	if exact, ok := new(big.Rat).SetString(str); ok {
+		num.exact = exact
+		if exact.IsInt() && !math.IsInf(float, 0) && new(big.Rat).SetFloat64(float).Cmp(exact) != 0 {
+			num.precise = true
+		}
+	}
+	return num, true
+}
+
+// hasLargeExponent returns true if the decimal exponent of the number string is larger than the maximum exact exponent
+func hasLargeExponent(str string) bool {
+	idx := strings.IndexAny(str, "eE")
+	if idx < 0 {
+		return false
+	}
+	exponent, err := strconv.Atoi(str[idx+1:])
+	if err != nil {
+		return true
+	}
+	// a decimal exponent is roughly 3.3 times smaller than the equivalent binary exponent
+	limit := maxExactExponent * 3 / 10
+	return exponent > limit || exponent < -limit
+}
+
+// useExact returns true if the numbers should be compared and calculated using their exact values,
+// this is only the case if either number is precise and neither is a binary floating point number.
+func useExact(first, second *number) bool {
+	if first.exact == nil || second.exact == nil {
+		return false
+	}
+	return (first.precise || second.precise) && !first.binary && !second.binary
+}
+
+// compareNumbers returns -1, 0, or +1 depending on if the first number is less than, equal to, or greater than
+// the second number, false is returned if the numbers can not be ordered.
+func compareNumbers(first, second *number) (int, bool) {
+	if useExact(first, second) {
+		// values are rounded so that exact values are equal to the results of calculations
+		return roundNumber(first.exact).Cmp(roundNumber(second.exact)), true
+	}
+
+	switch {
+	case math.IsNaN(first.float) || math.IsNaN(second.float):
+		return 0, false
+	case first.float < second.float:
+		return -1, true
+	case first.float > second.float:
+		return 1, true
+	}
+	return 0, true
+}
+
+// roundNumber returns the exact value as a *big.Float with the number precision
+func roundNumber(exact *big.Rat) *big.Float {
+	return new(big.Float).SetPrec(numberPrecision).SetRat(exact)
+}
+
+// getPreciseNumber returns the argument as a number, evaluating operators and replacing parameters
+func getPreciseNumber(argument interface{}, parameters map[string]interface{}) (*number, error) {
+	if argument == nil {
+		return nil, errInvalidArgumentNil
+	}
+	if parameters == nil {
+		parameters = make(map[string]interface{})
+	}
+
+	if sub, ok := argument.(operator); ok {
+		arg, err := sub.Evaluate(parameters)
+		if err != nil {
+			return nil, err
+		}
+		argument = arg
+	}
+
+	if str, ok := argument.(string); ok {
+		if arg, ok := parameters[str]; ok {
+			argument = arg
+		}
+	}
+
+	num, ok := newNumber(argument)
+	if !ok {
+		return nil, errInvalidArgumentExpectedNumber
+	}
+	return num, nil
+}
+
+// getPreciseNumbers returns both arguments as numbers
+func getPreciseNumbers(arg1, arg2 interface{}, parameters map[string]interface{}) (*number, *number, error) {
+	first, err := getPreciseNumber(arg1, parameters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	second, err := getPreciseNumber(arg2, parameters)
+	if err != nil {
+		return nil, nil, err
+	}
+	return first, second, nil
+}
+
+// getNumberLiteral returns the value of a number literal, as a float64 unless the literal is an integer
+// that can not be represented as a float64 in which case it is returned as a json.Number.
+func getNumberLiteral(literal string) (interface{}, bool) {
+	num, ok := parseNumber(literal, false)
+	if !ok {
+		return nil, false
+	}
+	if num.precise {
+		return json.Number(literal), true
+	}
+	return num.float, true
+}
+
+// decodeLiteral decodes an array or object literal, numbers are decoded in the same way as number literals
+// so integers that can not be represented as a float64 do not lose precision.
+func decodeLiteral(literal string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(literal))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errInvalidArgument
+	}
+	return convertNumberLiterals(value), nil
+}
+
+// convertNumberLiterals replaces the json.Number values within the value with the value of the number literal
+func convertNumberLiterals(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case json.Number:
+		if number, ok := getNumberLiteral(typed.String()); ok {
+			return number
+		}
+	case []interface{}:
+		for idx, element := range typed {
+			typed[idx] = convertNumberLiterals(element)
+		}
+	case map[string]interface{}:
+		for key, element := range typed {
+			typed[key] = convertNumberLiterals(element)
+		}
+	}
+	return value
+}
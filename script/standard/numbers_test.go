@@ -0,0 +1,162 @@
+package standard
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newNumber(t *testing.T) {
+
+	type expected struct {
+		float   float64
+		exact   bool
+		binary  bool
+		precise bool
+		ok      bool
+	}
+
+	tests := []struct {
+		input    interface{}
+		expected expected
+	}{
+		{
+			input:    nil,
+			expected: expected{},
+		},
+		{
+			input:    "value",
+			expected: expected{},
+		},
+		{
+			input:    true,
+			expected: expected{},
+		},
+		{
+			input:    "1.5",
+			expected: expected{float: 1.5, exact: true, ok: true},
+		},
+		{
+			input:    "9007199254740993",
+			expected: expected{float: 9007199254740992, exact: true, precise: true, ok: true},
+		},
+		{
+			input:    1.5,
+			expected: expected{float: 1.5, exact: true, binary: true, ok: true},
+		},
+		{
+			input:    int64(9007199254740993),
+			expected: expected{float: 9007199254740992, exact: true, precise: true, ok: true},
+		},
+		{
+			input:    uint8(3),
+			expected: expected{float: 3, exact: true, ok: true},
+		},
+		{
+			input:    json.Number("1.5"),
+			expected: expected{float: 1.5, exact: true, precise: true, ok: true},
+		},
+		{
+			input:    json.Number("1e5000"),
+			expected: expected{float: 0, exact: false, precise: true, ok: true},
+		},
+		{
+			input:    json.Number("1e-5000"),
+			expected: expected{float: 0, exact: false, precise: true, ok: true},
+		},
+		{
+			input:    big.NewFloat(1.5),
+			expected: expected{float: 1.5, exact: true, precise: true, ok: true},
+		},
+		{
+			input:    (*big.Float)(nil),
+			expected: expected{},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, ok := newNumber(test.input)
+			assert.Equal(t, test.expected.ok, ok)
+			if !ok {
+				assert.Nil(t, actual)
+				return
+			}
+			if test.expected.float != 0 {
+				assert.Equal(t, test.expected.float, actual.float)
+			}
+			assert.Equal(t, test.expected.exact, actual.exact != nil)
+			assert.Equal(t, test.expected.binary, actual.binary)
+			assert.Equal(t, test.expected.precise, actual.precise)
+		})
+	}
+}
+
+func Test_getNumberLiteral(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{input: "value", expected: nil},
+		{input: "1", expected: float64(1)},
+		{input: "0.1", expected: float64(0.1)},
+		{input: "-1e3", expected: float64(-1000)},
+		{input: "9007199254740992", expected: float64(9007199254740992)},
+		{input: "9007199254740993", expected: json.Number("9007199254740993")},
+		{input: "-18446744073709551615", expected: json.Number("-18446744073709551615")},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, ok := getNumberLiteral(test.input)
+			assert.Equal(t, test.expected != nil, ok)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_decodeLiteral(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		expected interface{}
+		err      string
+	}{
+		{
+			input:    `[1, "two", 9007199254740993]`,
+			expected: []interface{}{float64(1), "two", json.Number("9007199254740993")},
+		},
+		{
+			input: `{"a": [9007199254740993, 1.5], "b": {"c": -18446744073709551615}}`,
+			expected: map[string]interface{}{
+				"a": []interface{}{json.Number("9007199254740993"), float64(1.5)},
+				"b": map[string]interface{}{"c": json.Number("-18446744073709551615")},
+			},
+		},
+		{
+			input: `[1,]`,
+			err:   "invalid character ']' looking for beginning of value",
+		},
+		{
+			input: `[1] [2]`,
+			err:   "invalid argument",
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, err := decodeLiteral(test.input)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.Nil(t, actual)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
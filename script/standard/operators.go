@@ -1,11 +1,12 @@
 package standard
 
 import (
-	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+
+	"github.com/evilmonkeyinc/jsonpath/ordered"
 )
 
 type operator interface {
@@ -13,64 +14,26 @@ type operator interface {
 }
 
 func getInteger(argument interface{}, parameters map[string]interface{}) (int64, error) {
-	if argument == nil {
-		return 0, errInvalidArgumentNil
-	}
-	if parameters == nil {
-		parameters = make(map[string]interface{})
-	}
-
-	if sub, ok := argument.(operator); ok {
-		arg, err := sub.Evaluate(parameters)
-		if err != nil {
-			return 0, err
-		}
-		argument = arg
-	}
-
-	if str, ok := argument.(string); ok {
-		if arg, ok := parameters[str]; ok {
-			argument = arg
+	num, err := getPreciseNumber(argument, parameters)
+	if err != nil {
+		if err == errInvalidArgumentExpectedNumber {
+			return 0, errInvalidArgumentExpectedInteger
 		}
-
+		return 0, err
 	}
 
-	str := fmt.Sprintf("%v", argument)
-	intVal, err := strconv.ParseInt(str, 10, 64)
-	if err != nil {
+	if num.exact == nil || !num.exact.IsInt() || !num.exact.Num().IsInt64() {
 		return 0, errInvalidArgumentExpectedInteger
 	}
-	return intVal, nil
+	return num.exact.Num().Int64(), nil
 }
 
 func getNumber(argument interface{}, parameters map[string]interface{}) (float64, error) {
-	if argument == nil {
-		return 0, errInvalidArgumentNil
-	}
-	if parameters == nil {
-		parameters = make(map[string]interface{})
-	}
-
-	if sub, ok := argument.(operator); ok {
-		arg, err := sub.Evaluate(parameters)
-		if err != nil {
-			return 0, err
-		}
-		argument = arg
-	}
-
-	if str, ok := argument.(string); ok {
-		if arg, ok := parameters[str]; ok {
-			argument = arg
-		}
-	}
-
-	str := fmt.Sprintf("%v", argument)
-	floatVal, err := strconv.ParseFloat(str, 64)
+	num, err := getPreciseNumber(argument, parameters)
 	if err != nil {
-		return 0, errInvalidArgumentExpectedNumber
+		return 0, err
 	}
-	return floatVal, nil
+	return num.float, nil
 }
 
 func getBoolean(argument interface{}, parameters map[string]interface{}) (bool, error) {
@@ -151,7 +114,7 @@ func getString(argument interface{}, parameters map[string]interface{}) (string,
 		}
 	}
 
-	return fmt.Sprintf("%v", argument), nil
+	return fmt.Sprintf("%v", getObjectValues(argument)), nil
 }
 
 func getElements(argument interface{}, parameters map[string]interface{}) ([]interface{}, error) {
@@ -171,17 +134,11 @@ func getElements(argument interface{}, parameters map[string]interface{}) ([]int
 		if param, ok := parameters[strValue]; ok {
 			argument = param
 		} else {
-			if strings.HasPrefix(strValue, "{") && strings.HasSuffix(strValue, "}") {
-				// object
-				root := make(map[string]interface{})
-				if err := json.Unmarshal([]byte(strValue), &root); err != nil {
-					return nil, errInvalidArgument
-				}
-				argument = root
-			} else if strings.HasPrefix(strValue, "[") && strings.HasSuffix(strValue, "]") {
-				// array
-				root := make([]interface{}, 0)
-				if err := json.Unmarshal([]byte(strValue), &root); err != nil {
+			if (strings.HasPrefix(strValue, "{") && strings.HasSuffix(strValue, "}")) ||
+				(strings.HasPrefix(strValue, "[") && strings.HasSuffix(strValue, "]")) {
+				// object or array
+				root, err := decodeLiteral(strValue)
+				if err != nil {
 					return nil, errInvalidArgument
 				}
 				argument = root
@@ -189,6 +146,14 @@ func getElements(argument interface{}, parameters map[string]interface{}) ([]int
 		}
 	}
 
+	if object, ok := argument.(*ordered.Object); ok && object != nil {
+		elements := make([]interface{}, 0, object.Len())
+		for _, member := range object.Members() {
+			elements = append(elements, member.Value)
+		}
+		return elements, nil
+	}
+
 	objType := reflect.TypeOf(argument)
 	if objType == nil {
 		return nil, errInvalidArgumentNil
@@ -1,9 +1,12 @@
 package standard
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/evilmonkeyinc/jsonpath/ordered"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -88,6 +91,22 @@ func Test_getInteger(t *testing.T) {
 				err: "invalid argument. is nil",
 			},
 		},
+		{
+			input: input{
+				argument: json.Number("9007199254740993"),
+			},
+			expected: expected{
+				value: 9007199254740993,
+			},
+		},
+		{
+			input: input{
+				argument: uint64(18446744073709551615),
+			},
+			expected: expected{
+				err: "invalid argument. expected integer",
+			},
+		},
 	}
 
 	for idx, test := range tests {
@@ -194,6 +213,22 @@ func Test_getNumber(t *testing.T) {
 				err: "invalid argument. expected number",
 			},
 		},
+		{
+			input: input{
+				argument: json.Number("1.5"),
+			},
+			expected: expected{
+				value: float64(1.5),
+			},
+		},
+		{
+			input: input{
+				argument: big.NewFloat(2.5),
+			},
+			expected: expected{
+				value: float64(2.5),
+			},
+		},
 	}
 
 	for idx, test := range tests {
@@ -504,6 +539,20 @@ func Test_getElements(t *testing.T) {
 				value: []interface{}{"one", "two"},
 			},
 		},
+		{
+			input: input{
+				argument: "@",
+				parameters: map[string]interface{}{
+					"@": ordered.NewObject(
+						ordered.Member{Key: "two", Value: "two"},
+						ordered.Member{Key: "one", Value: "one"},
+					),
+				},
+			},
+			expected: expected{
+				value: []interface{}{"two", "one"},
+			},
+		},
 		{
 			input: input{
 				argument: "@",
@@ -0,0 +1,426 @@
+package standard
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/token"
+)
+
+var rfc9535NumberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?`)
+
+// rfc9535ComparisonOperators ordered so the two character operators are matched first
+var rfc9535ComparisonOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// compileRFC9535 parses the expression as an RFC 9535 filter logical expression
+func (engine *ScriptEngine) compileRFC9535(expression string, options *option.QueryOptions) (operator, error) {
+	parser := &rfc9535Parser{
+		source:  expression,
+		engine:  engine,
+		options: options,
+	}
+
+	operator, err := parser.parseLogicalOr()
+	if err != nil {
+		return nil, err
+	}
+	parser.skipBlank()
+	if !parser.atEnd() {
+		return nil, parser.unexpected()
+	}
+	return operator, nil
+}
+
+type rfc9535Parser struct {
+	source  string
+	idx     int
+	engine  *ScriptEngine
+	options *option.QueryOptions
+}
+
+func (parser *rfc9535Parser) atEnd() bool {
+	return parser.idx >= len(parser.source)
+}
+
+func (parser *rfc9535Parser) peek() byte {
+	if parser.atEnd() {
+		return 0
+	}
+	return parser.source[parser.idx]
+}
+
+func (parser *rfc9535Parser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(parser.source[parser.idx:], prefix)
+}
+
+func (parser *rfc9535Parser) skipBlank() {
+	for !parser.atEnd() {
+		switch parser.peek() {
+		case ' ', '\t', '\n', '\r':
+			parser.idx++
+			continue
+		}
+		return
+	}
+}
+
+func (parser *rfc9535Parser) unexpected() error {
+	found := ""
+	for _, rne := range parser.source[parser.idx:] {
+		found = string(rne)
+		break
+	}
+	return token.NewSyntaxError(getUnexpectedTokenError(found, parser.idx), parser.source, parser.idx, found)
+}
+
+func (parser *rfc9535Parser) parseLogicalOr() (operator, error) {
+	return parser.parseLogicalSequence("||", parser.parseLogicalAnd, func(args []operator) operator {
+		return &logicalOrOperator{args: args}
+	})
+}
+
+func (parser *rfc9535Parser) parseLogicalAnd() (operator, error) {
+	return parser.parseLogicalSequence("&&", parser.parseBasic, func(args []operator) operator {
+		return &logicalAndOperator{args: args}
+	})
+}
+
+// parseLogicalSequence parses one or more expressions separated by the logical operator
+func (parser *rfc9535Parser) parseLogicalSequence(separator string, parse func() (operator, error), build func(args []operator) operator) (operator, error) {
+	first, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	args := []operator{first}
+
+	for {
+		start := parser.idx
+		parser.skipBlank()
+		if !parser.hasPrefix(separator) {
+			parser.idx = start
+			break
+		}
+		parser.idx += len(separator)
+		parser.skipBlank()
+
+		arg, err := parse()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+
+	if len(args) == 1 {
+		return first, nil
+	}
+	return build(args), nil
+}
+
+// parseBasic parses a parenthesized, comparison, or test expression
+func (parser *rfc9535Parser) parseBasic() (operator, error) {
+	if parser.peek() == '!' {
+		parser.idx++
+		parser.skipBlank()
+
+		var arg operator
+		var err error
+		if parser.peek() == '(' {
+			arg, err = parser.parseParenthesized()
+		} else {
+			var operand operator
+			if operand, err = parser.parseOperand(); err == nil {
+				arg, err = getTestOperator(operand)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &logicalNotOperator{arg: arg}, nil
+	}
+
+	if parser.peek() == '(' {
+		return parser.parseParenthesized()
+	}
+
+	left, err := parser.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	start := parser.idx
+	parser.skipBlank()
+	for _, comparison := range rfc9535ComparisonOperators {
+		if !parser.hasPrefix(comparison) {
+			continue
+		}
+		parser.idx += len(comparison)
+		parser.skipBlank()
+
+		right, err := parser.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if !isComparable(left) || !isComparable(right) {
+			return nil, errInvalidArgumentExpectedValue
+		}
+		return &comparisonOperator{
+			operator: comparison,
+			arg1:     left,
+			arg2:     right,
+		}, nil
+	}
+	parser.idx = start
+
+	return getTestOperator(left)
+}
+
+func (parser *rfc9535Parser) parseParenthesized() (operator, error) {
+	parser.idx++
+	parser.skipBlank()
+
+	arg, err := parser.parseLogicalOr()
+	if err != nil {
+		return nil, err
+	}
+
+	parser.skipBlank()
+	if parser.peek() != ')' {
+		return nil, parser.unexpected()
+	}
+	parser.idx++
+	return arg, nil
+}
+
+// parseOperand parses a literal, query, or function expression
+func (parser *rfc9535Parser) parseOperand() (operator, error) {
+	switch next := parser.peek(); {
+	case next == '$' || next == '@':
+		tokens, length, err := token.ParseRFC9535Query(parser.source[parser.idx:], parser.engine, parser.options)
+		if err != nil {
+			return nil, err
+		}
+		parser.idx += length
+		return &queryOperand{
+			query:    parser.source[parser.idx-length : parser.idx],
+			tokens:   tokens,
+			singular: token.IsSingularQuery(tokens),
+		}, nil
+	case next == '\'' || next == '"':
+		value, err := parser.parseStringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return &literalOperand{value: value}, nil
+	case next == '-' || (next >= '0' && next <= '9'):
+		match := rfc9535NumberPattern.FindString(parser.source[parser.idx:])
+		if match == "" {
+			return nil, parser.unexpected()
+		}
+		number, ok := getNumberLiteral(match)
+		if !ok {
+			return nil, parser.unexpected()
+		}
+		parser.idx += len(match)
+		return &literalOperand{value: number}, nil
+	case next >= 'a' && next <= 'z':
+		return parser.parseNameOperand()
+	default:
+		return nil, parser.unexpected()
+	}
+}
+
+// parseNameOperand parses a function expression or one of the true, false, or null literals,
+// uppercase characters are allowed after the first character to support registered function names.
+func (parser *rfc9535Parser) parseNameOperand() (operator, error) {
+	start := parser.idx
+	for !parser.atEnd() {
+		next := parser.peek()
+		if (next >= 'a' && next <= 'z') || (next >= 'A' && next <= 'Z') || (next >= '0' && next <= '9') || next == '_' {
+			parser.idx++
+			continue
+		}
+		break
+	}
+	name := parser.source[start:parser.idx]
+
+	if parser.peek() != '(' {
+		switch name {
+		case "true":
+			return &literalOperand{value: true}, nil
+		case "false":
+			return &literalOperand{value: false}, nil
+		case "null":
+			return &literalOperand{value: nil}, nil
+		}
+		parser.idx = start
+		return nil, parser.unexpected()
+	}
+
+	function, ok := parser.engine.getFunction(name)
+	if !ok {
+		parser.idx = start
+		return nil, getUnknownFunctionError(name)
+	}
+	parser.idx++
+
+	arguments := make([]interface{}, 0)
+	parser.skipBlank()
+	for parser.peek() != ')' {
+		if len(arguments) > 0 {
+			if parser.peek() != ',' {
+				return nil, parser.unexpected()
+			}
+			parser.idx++
+			parser.skipBlank()
+		}
+
+		if len(arguments) >= len(function.Parameters) {
+			return nil, getInvalidFunctionArgumentCountError(name, len(function.Parameters), len(arguments)+1)
+		}
+		argument, err := parser.parseFunctionArgument(function.Parameters[len(arguments)])
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+		parser.skipBlank()
+	}
+	parser.idx++
+
+	if len(arguments) != len(function.Parameters) {
+		return nil, getInvalidFunctionArgumentCountError(name, len(function.Parameters), len(arguments))
+	}
+
+	return &functionOperator{
+		name:      name,
+		function:  function,
+		arguments: arguments,
+		rfc9535:   true,
+	}, nil
+}
+
+// parseFunctionArgument parses a function argument and checks it is well-typed for the parameter
+func (parser *rfc9535Parser) parseFunctionArgument(parameter FunctionType) (operator, error) {
+	start := parser.idx
+	var argument operator
+
+	// a literal, query, or function on its own is used as is, anything else must be a logical expression
+	if operand, err := parser.parseOperand(); err == nil {
+		end := parser.idx
+		parser.skipBlank()
+		if next := parser.peek(); next == ',' || next == ')' {
+			parser.idx = end
+			argument = operand
+		}
+	}
+	if argument == nil {
+		parser.idx = start
+		logical, err := parser.parseLogicalOr()
+		if err != nil {
+			return nil, err
+		}
+
+		end := parser.idx
+		parser.skipBlank()
+		if next := parser.peek(); next != ',' && next != ')' {
+			return nil, parser.unexpected()
+		}
+		parser.idx = end
+		argument = logical
+	}
+
+	switch parameter {
+	case ValueType:
+		if !isComparable(argument) {
+			return nil, errInvalidArgumentExpectedValue
+		}
+	case NodesType:
+		switch typed := argument.(type) {
+		case *queryOperand:
+		case *functionOperator:
+			if typed.function.Result != NodesType {
+				return nil, errInvalidArgumentExpectedNodes
+			}
+		default:
+			return nil, errInvalidArgumentExpectedNodes
+		}
+	case LogicalType:
+		switch typed := argument.(type) {
+		case *literalOperand:
+			return nil, errInvalidArgumentExpectedLogical
+		case *functionOperator:
+			if typed.function.Result == ValueType {
+				return nil, errInvalidArgumentExpectedLogical
+			}
+		}
+	}
+	return argument, nil
+}
+
+// parseStringLiteral parses a single or double quoted string literal,
+// the escape sequences allowed by RFC 9535 are the same as those allowed by JSON
+// with the addition of escaping the single quote within single quoted strings.
+func (parser *rfc9535Parser) parseStringLiteral() (string, error) {
+	quote := parser.peek()
+	builder := strings.Builder{}
+	builder.WriteByte('"')
+
+	for idx := parser.idx + 1; idx < len(parser.source); idx++ {
+		char := parser.source[idx]
+		switch {
+		case char == '\\' && idx+1 < len(parser.source):
+			idx++
+			escaped := parser.source[idx]
+			if escaped == '\'' && quote == '\'' {
+				builder.WriteByte('\'')
+				continue
+			}
+			builder.WriteByte('\\')
+			builder.WriteByte(escaped)
+		case char == quote:
+			builder.WriteByte('"')
+
+			var value string
+			if err := json.Unmarshal([]byte(builder.String()), &value); err != nil {
+				return "", parser.unexpected()
+			}
+			parser.idx = idx + 1
+			return value, nil
+		case char == '"':
+			builder.WriteString(`\"`)
+		default:
+			builder.WriteByte(char)
+		}
+	}
+
+	parser.idx = len(parser.source)
+	return "", parser.unexpected()
+}
+
+func isComparable(arg operator) bool {
+	switch typed := arg.(type) {
+	case *literalOperand:
+		return true
+	case *queryOperand:
+		return typed.singular
+	case *functionOperator:
+		return typed.function.Result == ValueType
+	}
+	return false
+}
+
+func getTestOperator(arg operator) (operator, error) {
+	switch typed := arg.(type) {
+	case *queryOperand:
+		return &existenceOperator{arg: typed}, nil
+	case *functionOperator:
+		switch typed.function.Result {
+		case LogicalType:
+			return typed, nil
+		case NodesType:
+			return &existenceOperator{arg: typed}, nil
+		}
+	}
+	return nil, errInvalidArgumentExpectedTestable
+}
@@ -0,0 +1,252 @@
+package standard
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+
+	"github.com/evilmonkeyinc/jsonpath/token"
+)
+
+// nothingValue represents the absence of a value, the result of a singular query that selects no node
+type nothingValue struct{}
+
+var nothing = nothingValue{}
+
+type literalOperand struct {
+	value interface{}
+}
+
+func (op *literalOperand) Evaluate(parameters map[string]interface{}) (interface{}, error) {
+	return op.value, nil
+}
+
+type queryOperand struct {
+	query    string
+	tokens   []token.Token
+	singular bool
+}
+
+// Evaluate returns the value of the single selected node, or nothing if the query does not select exactly one node
+func (op *queryOperand) Evaluate(parameters map[string]interface{}) (interface{}, error) {
+	nodes, err := op.nodes(parameters)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) != 1 {
+		return nothing, nil
+	}
+	return nodes[0].Value, nil
+}
+
+func (op *queryOperand) nodes(parameters map[string]interface{}) ([]*token.Node, error) {
+	root := parameters["$"]
+	current := parameters["@"]
+
+	return op.tokens[0].ApplyNodes(getContext(parameters), root, &token.Node{Path: token.Path{}, Value: current}, op.tokens[1:])
+}
+
+// existenceOperator tests if a query, or function that returns nodes, selects at least one node
+type existenceOperator struct {
+	arg operator
+}
+
+func (op *existenceOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
+	if query, ok := op.arg.(nodesOperator); ok {
+		nodes, err := query.nodes(parameters)
+		if err != nil {
+			return nil, err
+		}
+		return len(nodes) > 0, nil
+	}
+
+	result, err := op.arg.Evaluate(parameters)
+	if err != nil {
+		return nil, err
+	}
+	nodes, _ := result.([]*token.Node)
+	return len(nodes) > 0, nil
+}
+
+type logicalOrOperator struct {
+	args []operator
+}
+
+func (op *logicalOrOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
+	for _, arg := range op.args {
+		value, err := getBoolean(arg, parameters)
+		if err != nil {
+			return nil, err
+		}
+		if value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type logicalAndOperator struct {
+	args []operator
+}
+
+func (op *logicalAndOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
+	for _, arg := range op.args {
+		value, err := getBoolean(arg, parameters)
+		if err != nil {
+			return nil, err
+		}
+		if !value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+type logicalNotOperator struct {
+	arg operator
+}
+
+func (op *logicalNotOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
+	value, err := getBoolean(op.arg, parameters)
+	if err != nil {
+		return nil, err
+	}
+	return !value, nil
+}
+
+type comparisonOperator struct {
+	operator   string
+	arg1, arg2 operator
+}
+
+func (op *comparisonOperator) Evaluate(parameters map[string]interface{}) (interface{}, error) {
+	first, err := op.arg1.Evaluate(parameters)
+	if err != nil {
+		return nil, err
+	}
+	second, err := op.arg2.Evaluate(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.operator {
+	case "==":
+		return compareEqual(first, second), nil
+	case "!=":
+		return !compareEqual(first, second), nil
+	case "<":
+		return compareLess(first, second), nil
+	case "<=":
+		return compareLess(first, second) || compareEqual(first, second), nil
+	case ">":
+		return compareLess(second, first), nil
+	case ">=":
+		return compareLess(second, first) || compareEqual(first, second), nil
+	}
+	return nil, errUnsupportedOperator
+}
+
+// compareEqual compares two values following the RFC 9535 comparison rules,
+// nothing is only equal to nothing and numbers are compared by value regardless of their type.
+func compareEqual(first, second interface{}) bool {
+	_, firstNothing := first.(nothingValue)
+	_, secondNothing := second.(nothingValue)
+	if firstNothing || secondNothing {
+		return firstNothing && secondNothing
+	}
+
+	if first == nil || second == nil {
+		return first == nil && second == nil
+	}
+
+	if firstNumber, ok := getComparableNumber(first); ok {
+		secondNumber, ok := getComparableNumber(second)
+		if !ok {
+			return false
+		}
+		comparison, ok := compareNumbers(firstNumber, secondNumber)
+		return ok && comparison == 0
+	}
+
+	first, second = getObjectValues(first), getObjectValues(second)
+	firstValue := reflect.ValueOf(first)
+	secondValue := reflect.ValueOf(second)
+
+	switch firstValue.Kind() {
+	case reflect.Array, reflect.Slice:
+		if secondValue.Kind() != reflect.Array && secondValue.Kind() != reflect.Slice {
+			return false
+		}
+		if firstValue.Len() != secondValue.Len() {
+			return false
+		}
+		for idx := 0; idx < firstValue.Len(); idx++ {
+			if !compareEqual(firstValue.Index(idx).Interface(), secondValue.Index(idx).Interface()) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if secondValue.Kind() != reflect.Map || firstValue.Len() != secondValue.Len() {
+			return false
+		}
+		secondKeys := make(map[string]reflect.Value)
+		for _, key := range secondValue.MapKeys() {
+			secondKeys[token.MapKeyString(key)] = key
+		}
+		for _, key := range firstValue.MapKeys() {
+			secondKey, ok := secondKeys[token.MapKeyString(key)]
+			if !ok {
+				return false
+			}
+			if !compareEqual(firstValue.MapIndex(key).Interface(), secondValue.MapIndex(secondKey).Interface()) {
+				return false
+			}
+		}
+		return true
+	case reflect.String:
+		return secondValue.Kind() == reflect.String && firstValue.String() == secondValue.String()
+	case reflect.Bool:
+		return secondValue.Kind() == reflect.Bool && firstValue.Bool() == secondValue.Bool()
+	}
+
+	return reflect.DeepEqual(first, second)
+}
+
+// compareLess returns true if the first value is less than the second, only numbers and strings can be ordered
+func compareLess(first, second interface{}) bool {
+	if firstNumber, ok := getComparableNumber(first); ok {
+		secondNumber, ok := getComparableNumber(second)
+		if !ok {
+			return false
+		}
+		comparison, ok := compareNumbers(firstNumber, secondNumber)
+		return ok && comparison < 0
+	}
+
+	firstValue := reflect.ValueOf(first)
+	secondValue := reflect.ValueOf(second)
+	if firstValue.Kind() == reflect.String && secondValue.Kind() == reflect.String {
+		return firstValue.String() < secondValue.String()
+	}
+	return false
+}
+
+// getComparableNumber returns the value as a number, strings are never treated as numbers
+func getComparableNumber(value interface{}) (*number, bool) {
+	switch value.(type) {
+	case json.Number, *big.Float:
+		return newNumber(value)
+	}
+	if value == nil {
+		return nil, false
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return newNumber(value)
+	}
+	return nil, false
+}
@@ -0,0 +1,217 @@
+package standard
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_literalOperand(t *testing.T) {
+	tests := []*operatorTest{
+		{
+			input: operatorTestInput{
+				operator: &literalOperand{value: "value"},
+			},
+			expected: operatorTestExpected{
+				value: "value",
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &literalOperand{value: nil},
+			},
+			expected: operatorTestExpected{
+				value: nil,
+			},
+		},
+	}
+	batchOperatorTests(t, tests)
+}
+
+func Test_logicalOperators(t *testing.T) {
+	tests := []*operatorTest{
+		{
+			input: operatorTestInput{
+				operator: &logicalOrOperator{args: []operator{
+					&literalOperand{value: false},
+					&literalOperand{value: true},
+				}},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &logicalOrOperator{args: []operator{
+					&literalOperand{value: "invalid"},
+				}},
+			},
+			expected: operatorTestExpected{
+				err: "invalid argument. expected boolean",
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &logicalAndOperator{args: []operator{
+					&literalOperand{value: true},
+					&literalOperand{value: false},
+				}},
+			},
+			expected: operatorTestExpected{
+				value: false,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &logicalAndOperator{args: []operator{
+					&literalOperand{value: "invalid"},
+				}},
+			},
+			expected: operatorTestExpected{
+				err: "invalid argument. expected boolean",
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &logicalNotOperator{arg: &literalOperand{value: false}},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &logicalNotOperator{arg: &literalOperand{value: "invalid"}},
+			},
+			expected: operatorTestExpected{
+				err: "invalid argument. expected boolean",
+			},
+		},
+	}
+	batchOperatorTests(t, tests)
+}
+
+func Test_comparisonOperator(t *testing.T) {
+	tests := []*operatorTest{
+		{
+			input: operatorTestInput{
+				operator: &comparisonOperator{
+					operator: "==",
+					arg1:     &literalOperand{value: 1},
+					arg2:     &literalOperand{value: float64(1)},
+				},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &comparisonOperator{
+					operator: "!=",
+					arg1:     &literalOperand{value: nothing},
+					arg2:     &literalOperand{value: nil},
+				},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &comparisonOperator{
+					operator: ">=",
+					arg1:     &literalOperand{value: "b"},
+					arg2:     &literalOperand{value: "a"},
+				},
+			},
+			expected: operatorTestExpected{
+				value: true,
+			},
+		},
+		{
+			input: operatorTestInput{
+				operator: &comparisonOperator{
+					operator: "=~",
+					arg1:     &literalOperand{value: "b"},
+					arg2:     &literalOperand{value: "a"},
+				},
+			},
+			expected: operatorTestExpected{
+				err: "unsupported operator",
+			},
+		},
+	}
+	batchOperatorTests(t, tests)
+}
+
+func Test_compareEqual(t *testing.T) {
+	tests := []struct {
+		first, second interface{}
+		expected      bool
+	}{
+		{first: nothing, second: nothing, expected: true},
+		{first: nothing, second: nil, expected: false},
+		{first: nil, second: nil, expected: true},
+		{first: nil, second: false, expected: false},
+		{first: int8(1), second: uint(1), expected: true},
+		{first: 1.5, second: float32(1.5), expected: true},
+		{first: 1, second: "1", expected: false},
+		{first: "a", second: "a", expected: true},
+		{first: true, second: "true", expected: false},
+		{first: []int{1, 2}, second: [2]float64{1, 2}, expected: true},
+		{first: []int{1, 2}, second: []int{1}, expected: false},
+		{first: []int{1, 2}, second: map[string]int{}, expected: false},
+		{first: map[string]int{"a": 1}, second: map[string]interface{}{"a": float64(1)}, expected: true},
+		{first: map[string]int{"a": 1}, second: map[string]int{"b": 1}, expected: false},
+		{first: map[interface{}]interface{}{"a": 1, 2: "b"}, second: map[string]interface{}{"a": 1, "2": "b"}, expected: true},
+		{first: map[interface{}]interface{}{"a": 1}, second: map[interface{}]interface{}{"b": 1}, expected: false},
+		{first: map[int]int{1: 1, 10: 2}, second: map[string]int{"1": 1, "10": 2}, expected: true},
+		{first: ordered.NewObject(ordered.Member{Key: "b", Value: 2}, ordered.Member{Key: "a", Value: 1}), second: map[string]int{"a": 1, "b": 2}, expected: true},
+		{first: map[string]int{"a": 1}, second: ordered.NewObject(ordered.Member{Key: "a", Value: "1"}), expected: false},
+		{first: struct{ A int }{A: 1}, second: struct{ A int }{A: 1}, expected: true},
+		{first: json.Number("9007199254740993"), second: json.Number("9007199254740992"), expected: false},
+		{first: json.Number("9007199254740993"), second: int64(9007199254740993), expected: true},
+		{first: uint64(math.MaxUint64), second: json.Number("18446744073709551615"), expected: true},
+		{first: json.Number("1.0"), second: 1, expected: true},
+		{first: json.Number("1"), second: "1", expected: false},
+		{first: big.NewFloat(1.5), second: json.Number("1.5"), expected: true},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, compareEqual(test.first, test.second))
+		})
+	}
+}
+
+func Test_compareLess(t *testing.T) {
+	tests := []struct {
+		first, second interface{}
+		expected      bool
+	}{
+		{first: 1, second: 2, expected: true},
+		{first: 2, second: 1.5, expected: false},
+		{first: 1, second: "2", expected: false},
+		{first: "a", second: "b", expected: true},
+		{first: "é", second: "z", expected: false},
+		{first: false, second: true, expected: false},
+		{first: nothing, second: 1, expected: false},
+		{first: nil, second: 1, expected: false},
+		{first: json.Number("9007199254740992"), second: json.Number("9007199254740993"), expected: true},
+		{first: json.Number("9007199254740993"), second: 9007199254740992, expected: false},
+		{first: json.Number("1"), second: "2", expected: false},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, compareLess(test.first, test.second))
+		})
+	}
+}
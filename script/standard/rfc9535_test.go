@@ -0,0 +1,133 @@
+package standard
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ScriptEngine_compileRFC9535(t *testing.T) {
+
+	tests := []struct {
+		expression string
+		err        string
+	}{
+		{expression: "@.a"},
+		{expression: "!@.a"},
+		{expression: "@.a == 'value'"},
+		{expression: "@.a==1&&@.b!=2||!(@.c<=3)"},
+		{expression: "( @.a > 1 ) && $.b"},
+		{expression: "@.a == -1.5e+3"},
+		{expression: "@.a == true || @.a == false || @.a == null"},
+		{expression: "@[0] == @['a'][-1]"},
+		{expression: "@..a"},
+		{expression: "@.a[?@.b == 1]"},
+		{expression: "length(@.a) > 2"},
+		{expression: "count(@.*) == 1 && !match(@.a, 'x')"},
+		{expression: "search(value(@..a), '[a-z]+')"},
+		{expression: "length( length(@.a) ) == 1"},
+		{expression: "length(@.*) == 1", err: "invalid argument. expected literal, singular query, or value function"},
+		{expression: "length(@.a)", err: "invalid argument. expected query or logical function"},
+		{expression: "match(@.a, 'x') == true", err: "invalid argument. expected literal, singular query, or value function"},
+		{expression: "count(1) == 1", err: "invalid argument. expected query"},
+		{expression: "count(length(@.a)) == 1", err: "invalid argument. expected query"},
+		{expression: "match(@.a)", err: "invalid argument. function 'match' expects 2 arguments got 1"},
+		{expression: "value(@.a, @.b) == 1", err: "invalid argument. function 'value' expects 1 arguments got 2"},
+		{expression: "unknown(@.a)", err: "unknown function 'unknown'"},
+		{expression: "length(@.a", err: "unexpected token '' at index 10"},
+		{expression: "length(@.a @.b)", err: "unexpected token '@' at index 11"},
+		{expression: "", err: "unexpected token '' at index 0"},
+		{expression: "1", err: "invalid argument. expected query or logical function"},
+		{expression: "'value'", err: "invalid argument. expected query or logical function"},
+		{expression: "!1", err: "invalid argument. expected query or logical function"},
+		{expression: "@.* == 1", err: "invalid argument. expected literal, singular query, or value function"},
+		{expression: "1 == @..a", err: "invalid argument. expected literal, singular query, or value function"},
+		{expression: "!@.a == 1", err: "unexpected token '=' at index 5"},
+		{expression: "@.a = 1", err: "unexpected token '=' at index 4"},
+		{expression: "@.a == 01", err: "unexpected token '1' at index 8"},
+		{expression: "@.a == 'value", err: "unexpected token '' at index 13"},
+		{expression: "@.a == '\\x'", err: "unexpected token ''' at index 7"},
+		{expression: "(@.a", err: "unexpected token '' at index 4"},
+		{expression: "@.a &&", err: "unexpected token '' at index 6"},
+		{expression: "@.a == value", err: "unexpected token 'v' at index 7"},
+		{expression: "@.", err: "unexpected token '' at index 2"},
+	}
+
+	engine := &ScriptEngine{RFC9535: true}
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			compiled, err := engine.Compile(test.expression, nil)
+			if test.err == "" {
+				assert.Nil(t, err)
+				assert.NotNil(t, compiled)
+			} else {
+				assert.EqualError(t, err, test.err)
+				assert.Nil(t, compiled)
+			}
+		})
+	}
+}
+
+func Test_ScriptEngine_EvaluateRFC9535(t *testing.T) {
+
+	root := map[string]interface{}{
+		"limit": 10,
+		"name":  "root",
+	}
+
+	tests := []struct {
+		expression string
+		current    interface{}
+		expected   bool
+	}{
+		{expression: "@.a", current: map[string]interface{}{"a": nil}, expected: true},
+		{expression: "@.a", current: map[string]interface{}{"b": 1}, expected: false},
+		{expression: "!@.a", current: map[string]interface{}{"b": 1}, expected: true},
+		{expression: "@.a == 1", current: map[string]interface{}{"a": float64(1)}, expected: true},
+		{expression: "@.a == 1", current: map[string]interface{}{"a": "1"}, expected: false},
+		{expression: "@.a == @.b", current: map[string]interface{}{}, expected: true},
+		{expression: "@.a == null", current: map[string]interface{}{}, expected: false},
+		{expression: "@.a != 1", current: map[string]interface{}{}, expected: true},
+		{expression: "@.a < 1", current: map[string]interface{}{}, expected: false},
+		{expression: "@.a <= @.b", current: map[string]interface{}{}, expected: true},
+		{expression: "@ < $.limit", current: 9, expected: true},
+		{expression: "@ >= $.limit", current: int64(10), expected: true},
+		{expression: "@ > $.limit", current: 10, expected: false},
+		{expression: "@ > 'a'", current: "b", expected: true},
+		{expression: "@ < 'a'", current: true, expected: false},
+		{expression: "@ <= true", current: true, expected: true},
+		{expression: "@ == $.name", current: "root", expected: true},
+		{expression: "@ == \"it's\"", current: "it's", expected: true},
+		{expression: "@ == 'it\\'s'", current: "it's", expected: true},
+		{expression: "@ == '\\u00e9'", current: "é", expected: true},
+		{expression: "@.a == @.b", current: map[string]interface{}{"a": []interface{}{1, "x"}, "b": []interface{}{float64(1), "x"}}, expected: true},
+		{expression: "@.a == @.b", current: map[string]interface{}{"a": map[string]interface{}{"x": 1}, "b": map[string]interface{}{"x": 1}}, expected: true},
+		{expression: "@.a == @.b", current: map[string]interface{}{"a": map[string]interface{}{"x": 1}, "b": map[string]interface{}{"y": 1}}, expected: false},
+		{expression: "@.a || @.b && @.c", current: map[string]interface{}{"a": 1}, expected: true},
+		{expression: "(@.a || @.b) && @.c", current: map[string]interface{}{"a": 1}, expected: false},
+		{expression: "@.a[?@ > 2]", current: map[string]interface{}{"a": []interface{}{1, 2, 3}}, expected: true},
+		{expression: "@.a[?@ > 3]", current: map[string]interface{}{"a": []interface{}{1, 2, 3}}, expected: false},
+		{expression: "@..c", current: map[string]interface{}{"a": []interface{}{map[string]interface{}{"c": 1}}}, expected: true},
+		{expression: "length(@.a) == 2", current: map[string]interface{}{"a": "ab"}, expected: true},
+		{expression: "length(@.a) == 2", current: map[string]interface{}{"a": []interface{}{1}}, expected: false},
+		{expression: "length(@.a) == @.b", current: map[string]interface{}{"a": 1}, expected: true},
+		{expression: "count(@.*) == 2", current: map[string]interface{}{"a": 1, "b": 2}, expected: true},
+		{expression: "count(@..*) > count($.*)", current: map[string]interface{}{"a": []interface{}{1, 2}}, expected: true},
+		{expression: "match(@, '1974-05-..')", current: "1974-05-01", expected: true},
+		{expression: "match(@, '05')", current: "1974-05-01", expected: false},
+		{expression: "search(@, '05')", current: "1974-05-01", expected: true},
+		{expression: "search(@, $.name)", current: "the root", expected: true},
+		{expression: "value(@..b) == 2", current: map[string]interface{}{"a": map[string]interface{}{"b": 2}}, expected: true},
+		{expression: "value(@.*) == 2", current: map[string]interface{}{"a": 2, "b": 2}, expected: false},
+	}
+
+	engine := &ScriptEngine{RFC9535: true}
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, err := engine.Evaluate(root, test.current, test.expression, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package standard
+
+import (
+	"strings"
+
+	"github.com/evilmonkeyinc/jsonpath/ast"
+	"github.com/evilmonkeyinc/jsonpath/token"
+)
+
+// Tree returns the syntax tree of the expression
+func (compiled *compiledExpression) Tree() ast.Expression {
+	if compiled.rootOperator == nil {
+		return getLiteralTree(strings.TrimSpace(compiled.expression))
+	}
+	return getExpressionTree(compiled.rootOperator)
+}
+
+// getExpressionTree returns the syntax tree of an operator, or an argument of an operator
+func getExpressionTree(argument interface{}) ast.Expression {
+	binary := func(operator string, arg1, arg2 interface{}) ast.Expression {
+		return &ast.BinaryExpression{
+			Operator: operator,
+			Left:     getExpressionTree(arg1),
+			Right:    getExpressionTree(arg2),
+		}
+	}
+
+	switch op := argument.(type) {
+	case nil:
+		return nil
+	case string:
+		return getLiteralTree(op)
+	case *selectorOperator:
+		return &ast.QueryExpression{Query: token.Tree(op.tokens)}
+	case *queryOperand:
+		return &ast.QueryExpression{Query: token.Tree(op.tokens)}
+	case *literalOperand:
+		return &ast.Literal{Value: op.value}
+	case *existenceOperator:
+		return getExpressionTree(op.arg)
+	case *functionOperator:
+		arguments := make([]ast.Expression, len(op.arguments))
+		for idx, argument := range op.arguments {
+			arguments[idx] = getExpressionTree(argument)
+		}
+		return &ast.FunctionCall{Name: op.name, Arguments: arguments}
+	case *notOperator:
+		return &ast.UnaryExpression{Operator: "!", Operand: getExpressionTree(op.arg)}
+	case *logicalNotOperator:
+		return &ast.UnaryExpression{Operator: "!", Operand: getExpressionTree(op.arg)}
+	case *logicalOrOperator:
+		return getLogicalTree("||", op.args)
+	case *logicalAndOperator:
+		return getLogicalTree("&&", op.args)
+	case *comparisonOperator:
+		return binary(op.operator, op.arg1, op.arg2)
+	case *orOperator:
+		return binary("||", op.arg1, op.arg2)
+	case *andOperator:
+		return binary("&&", op.arg1, op.arg2)
+	case *equalsOperator:
+		return binary("==", op.arg1, op.arg2)
+	case *notEqualsOperator:
+		return binary("!=", op.arg1, op.arg2)
+	case *lessThanOperator:
+		return binary("<", op.arg1, op.arg2)
+	case *lessThanOrEqualOperator:
+		return binary("<=", op.arg1, op.arg2)
+	case *greaterThanOperator:
+		return binary(">", op.arg1, op.arg2)
+	case *greaterThanOrEqualOperator:
+		return binary(">=", op.arg1, op.arg2)
+	case *regexOperator:
+		if pattern, ok := op.arg2.(string); ok && len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+			// regular expression literals are described as they are written
+			return &ast.BinaryExpression{
+				Operator: "=~",
+				Left:     getExpressionTree(op.arg1),
+				Right:    &ast.RawExpression{Source: pattern},
+			}
+		}
+		return binary("=~", op.arg1, op.arg2)
+	case *inOperator:
+		return binary("in", op.arg1, op.arg2)
+	case *notInOperator:
+		return binary("not in", op.arg1, op.arg2)
+	case *plusOperator:
+		return binary("+", op.arg1, op.arg2)
+	case *subtractOperator:
+		return binary("-", op.arg1, op.arg2)
+	case *multiplyOperator:
+		return binary("*", op.arg1, op.arg2)
+	case *divideOperator:
+		return binary("/", op.arg1, op.arg2)
+	case *modulusOperator:
+		return binary("%", op.arg1, op.arg2)
+	case *powerOfOperator:
+		return binary("**", op.arg1, op.arg2)
+	}
+	return &ast.Literal{Value: argument}
+}
+
+// getLogicalTree returns the arguments joined by the logical operator as left associative binary expressions
+func getLogicalTree(operator string, args []operator) ast.Expression {
+	var tree ast.Expression
+	for _, arg := range args {
+		if tree == nil {
+			tree = getExpressionTree(arg)
+			continue
+		}
+		tree = &ast.BinaryExpression{
+			Operator: operator,
+			Left:     tree,
+			Right:    getExpressionTree(arg),
+		}
+	}
+	return tree
+}
+
+// getLiteralTree returns the literal value of an argument in the way it is evaluated by the operators
+func getLiteralTree(argument string) ast.Expression {
+	switch argument {
+	case "nil", "null":
+		return &ast.Literal{Value: nil}
+	case "true":
+		return &ast.Literal{Value: true}
+	case "false":
+		return &ast.Literal{Value: false}
+	}
+
+	if len(argument) > 1 {
+		if strings.HasPrefix(argument, "'") && strings.HasSuffix(argument, "'") {
+			return &ast.Literal{Value: argument[1 : len(argument)-1]}
+		} else if strings.HasPrefix(argument, `"`) && strings.HasSuffix(argument, `"`) {
+			return &ast.Literal{Value: argument[1 : len(argument)-1]}
+		}
+	}
+	if number, ok := getNumberLiteral(argument); ok {
+		return &ast.Literal{Value: number}
+	}
+	return &ast.Literal{Value: argument}
+}
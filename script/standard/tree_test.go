@@ -0,0 +1,69 @@
+package standard
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_compiledExpression_Tree(t *testing.T) {
+
+	tests := []struct {
+		expression string
+		rfc9535    bool
+		expected   string
+	}{
+		{expression: "@.price < 10", expected: "@['price'] < 10"},
+		{expression: "@.a == 'b' && !@.c", expected: "(@['a'] == 'b') && !@['c']"},
+		{expression: "@.a || @.b == true", expected: "@['a'] || (@['b'] == true)"},
+		{expression: "(@.a + 1) * 2 >= $.b", expected: "((@['a'] + 1) * 2) >= $['b']"},
+		{expression: "@.a in [1,2]", expected: "@['a'] in [1,2]"},
+		{expression: `@.a not in ["x"]`, expected: `@['a'] not in ["x"]`},
+		{expression: "@.a =~ /^a/", expected: "@['a'] =~ /^a/"},
+		{expression: "@.a =~ 'a.*'", expected: "@['a'] =~ 'a.*'"},
+		{expression: "@.a != nil", expected: "@['a'] != null"},
+		{expression: "length(@.a) % 2 == 0", expected: "(length(@['a']) % 2) == 0"},
+		{expression: "@.length-1", expected: "@.length - 1"},
+		{expression: "'key'", expected: "'key'"},
+		{expression: "2", expected: "2"},
+		{expression: "@.a == 1 || @.b == 2 || !@.c", rfc9535: true, expected: "((@['a'] == 1) || (@['b'] == 2)) || !@['c']"},
+		{expression: "@.a && match(@.b, 'x.*')", rfc9535: true, expected: "@['a'] && match(@['b'],'x.*')"},
+		{expression: "count(@.*) > 1", rfc9535: true, expected: "count(@[*]) > 1"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			engine := &ScriptEngine{RFC9535: test.rfc9535}
+			compiled, err := engine.Compile(test.expression, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, compiled.(*compiledExpression).Tree().String())
+		})
+	}
+}
+
+func Test_getLiteralTree(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		expected ast.Expression
+	}{
+		{input: "nil", expected: &ast.Literal{Value: nil}},
+		{input: "null", expected: &ast.Literal{Value: nil}},
+		{input: "true", expected: &ast.Literal{Value: true}},
+		{input: "false", expected: &ast.Literal{Value: false}},
+		{input: "'a'", expected: &ast.Literal{Value: "a"}},
+		{input: `"a"`, expected: &ast.Literal{Value: "a"}},
+		{input: "1.5", expected: &ast.Literal{Value: 1.5}},
+		{input: "12345678901234567890", expected: &ast.Literal{Value: json.Number("12345678901234567890")}},
+		{input: "text", expected: &ast.Literal{Value: "text"}},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, getLiteralTree(test.input))
+		})
+	}
+}
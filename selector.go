@@ -1,14 +1,21 @@
 package jsonpath
 
 import (
+	"context"
 	"encoding/json"
+	goErr "errors"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 
+	"github.com/evilmonkeyinc/jsonpath/ast"
+	"github.com/evilmonkeyinc/jsonpath/errors"
 	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/ordered"
 	"github.com/evilmonkeyinc/jsonpath/script"
 	"github.com/evilmonkeyinc/jsonpath/token"
+	"gopkg.in/yaml.v3"
 )
 
 // Selector represents a compiled JSONPath selector
@@ -18,6 +25,7 @@ type Selector struct {
 	engine   script.Engine
 	tokens   []token.Token
 	selector string
+	standard Specification
 }
 
 // String returns the compiled selector string representation
@@ -29,26 +37,224 @@ func (query *Selector) String() string {
 	return jsonPath
 }
 
+// Tree returns the syntax tree of the compiled selector
+func (query *Selector) Tree() *ast.Query {
+	return token.Tree(query.tokens)
+}
+
+// IsDefinite returns true if the selector can only ever match a single value, such as $.store.book[0].title,
+// or $.store.book[?(@.price < 10)][0], selectors with wildcard or recursive tokens, or range, union, or filter
+// tokens that are not followed by an index, are not definite.
+//
+// The result of Query is the matched value for definite selectors, and a list of the matched values for
+// selectors that are not, unless the AlwaysReturnList option is enabled or the RFC9535 standard is used.
+func (query *Selector) IsDefinite() bool {
+	return token.IsDefinite(query.tokens)
+}
+
 // Query will return the result of the JSONPath query applied against the specified JSON data.
+//
+// When compiled with the RFC9535 standard, or with the AlwaysReturnList option, the result is always a list of the selected values.
 func (query *Selector) Query(root interface{}) (interface{}, error) {
+	return query.QueryContext(context.Background(), root)
+}
+
+// QueryContext will return the result of the JSONPath query applied against the specified JSON data,
+// the query is stopped and the context error returned if the context is cancelled or its deadline is exceeded.
+func (query *Selector) QueryContext(ctx context.Context, root interface{}) (interface{}, error) {
 	if len(query.tokens) == 0 {
 		return nil, getInvalidJSONPathSelector(query.selector)
 	}
 
+	if query.alwaysReturnList() {
+		nodes, err := query.QueryNodesContext(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, len(nodes))
+		for idx, node := range nodes {
+			values[idx] = node.Value
+		}
+		return values, nil
+	}
+
 	tokens := make([]token.Token, 0)
 	if len(query.tokens) > 1 {
 		tokens = query.tokens[1:]
 	}
 
-	found, err := query.tokens[0].Apply(root, root, tokens)
+	if err := query.checkMatchCount(ctx, root); err != nil {
+		return nil, err
+	}
+
+	found, err := query.tokens[0].Apply(ctx, root, root, tokens)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		// tokens may ignore the errors of the tokens that follow them so the context is checked once done
+		return nil, getQueryCancelledError(ctxErr)
+	}
 	if err != nil {
+		if query.suppressError(err) {
+			return nil, nil
+		}
 		return nil, err
 	}
 	return found, nil
 }
 
+// QueryNodes will return the nodes matched by the JSONPath query applied against the specified JSON data.
+//
+// Each node includes the matched value and the normalized path to its location in the data.
+// Unlike Query, the result is always a flat collection of nodes, and values that are nil are included.
+func (query *Selector) QueryNodes(root interface{}) ([]*token.Node, error) {
+	return query.QueryNodesContext(context.Background(), root)
+}
+
+// QueryNodesContext will return the nodes matched by the JSONPath query applied against the specified JSON data,
+// the query is stopped and the context error returned if the context is cancelled or its deadline is exceeded.
+func (query *Selector) QueryNodesContext(ctx context.Context, root interface{}) ([]*token.Node, error) {
+	if len(query.tokens) == 0 {
+		return nil, getInvalidJSONPathSelector(query.selector)
+	}
+
+	tokens := make([]token.Token, 0)
+	if len(query.tokens) > 1 {
+		tokens = query.tokens[1:]
+	}
+
+	nodes, err := query.tokens[0].ApplyNodes(ctx, root, &token.Node{Path: token.Path{}, Value: root}, tokens)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, getQueryCancelledError(ctxErr)
+	}
+	if err != nil {
+		if query.suppressError(err) {
+			return make([]*token.Node, 0), nil
+		}
+		return nil, err
+	}
+	if err := query.checkResultCount(len(nodes)); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// QueryPaths will return the normalized paths of the locations matched by the JSONPath query
+// applied against the specified JSON data, for example $['store']['book'][2].
+// An error is returned if a matched value is computed, such as the length of a collection, as it has no location.
+func (query *Selector) QueryPaths(root interface{}) ([]string, error) {
+	nodes, err := query.QueryNodes(root)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(nodes))
+	for idx, node := range nodes {
+		if node.Computed {
+			return nil, getInvalidPathComputedError(node.Path)
+		}
+		paths[idx] = node.Path.String()
+	}
+	return paths, nil
+}
+
+// Set will set the value at every location matched by the JSONPath query applied against the specified data.
+//
+// Maps, slices, and values referenced by pointers are modified in place, but the modified data is returned
+// and should be used in place of the original as arrays and structs that can not be addressed are copied.
+func (query *Selector) Set(root, value interface{}) (interface{}, error) {
+	return query.Update(root, func(interface{}) interface{} {
+		return value
+	})
+}
+
+// Update will replace the value at every location matched by the JSONPath query applied against the
+// specified data with the result of the update function, which is passed the current value.
+//
+// Locations are updated deepest first so when both a parent and its child are matched the update
+// function for the parent will be passed the already updated child.
+//
+// Maps, slices, and values referenced by pointers are modified in place, but the modified data is returned
+// and should be used in place of the original as arrays and structs that can not be addressed are copied.
+func (query *Selector) Update(root interface{}, update func(value interface{}) interface{}) (interface{}, error) {
+	nodes, err := query.QueryNodes(root)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := getModifiablePaths(nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		root, err = path.Update(root, update)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+// Delete will remove every map entry or slice element matched by the JSONPath query applied against the specified data.
+//
+// Maps, and values referenced by pointers are modified in place, but slices are reallocated when elements are
+// removed so the modified data is returned and should be used in place of the original.
+// Deleting the root of the data will return nil.
+func (query *Selector) Delete(root interface{}) (interface{}, error) {
+	nodes, err := query.QueryNodes(root)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := getModifiablePaths(nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		root, err = path.Delete(root)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
 // QueryString will return the result of the JSONPath query applied against the specified JSON data.
 func (query *Selector) QueryString(jsonData string) (interface{}, error) {
+	root, err := query.parseJSONData(jsonData)
+	if err != nil {
+		return nil, err
+	}
+	return query.Query(root)
+}
+
+// QueryYAML will return the result of the JSONPath query applied against the specified YAML data.
+//
+// Only the first document of the YAML data is queried. Mappings are decoded as map[string]interface{}, or as
+// map[interface{}]interface{} if they have keys that are not strings, which are selected by the string of their key.
+func (query *Selector) QueryYAML(yamlData string) (interface{}, error) {
+	root, err := parseYAMLData(yamlData)
+	if err != nil {
+		return nil, err
+	}
+	return query.Query(root)
+}
+
+// parseYAMLData decodes the first document of the YAML data
+func parseYAMLData(yamlData string) (interface{}, error) {
+	var root interface{}
+	if err := yaml.Unmarshal([]byte(yamlData), &root); err != nil {
+		return nil, getInvalidJSONData(err)
+	}
+	if root == nil {
+		return nil, getInvalidJSONData(errDataIsUnexpectedTypeOrNil)
+	}
+	return root, nil
+}
+
+// parseJSONData decodes the JSON data, numbers are decoded as json.Number when the UseNumber option is enabled
+// and objects are decoded as ordered objects when the PreserveKeyOrder option is enabled
+func (query *Selector) parseJSONData(jsonData string) (interface{}, error) {
 	jsonData = strings.TrimSpace(jsonData)
 	if jsonData == "" {
 		return nil, getInvalidJSONData(errDataIsUnexpectedTypeOrNil)
@@ -59,13 +265,13 @@ func (query *Selector) QueryString(jsonData string) (interface{}, error) {
 	if strings.HasPrefix(jsonData, "{") && strings.HasSuffix(jsonData, "}") {
 		// object
 		root = make(map[string]interface{})
-		if err := json.Unmarshal([]byte(jsonData), &root); err != nil {
+		if err := query.unmarshal(jsonData, &root); err != nil {
 			return nil, getInvalidJSONData(err)
 		}
 	} else if strings.HasPrefix(jsonData, "[") && strings.HasSuffix(jsonData, "]") {
 		// array
 		root = make([]interface{}, 0)
-		if err := json.Unmarshal([]byte(jsonData), &root); err != nil {
+		if err := query.unmarshal(jsonData, &root); err != nil {
 			return nil, getInvalidJSONData(err)
 		}
 	} else if len(jsonData) > 2 && strings.HasPrefix(jsonData, "\"") && strings.HasPrefix(jsonData, "\"") {
@@ -77,6 +283,12 @@ func (query *Selector) QueryString(jsonData string) (interface{}, error) {
 	} else if strings.ToLower(jsonData) == "false" {
 		// bool false
 		root = false
+	} else if query.useNumber() {
+		// number
+		if _, err := strconv.ParseFloat(jsonData, 64); err != nil {
+			return nil, getInvalidJSONData(errDataIsUnexpectedTypeOrNil)
+		}
+		root = json.Number(jsonData)
 	} else if val, err := strconv.ParseInt(jsonData, 10, 64); err == nil {
 		// integer
 		root = val
@@ -86,6 +298,146 @@ func (query *Selector) QueryString(jsonData string) (interface{}, error) {
 	} else {
 		return nil, getInvalidJSONData(errDataIsUnexpectedTypeOrNil)
 	}
+	return root, nil
+}
+
+// alwaysReturnList returns true if the result of Query is always a list of the selected values
+func (query *Selector) alwaysReturnList() bool {
+	return query.standard == RFC9535 || (query.Options != nil && query.Options.AlwaysReturnList)
+}
+
+// checkResultCount returns an error if the number of results exceeds the MaxResults limit
+func (query *Selector) checkResultCount(count int) error {
+	if query.Options == nil {
+		return nil
+	}
+	if maximum := query.Options.Limits.MaxResults; maximum > 0 && count > maximum {
+		return getLimitExceededError("result count", maximum)
+	}
+	return nil
+}
+
+// checkMatchCount returns an error if a selector that can match more than one value matches more values than
+// the MaxResults limit, the matched nodes are counted as the list returned by Query can contain the lists
+// selected by each of the tokens rather than the matched values.
+func (query *Selector) checkMatchCount(ctx context.Context, root interface{}) error {
+	if query.Options == nil || query.Options.Limits.MaxResults <= 0 || query.IsDefinite() {
+		return nil
+	}
+	if _, err := query.QueryNodesContext(ctx, root); goErr.Is(err, errors.ErrLimitExceeded) {
+		return err
+	}
+	return nil
+}
+
+// suppressError returns true if the error of the query is suppressed by the SuppressExceptions option,
+// errors caused by exceeding a limit are not suppressed
+func (query *Selector) suppressError(err error) bool {
+	if query.Options == nil || !query.Options.SuppressExceptions {
+		return false
+	}
+	return !goErr.Is(err, errors.ErrLimitExceeded)
+}
+
+func (query *Selector) useNumber() bool {
+	return query.Options != nil && query.Options.UseNumber
+}
+
+func (query *Selector) preserveKeyOrder() bool {
+	return query.Options != nil && query.Options.PreserveKeyOrder
+}
+
+// unmarshal decodes the JSON data, numbers are decoded as json.Number when the UseNumber option is enabled
+// and objects are decoded as ordered objects when the PreserveKeyOrder option is enabled
+func (query *Selector) unmarshal(jsonData string, value *interface{}) error {
+	if (!query.useNumber() && !query.preserveKeyOrder()) || !json.Valid([]byte(jsonData)) {
+		// invalid data reports the same error as json.Unmarshal
+		return json.Unmarshal([]byte(jsonData), value)
+	}
+	decoder := json.NewDecoder(strings.NewReader(jsonData))
+	if query.useNumber() {
+		decoder.UseNumber()
+	}
+	if !query.preserveKeyOrder() {
+		return decoder.Decode(value)
+	}
+
+	decoded, err := ordered.Decode(decoder)
+	if err != nil {
+		return err
+	}
+	*value = decoded
+	return nil
+}
+
+// QueryReader will return the result of the JSONPath query applied against the JSON data read from the reader.
+//
+// The JSON data is read as a stream and only the parts of the data that the selector can match are kept in memory.
+// Key, index, wildcard, range, and union tokens are applied while reading, any other token, such as a filter,
+// requires the complete value it is applied to be read, and filters or scripts that reference the root
+// require the complete data to be read. The data is read to the end, and like QueryString and QueryBytes,
+// data that follows the JSON value is invalid.
+func (query *Selector) QueryReader(reader io.Reader) (interface{}, error) {
+	if len(query.tokens) == 0 {
+		return nil, getInvalidJSONPathSelector(query.selector)
+	}
 
+	decoder := json.NewDecoder(reader)
+	root, err := token.Decode(decoder, query.tokens, query.Options)
+	if err != nil {
+		return nil, getInvalidJSONData(err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		if err == nil {
+			err = errDataHasTrailingValue
+		}
+		return nil, getInvalidJSONData(err)
+	}
 	return query.Query(root)
 }
+
+// QueryBytes will return the raw JSON of each value matched by the JSONPath query applied against the JSON data.
+//
+// Like QueryNodes, the result is always a flat collection of the matched values. Only the parts of the data that
+// the selector can match are decoded, the matched values are returned as they appear in the data without being
+// decoded, and reference the data. Key, index, wildcard, range, and union tokens are applied without decoding
+// values, any other token, such as a filter, requires the value it is applied to be decoded, and filters or scripts
+// that reference the root require all of the data to be decoded.
+func (query *Selector) QueryBytes(jsonData []byte) ([]json.RawMessage, error) {
+	if len(query.tokens) == 0 {
+		return nil, getInvalidJSONPathSelector(query.selector)
+	}
+
+	root, err := token.DecodeBytes(jsonData, query.tokens, query.Options)
+	if err != nil {
+		return nil, getInvalidJSONData(err)
+	}
+
+	nodes, err := query.QueryNodes(root)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]json.RawMessage, len(nodes))
+	decoded := make([]int, 0)
+	paths := make([]token.Path, 0)
+	for idx, node := range nodes {
+		if raw, ok := node.Value.(json.RawMessage); ok {
+			values[idx] = raw
+		} else {
+			decoded = append(decoded, idx)
+			paths = append(paths, node.Path)
+		}
+	}
+
+	// the raw values of decoded nodes are read from the data in a single pass
+	raws := token.RawValues(jsonData, paths)
+	for pos, idx := range decoded {
+		if raws[pos] != nil {
+			values[idx] = raws[pos]
+		} else if values[idx], err = json.Marshal(nodes[idx].Value); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
@@ -1,9 +1,14 @@
 package jsonpath
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/evilmonkeyinc/jsonpath/option"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -382,3 +387,1439 @@ func Test_Selector_Query(t *testing.T) {
 		})
 	}
 }
+
+func Test_Selector_IsDefinite(t *testing.T) {
+	tests := []struct {
+		selector string
+		options  []Option
+		expected bool
+	}{
+		{selector: "$", expected: true},
+		{selector: "$.store.book[0].title", expected: true},
+		{selector: "$.store.book.length", expected: true},
+		{selector: "$.store.book[(@.length-1)]", expected: true},
+		{selector: "$.store.book[*].title", expected: false},
+		{selector: "$.store.book[0:1]", expected: false},
+		{selector: "$.store.book[0,1]", expected: false},
+		{selector: "$.store.book[?(@.price < 10)][0]", expected: true},
+		{selector: "$.store.book[0:2][1]", expected: true},
+		{selector: "$.store.book[0,1][0].title", expected: true},
+		{selector: "$..title", expected: false},
+		{selector: "$.store.book[0].title", options: []Option{Standard(RFC9535)}, expected: true},
+		{selector: "$.store.book[0,1]", options: []Option{Standard(RFC9535)}, expected: false},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector, test.options...)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, selector.IsDefinite())
+		})
+	}
+}
+
+func Test_Selector_Query_AlwaysReturnList(t *testing.T) {
+	tests := []struct {
+		selector string
+		expected interface{}
+		err      string
+	}{
+		{
+			selector: "$.store.book[0].title",
+			expected: []interface{}{"Sayings of the Century"},
+		},
+		{
+			selector: "$.store.book[?(@.price < 10)][0].title",
+			expected: []interface{}{"Sayings of the Century"},
+		},
+		{
+			selector: "$.store.book[?(@.price < 10)].title",
+			expected: []interface{}{"Sayings of the Century", "Moby Dick"},
+		},
+		{
+			selector: "$.store.book[?(@.price > 100)].title",
+			expected: []interface{}{},
+		},
+		{
+			selector: "$.store.book.length",
+			expected: []interface{}{int64(4)},
+		},
+		{
+			selector: "$.store.missing",
+			err:      "key: invalid token key 'missing' not found",
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector, QueryOptions(&option.QueryOptions{AlwaysReturnList: true}))
+			assert.Nil(t, err)
+
+			actual, err := selector.Query(sampleDataObject)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.Nil(t, actual)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_Selector_Query_MissingValues(t *testing.T) {
+	var data interface{}
+	json.Unmarshal([]byte(`{
+		"a": {"b": {"x": 1}},
+		"items": [{"price": 1}, {"name": "x"}, {"price": null}, {"price": 3}],
+		"list": [1, 2]
+	}`), &data)
+
+	leafToNull := QueryOptions(&option.QueryOptions{DefaultPathLeafToNull: true})
+	requireProperties := QueryOptions(&option.QueryOptions{RequireProperties: true})
+	suppressExceptions := QueryOptions(&option.QueryOptions{SuppressExceptions: true})
+
+	tests := []struct {
+		selector string
+		option   Option
+		expected interface{}
+		err      string
+	}{
+		{
+			selector: "$.a.b.c",
+			option:   leafToNull,
+			expected: nil,
+		},
+		{
+			selector: "$.a.x.c",
+			option:   leafToNull,
+			err:      "key: invalid token key 'x' not found",
+		},
+		{
+			selector: "$.items[*].price",
+			option:   leafToNull,
+			expected: []interface{}{float64(1), nil, nil, float64(3)},
+		},
+		{
+			selector: "$.a.b['x','y']",
+			option:   leafToNull,
+			expected: []interface{}{float64(1), nil},
+		},
+		{
+			selector: "$.list[5]",
+			option:   leafToNull,
+			expected: nil,
+		},
+		{
+			selector: "$..price",
+			option:   leafToNull,
+			expected: []interface{}{float64(1), float64(3)},
+		},
+		{
+			selector: "$.items[*].price",
+			option:   requireProperties,
+			err:      "key: invalid token key 'price' not found",
+		},
+		{
+			selector: "$.a.b['x','y']",
+			option:   requireProperties,
+			err:      "union: invalid token key 'y' not found",
+		},
+		{
+			selector: "$.items[?(@.price > 1)].price",
+			option:   requireProperties,
+			expected: []interface{}{float64(3)},
+		},
+		{
+			selector: "$.a.x.c",
+			option:   suppressExceptions,
+			expected: nil,
+		},
+		{
+			selector: "$.list.key",
+			option:   suppressExceptions,
+			expected: nil,
+		},
+		{
+			selector: "$.items[*].price",
+			option:   suppressExceptions,
+			expected: []interface{}{float64(1), float64(3)},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector, test.option)
+			assert.Nil(t, err)
+
+			actual, err := selector.Query(data)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.Nil(t, actual)
+				return
+			}
+			assert.Nil(t, err)
+			if values, ok := test.expected.([]interface{}); ok {
+				assert.ElementsMatch(t, values, actual)
+				return
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("nodes", func(t *testing.T) {
+		selector, _ := Compile("$.a.b.c", leafToNull)
+		nodes, err := selector.QueryNodes(data)
+		assert.Nil(t, err)
+		assert.Len(t, nodes, 1)
+		assert.Equal(t, "$['a']['b']['c']", nodes[0].Path.String())
+		assert.Nil(t, nodes[0].Value)
+		assert.True(t, nodes[0].Missing)
+
+		selector, _ = Compile("$.a.x.c", suppressExceptions)
+		nodes, err = selector.QueryNodes(data)
+		assert.Nil(t, err)
+		assert.Empty(t, nodes)
+	})
+	t.Run("modify", func(t *testing.T) {
+		getData := func() interface{} {
+			var data interface{}
+			json.Unmarshal([]byte(`{"items": [{"price": 1}, {"name": "x"}], "list": [1]}`), &data)
+			return data
+		}
+
+		selector, _ := Compile("$.items[*].price", leafToNull)
+		actual, err := selector.Set(getData(), float64(5))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"price": float64(5)},
+				map[string]interface{}{"name": "x"},
+			},
+			"list": []interface{}{float64(1)},
+		}, actual)
+
+		selector, _ = Compile("$.list[0,3]", leafToNull)
+		actual, err = selector.Delete(getData())
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{}, actual.(map[string]interface{})["list"])
+
+		selector, _ = Compile("$.items[1]['name','price']", leafToNull)
+		actual, err = selector.Update(getData(), func(value interface{}) interface{} {
+			return fmt.Sprintf("%v!", value)
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "x!"}, actual.(map[string]interface{})["items"].([]interface{})[1])
+	})
+	t.Run("limits", func(t *testing.T) {
+		selector, _ := Compile("$.items[*]", QueryOptions(&option.QueryOptions{
+			SuppressExceptions: true,
+			Limits:             option.Limits{MaxResults: 2},
+		}))
+		_, err := selector.QueryNodes(data)
+		assert.EqualError(t, err, "limit exceeded. result count exceeds maximum of 2")
+
+		actual, err := selector.Query(data)
+		assert.EqualError(t, err, "limit exceeded. result count exceeds maximum of 2")
+		assert.Nil(t, actual)
+
+		selector, _ = Compile("$[*]", QueryOptions(&option.QueryOptions{Limits: option.Limits{MaxResults: 2}}))
+		actual, err = selector.Query([]interface{}{1, 2, 3, 4, 5})
+		assert.EqualError(t, err, "limit exceeded. result count exceeds maximum of 2")
+		assert.Nil(t, actual)
+
+		actual, err = selector.Query([]interface{}{1, 2})
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{1, 2}, actual)
+
+		nested := map[string]interface{}{"arr": []interface{}{[]interface{}{1, 2, 3, 4, 5}, []interface{}{6}}}
+		selector, _ = Compile("$.arr[?(@)][0]", QueryOptions(&option.QueryOptions{Limits: option.Limits{MaxResults: 3}}))
+		actual, err = selector.Query(nested)
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, actual)
+
+		selector, _ = Compile("$.arr[0:1]", QueryOptions(&option.QueryOptions{Limits: option.Limits{MaxResults: 1}}))
+		actual, err = selector.Query(nested)
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{[]interface{}{1, 2, 3, 4, 5}}, actual)
+
+		selector, _ = Compile("$.arr[*][*]", QueryOptions(&option.QueryOptions{Limits: option.Limits{MaxResults: 3}}))
+		actual, err = selector.Query(nested)
+		assert.EqualError(t, err, "limit exceeded. result count exceeds maximum of 3")
+		assert.Nil(t, actual)
+	})
+}
+
+func Test_Selector_Query_KeyMatching(t *testing.T) {
+	var data interface{}
+	json.Unmarshal([]byte(`{
+		"users": [{"userId": 1}, {"UserID": 2}, {"USERID": 3, "userid": 4}],
+		"caf\u00e9": "composed",
+		"cafe\u0301s": "decomposed"
+	}`), &data)
+
+	caseInsensitive := &option.QueryOptions{CaseInsensitiveKeys: true}
+	normalize := &option.QueryOptions{NormalizeKeys: true}
+
+	tests := []struct {
+		selector string
+		options  *option.QueryOptions
+		expected interface{}
+		err      string
+	}{
+		{
+			selector: "$.users[*].userId",
+			expected: []interface{}{float64(1)},
+		},
+		{
+			selector: "$.users[*].userId",
+			options:  caseInsensitive,
+			expected: []interface{}{float64(1), float64(2), float64(3)},
+		},
+		{
+			selector: "$.users[2].userid",
+			options:  caseInsensitive,
+			expected: float64(4),
+		},
+		{
+			selector: "$.users[2]['UserId','x']",
+			options:  caseInsensitive,
+			expected: []interface{}{float64(3)},
+		},
+		{
+			selector: "$['cafe\u0301']",
+			err:      "key: invalid token key 'cafe\u0301' not found",
+		},
+		{
+			selector: "$['cafe\u0301']",
+			options:  normalize,
+			expected: "composed",
+		},
+		{
+			selector: "$['caf\u00e9s']",
+			options:  normalize,
+			expected: "decomposed",
+		},
+		{
+			selector: "$['CAF\u00c9']",
+			options:  &option.QueryOptions{CaseInsensitiveKeys: true, NormalizeKeys: true},
+			expected: "composed",
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector, QueryOptions(test.options))
+			assert.Nil(t, err)
+
+			actual, err := selector.Query(data)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("paths", func(t *testing.T) {
+		selector, _ := Compile("$.users[*].USERID", QueryOptions(caseInsensitive))
+		paths, err := selector.QueryPaths(data)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"$['users'][0]['userId']", "$['users'][1]['UserID']", "$['users'][2]['USERID']"}, paths)
+	})
+}
+
+func Test_Selector_QueryString_PreserveKeyOrder(t *testing.T) {
+	data := `{"z": 1, "a": {"y": 2, "b": 3}, "m": [{"k": "v", "c": 1}, {"c": 2}]}`
+
+	preserve := &option.QueryOptions{PreserveKeyOrder: true}
+
+	tests := []struct {
+		selector string
+		options  []Option
+		expected string
+		err      string
+	}{
+		{
+			selector: "$.*",
+			expected: `[{"b":3,"y":2},[{"c":1,"k":"v"},{"c":2}],1]`,
+		},
+		{
+			selector: "$.*",
+			options:  []Option{QueryOptions(preserve)},
+			expected: `[1,{"y":2,"b":3},[{"k":"v","c":1},{"c":2}]]`,
+		},
+		{
+			selector: "$..*",
+			options:  []Option{QueryOptions(preserve)},
+			expected: `[1,{"y":2,"b":3},[{"k":"v","c":1},{"c":2}],2,3,{"k":"v","c":1},{"c":2},"v",1,2]`,
+		},
+		{
+			selector: "$",
+			options:  []Option{QueryOptions(preserve)},
+			expected: `{"z":1,"a":{"y":2,"b":3},"m":[{"k":"v","c":1},{"c":2}]}`,
+		},
+		{
+			selector: "$.a[?(@ > 1)]",
+			options:  []Option{QueryOptions(preserve)},
+			expected: `[2,3]`,
+		},
+		{
+			selector: "$.m[?(@.c > 1)].c",
+			options:  []Option{QueryOptions(preserve)},
+			expected: `[2]`,
+		},
+		{
+			selector: "$.a[-1:]",
+			options:  []Option{QueryOptions(&option.QueryOptions{PreserveKeyOrder: true, AllowMapReferenceByIndex: true})},
+			expected: `[3]`,
+		},
+		{
+			selector: "$[?length(@) == 2]",
+			options:  []Option{QueryOptions(preserve), Standard(RFC9535)},
+			expected: `[{"y":2,"b":3},[{"k":"v","c":1},{"c":2}]]`,
+		},
+		{
+			selector: "$.a.length",
+			options:  []Option{QueryOptions(preserve)},
+			expected: `2`,
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector, test.options...)
+			assert.Nil(t, err)
+
+			actual, err := selector.QueryString(data)
+			assert.Nil(t, err)
+			encoded, _ := json.Marshal(actual)
+			assert.Equal(t, test.expected, string(encoded))
+
+			actual, err = selector.QueryReader(strings.NewReader(data))
+			assert.Nil(t, err)
+			encoded, _ = json.Marshal(actual)
+			assert.Equal(t, test.expected, string(encoded))
+
+			if !selector.preserveKeyOrder() {
+				// raw values are always in document order
+				return
+			}
+			raw, err := selector.QueryBytes([]byte(data))
+			assert.Nil(t, err)
+			if selector.IsDefinite() && selector.standard != RFC9535 {
+				encoded, _ = json.Marshal(raw[0])
+			} else {
+				encoded, _ = json.Marshal(raw)
+			}
+			assert.Equal(t, test.expected, string(encoded))
+		})
+	}
+
+	t.Run("paths", func(t *testing.T) {
+		selector, _ := Compile("$.*", QueryOptions(preserve))
+		root, err := selector.parseJSONData(data)
+		assert.Nil(t, err)
+		paths, err := selector.QueryPaths(root)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"$['z']", "$['a']", "$['m']"}, paths)
+	})
+	t.Run("use number", func(t *testing.T) {
+		selector, _ := Compile("$.*", QueryOptions(&option.QueryOptions{PreserveKeyOrder: true, UseNumber: true}))
+		actual, err := selector.QueryString(`{"b": 9007199254740993, "a": 1.5}`)
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{json.Number("9007199254740993"), json.Number("1.5")}, actual)
+	})
+	t.Run("invalid data", func(t *testing.T) {
+		selector, _ := Compile("$.*", QueryOptions(preserve))
+		_, err := selector.QueryString(`{"b": 1,}`)
+		assert.EqualError(t, err, "invalid data. invalid character '}' looking for beginning of object key string")
+	})
+	t.Run("update", func(t *testing.T) {
+		selector, _ := Compile("$.a.y", QueryOptions(preserve))
+		root, _ := selector.parseJSONData(data)
+		updated, err := selector.Set(root, "new")
+		assert.Nil(t, err)
+		encoded, _ := json.Marshal(updated)
+		assert.Equal(t, `{"z":1,"a":{"y":"new","b":3},"m":[{"k":"v","c":1},{"c":2}]}`, string(encoded))
+
+		deleted, err := selector.Delete(updated)
+		assert.Nil(t, err)
+		encoded, _ = json.Marshal(deleted)
+		assert.Equal(t, `{"z":1,"a":{"b":3},"m":[{"k":"v","c":1},{"c":2}]}`, string(encoded))
+	})
+}
+
+func Test_Selector_QueryYAML(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  creationTimestamp: 2024-01-02T15:04:05Z
+  labels:
+    tier: frontend
+    app: nginx
+spec:
+  containers:
+    - name: web
+      image: nginx:1.25
+      ports:
+        - containerPort: 80
+    - name: sidecar
+      image: envoy:1.28
+      ports:
+        - containerPort: 9901
+  nodeSelector:
+    1: one
+    zone: a
+---
+kind: Service
+`
+
+	tests := []struct {
+		selector string
+		expected interface{}
+		err      string
+	}{
+		{
+			selector: "$.kind",
+			expected: "Pod",
+		},
+		{
+			selector: "$.spec.containers[*].image",
+			expected: []interface{}{"nginx:1.25", "envoy:1.28"},
+		},
+		{
+			selector: "$.spec.containers[?(@.name == 'sidecar')].image",
+			expected: []interface{}{"envoy:1.28"},
+		},
+		{
+			selector: "$..containerPort",
+			expected: []interface{}{80, 9901},
+		},
+		{
+			selector: "$.metadata.labels.*",
+			expected: []interface{}{"nginx", "frontend"},
+		},
+		{
+			selector: "$.spec.nodeSelector.*",
+			expected: []interface{}{"one", "a"},
+		},
+		{
+			selector: "$.spec.nodeSelector['1','zone']",
+			expected: []interface{}{"one", "a"},
+		},
+		{
+			selector: "$.spec.nodeSelector.length",
+			expected: int64(2),
+		},
+		{
+			selector: "$.spec.missing",
+			err:      "key: invalid token key 'missing' not found",
+		},
+		{
+			selector: "$..image",
+			expected: []interface{}{"nginx:1.25", "envoy:1.28"},
+		},
+		{
+			selector: "$..creationTimestamp",
+			expected: []interface{}{time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		},
+		{
+			selector: "$.metadata..*",
+			expected: []interface{}{
+				time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+				map[string]interface{}{"app": "nginx", "tier": "frontend"},
+				"web",
+				"nginx",
+				"frontend",
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector)
+			assert.Nil(t, err)
+
+			actual, err := selector.QueryYAML(manifest)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("paths", func(t *testing.T) {
+		root, err := parseYAMLData(manifest)
+		assert.Nil(t, err)
+
+		selector, _ := Compile("$.spec.nodeSelector.*")
+		paths, err := selector.QueryPaths(root)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"$['spec']['nodeSelector']['1']", "$['spec']['nodeSelector']['zone']"}, paths)
+	})
+	t.Run("update", func(t *testing.T) {
+		root, _ := parseYAMLData(manifest)
+
+		selector, _ := Compile("$.spec.nodeSelector['1']")
+		updated, err := selector.Set(root, "two")
+		assert.Nil(t, err)
+
+		actual, _ := Query("$.spec.nodeSelector.*", updated)
+		assert.Equal(t, []interface{}{"two", "a"}, actual)
+	})
+	t.Run("rfc9535", func(t *testing.T) {
+		selector, _ := Compile("$.spec.nodeSelector[?@ == 'a']", Standard(RFC9535))
+		actual, err := selector.QueryYAML(manifest)
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"a"}, actual)
+	})
+}
+
+type mapKeyCode int
+
+func (code mapKeyCode) String() string {
+	return fmt.Sprintf("code-%d", int(code))
+}
+
+type mapKeyName string
+
+func Test_Selector_Query_MapKeys(t *testing.T) {
+	data := map[string]interface{}{
+		"byID": map[int]map[string]interface{}{
+			10: {"name": "ten", "price": 10},
+			9:  {"name": "nine", "price": 9},
+			2:  {"name": "two", "price": 2},
+		},
+		"byCode": map[mapKeyCode]string{404: "Not Found", 200: "OK"},
+		"byName": map[mapKeyName]int{"b": 2, "a": 1},
+		"byTime": map[time.Time]string{
+			time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC): "second",
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC): "first",
+		},
+	}
+
+	tests := []struct {
+		selector string
+		expected interface{}
+		err      string
+	}{
+		{
+			selector: "$.byID['10'].name",
+			expected: "ten",
+		},
+		{
+			selector: "$.byID.*.name",
+			expected: []interface{}{"two", "nine", "ten"},
+		},
+		{
+			selector: "$.byID['9','2'].name",
+			expected: []interface{}{"nine", "two"},
+		},
+		{
+			selector: "$.byID[?(@.price > 5)].name",
+			expected: []interface{}{"nine", "ten"},
+		},
+		{
+			selector: "$..name",
+			expected: []interface{}{"two", "nine", "ten"},
+		},
+		{
+			selector: "$.byCode['404']",
+			expected: "Not Found",
+		},
+		{
+			selector: "$.byCode.*",
+			expected: []interface{}{"OK", "Not Found"},
+		},
+		{
+			selector: "$.byName.*",
+			expected: []interface{}{1, 2},
+		},
+		{
+			selector: "$.byTime['2024-01-01T00:00:00Z']",
+			expected: "first",
+		},
+		{
+			selector: "$.byTime.*",
+			expected: []interface{}{"first", "second"},
+		},
+		{
+			selector: "$.byID['11']",
+			err:      "key: invalid token key '11' not found",
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector)
+			assert.Nil(t, err)
+
+			actual, err := selector.Query(data)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("paths", func(t *testing.T) {
+		selector, _ := Compile("$.byID.*")
+		paths, err := selector.QueryPaths(data)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"$['byID']['2']", "$['byID']['9']", "$['byID']['10']"}, paths)
+	})
+	t.Run("set", func(t *testing.T) {
+		selector, _ := Compile("$.byCode['200']")
+		_, err := selector.Set(data, "Success")
+		assert.Nil(t, err)
+		assert.Equal(t, "Success", data["byCode"].(map[mapKeyCode]string)[200])
+	})
+}
+
+func Test_Selector_QueryNodes(t *testing.T) {
+
+	type input struct {
+		selector string
+		jsonData interface{}
+	}
+
+	type expected struct {
+		paths  []string
+		values []interface{}
+		err    string
+	}
+
+	tests := []struct {
+		input    input
+		expected expected
+	}{
+		{
+			input: input{
+				selector: "$.expensive",
+				jsonData: &storeData{},
+			},
+			expected: expected{
+				err: "key: invalid token key 'expensive' not found",
+			},
+		},
+		{
+			input: input{
+				selector: "$",
+				jsonData: "root",
+			},
+			expected: expected{
+				paths:  []string{"$"},
+				values: []interface{}{"root"},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[2].title",
+				jsonData: sampleDataObject,
+			},
+			expected: expected{
+				paths:  []string{"$['store']['book'][2]['title']"},
+				values: []interface{}{"Moby Dick"},
+			},
+		},
+		{
+			input: input{
+				selector: "$..book[?(@.price<10)]",
+				jsonData: sampleDataObject,
+			},
+			expected: expected{
+				paths: []string{
+					"$['store']['book'][0]",
+					"$['store']['book'][2]",
+				},
+				values: []interface{}{
+					sampleDataObject.Store.Book[0],
+					sampleDataObject.Store.Book[2],
+				},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[-1:].author",
+				jsonData: sampleDataObject,
+			},
+			expected: expected{
+				paths:  []string{"$['store']['book'][3]['author']"},
+				values: []interface{}{"J. R. R. Tolkien"},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[0,1]['title','price']",
+				jsonData: sampleDataObject,
+			},
+			expected: expected{
+				paths: []string{
+					"$['store']['book'][0]['title']",
+					"$['store']['book'][0]['price']",
+					"$['store']['book'][1]['title']",
+					"$['store']['book'][1]['price']",
+				},
+				values: []interface{}{
+					"Sayings of the Century",
+					8.95,
+					"Sword of Honour",
+					12.99,
+				},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[(@.length-1)].isbn",
+				jsonData: sampleDataObject,
+			},
+			expected: expected{
+				paths:  []string{"$['store']['book'][3]['isbn']"},
+				values: []interface{}{"0-395-19395-8"},
+			},
+		},
+		{
+			input: input{
+				selector: "$[*]['a','b']",
+				jsonData: []interface{}{
+					map[string]interface{}{"a": "x", "b": nil},
+					map[string]interface{}{"a'b": "y"},
+				},
+			},
+			expected: expected{
+				paths:  []string{"$[0]['a']", "$[0]['b']"},
+				values: []interface{}{"x", nil},
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.input.selector)
+			assert.Nil(t, err)
+
+			nodes, err := selector.QueryNodes(test.input.jsonData)
+
+			if test.expected.err != "" {
+				assert.EqualError(t, err, test.expected.err)
+				assert.Nil(t, nodes)
+				return
+			}
+			assert.Nil(t, err)
+
+			paths := make([]string, len(nodes))
+			values := make([]interface{}, len(nodes))
+			for i, node := range nodes {
+				paths[i] = node.Path.String()
+				values[i] = node.Value
+			}
+			assert.Equal(t, test.expected.paths, paths)
+			assert.Equal(t, test.expected.values, values)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		selector := &Selector{selector: "invalid"}
+		nodes, err := selector.QueryNodes(nil)
+		assert.EqualError(t, err, "invalid JSONPath selector 'invalid'")
+		assert.Nil(t, nodes)
+	})
+}
+
+func Test_Selector_QueryPaths(t *testing.T) {
+
+	t.Run("paths", func(t *testing.T) {
+		selector, _ := Compile("$..author")
+		paths, err := selector.QueryPaths(sampleDataObject)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"$['store']['book'][0]['author']",
+			"$['store']['book'][1]['author']",
+			"$['store']['book'][2]['author']",
+			"$['store']['book'][3]['author']",
+		}, paths)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type account struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Email    string `json:"email"`
+			session  string
+		}
+		root := &struct {
+			Accounts []*account `json:"accounts"`
+		}{
+			Accounts: []*account{
+				{Username: "one", Password: "secret", Email: "one@example.com", session: "abc"},
+			},
+		}
+
+		selector, _ := Compile("$..*")
+		paths, err := selector.QueryPaths(root)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"$['accounts']",
+			"$['accounts'][0]",
+			"$['accounts'][0]['username']",
+			"$['accounts'][0]['password']",
+			"$['accounts'][0]['email']",
+		}, paths)
+
+		selector, _ = Compile("$..password")
+		actual, err := selector.Set(root, "redacted")
+		assert.Nil(t, err)
+		assert.Equal(t, root, actual)
+		assert.Equal(t, "redacted", root.Accounts[0].Password)
+		assert.Equal(t, "abc", root.Accounts[0].session)
+	})
+
+	t.Run("length", func(t *testing.T) {
+		selector, _ := Compile("$.a.length")
+		paths, err := selector.QueryPaths(map[string]interface{}{"a": []interface{}{1, 2}})
+		assert.EqualError(t, err, "invalid path $['a']['length']. value is computed and is not a location in the data")
+		assert.Nil(t, paths)
+
+		actual, err := selector.Set(map[string]interface{}{"a": []interface{}{1, 2}}, 3)
+		assert.EqualError(t, err, "invalid path $['a']['length']. value is computed and is not a location in the data")
+		assert.Nil(t, actual)
+
+		actual, err = selector.Delete(map[string]interface{}{"a": []interface{}{1, 2}})
+		assert.EqualError(t, err, "invalid path $['a']['length']. value is computed and is not a location in the data")
+		assert.Nil(t, actual)
+
+		paths, err = selector.QueryPaths(map[string]interface{}{"a": map[string]interface{}{"length": 1}})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"$['a']['length']"}, paths)
+
+		actual, err = selector.Set(map[string]interface{}{"a": map[string]interface{}{"length": 1}}, 3)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"a": map[string]interface{}{"length": 3}}, actual)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		selector, _ := Compile("$.missing")
+		paths, err := selector.QueryPaths(map[string]interface{}{})
+		assert.EqualError(t, err, "key: invalid token key 'missing' not found")
+		assert.Nil(t, paths)
+	})
+}
+
+func Test_Selector_QueryContext(t *testing.T) {
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithTimeout(context.Background(), -time.Second)
+	defer cancelExpired()
+
+	tests := []struct {
+		selector string
+		options  []Option
+		ctx      context.Context
+		expected interface{}
+		err      error
+	}{
+		{selector: "$.store.book[0].author", ctx: context.Background(), expected: "Nigel Rees"},
+		{selector: "$..author", ctx: cancelled, err: context.Canceled},
+		{selector: "$.store.book[*].author", ctx: cancelled, err: context.Canceled},
+		{selector: "$..book[?(@.price < 10)].title", ctx: expired, err: context.DeadlineExceeded},
+		{selector: "$.store.book[0:2].title", ctx: expired, err: context.DeadlineExceeded},
+		{selector: "$..[?@.price < 10].title", options: []Option{Standard(RFC9535)}, ctx: cancelled, err: context.Canceled},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector, test.options...)
+			assert.Nil(t, err)
+
+			actual, err := selector.QueryContext(test.ctx, sampleDataObject)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				assert.EqualError(t, err, "query cancelled. "+test.err.Error())
+				assert.Nil(t, actual)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("deadline", func(t *testing.T) {
+		data := make(map[string]interface{})
+		for i := 0; i < 200000; i++ {
+			data[fmt.Sprintf("key%d", i)] = i
+		}
+
+		selector, _ := Compile("$..[?(@ == 1)]")
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		actual, err := selector.QueryContext(ctx, data)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Nil(t, actual)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("not compiled", func(t *testing.T) {
+		selector := &Selector{selector: "$"}
+		actual, err := selector.QueryContext(context.Background(), sampleDataObject)
+		assert.EqualError(t, err, "invalid JSONPath selector '$'")
+		assert.Nil(t, actual)
+	})
+}
+
+func Test_Selector_QueryReader(t *testing.T) {
+
+	tests := []struct {
+		selector string
+		data     string
+		expected interface{}
+		err      string
+	}{
+		{selector: "$.store.book[-1].title", data: sampleDataString, expected: "The Lord of the Rings"},
+		{selector: "$.store.book[0,1].author", data: sampleDataString, expected: []interface{}{"Nigel Rees", "Evelyn Waugh"}},
+		{selector: "$..book[?(@.price < $.expensive)].title", data: sampleDataString, expected: []interface{}{"Sayings of the Century", "Moby Dick"}},
+		{selector: "$.store.missing", data: sampleDataString, err: "key: invalid token key 'missing' not found"},
+		{selector: "$.store", data: `{"store":`, err: "invalid data. unexpected EOF"},
+		{selector: "$.store", data: ``, err: "invalid data. EOF"},
+		{selector: "$.a", data: `{"a":1} garbage`, err: "invalid data. invalid character 'g' looking for beginning of value"},
+		{selector: "$.a", data: `{"a":1} {"a":2}`, err: "invalid data. unexpected value after top-level value"},
+		{selector: "$.a", data: "{\"a\":1}\n", expected: float64(1)},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector)
+			assert.Nil(t, err)
+
+			actual, err := selector.QueryReader(strings.NewReader(test.data))
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("not compiled", func(t *testing.T) {
+		selector := &Selector{selector: "$"}
+		actual, err := selector.QueryReader(strings.NewReader(sampleDataString))
+		assert.EqualError(t, err, "invalid JSONPath selector '$'")
+		assert.Nil(t, actual)
+	})
+}
+
+func Test_Selector_QueryBytes(t *testing.T) {
+
+	tests := []struct {
+		selector string
+		options  []Option
+		data     string
+		expected []string
+		err      string
+	}{
+		{selector: "$.store.book[-1].title", data: sampleDataString, expected: []string{`"The Lord of the Rings"`}},
+		{selector: "$.store.bicycle", data: `{"store": {"bicycle": {"color": "red"}}}`, expected: []string{`{"color": "red"}`}},
+		{selector: "$..book[?(@.price < $.expensive)].price", data: sampleDataString, expected: []string{`8.95`, `8.99`}},
+		{selector: "$[?@.a]", options: []Option{Standard(RFC9535)}, data: `[{"a": 1}, {"b": 2}]`, expected: []string{`{"a": 1}`}},
+		{selector: "$.name[0:3]", options: []Option{QueryOptions(&option.QueryOptions{AllowStringReferenceByIndex: true})}, data: `{"name": "string"}`, expected: []string{`"s"`, `"t"`, `"r"`}},
+		{selector: "$.users[?(@.id == 9007199254740993)].id", options: []Option{QueryOptions(&option.QueryOptions{UseNumber: true})}, data: `{"users": [{"id": 9007199254740993}, {"id": 9007199254740992}]}`, expected: []string{`9007199254740993`}},
+		{selector: "$.ids[?(@ in [9007199254740993])]", options: []Option{QueryOptions(&option.QueryOptions{UseNumber: true})}, data: `{"ids": [9007199254740993, 9007199254740992]}`, expected: []string{`9007199254740993`}},
+		{selector: "$.store.missing", data: sampleDataString, err: "key: invalid token key 'missing' not found"},
+		{selector: "$.store", data: `{"store":`, err: "invalid data. unexpected end of JSON input"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.selector, test.options...)
+			assert.Nil(t, err)
+
+			actual, err := selector.QueryBytes([]byte(test.data))
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.Nil(t, actual)
+				return
+			}
+			assert.Nil(t, err)
+
+			values := make([]string, len(actual))
+			for idx, raw := range actual {
+				values[idx] = string(raw)
+			}
+			assert.Equal(t, test.expected, values)
+		})
+	}
+
+	t.Run("not compiled", func(t *testing.T) {
+		selector := &Selector{selector: "$"}
+		actual, err := selector.QueryBytes([]byte(sampleDataString))
+		assert.EqualError(t, err, "invalid JSONPath selector '$'")
+		assert.Nil(t, actual)
+	})
+}
+
+func Test_Selector_Set(t *testing.T) {
+
+	type input struct {
+		selector string
+		root     interface{}
+		value    interface{}
+	}
+
+	type expected struct {
+		value interface{}
+		err   string
+	}
+
+	tests := []struct {
+		input    input
+		expected expected
+	}{
+		{
+			input: input{
+				selector: "$.missing",
+				root:     map[string]interface{}{},
+				value:    "new",
+			},
+			expected: expected{
+				err: "key: invalid token key 'missing' not found",
+			},
+		},
+		{
+			input: input{
+				selector: "$",
+				root:     map[string]interface{}{},
+				value:    "new",
+			},
+			expected: expected{
+				value: "new",
+			},
+		},
+		{
+			input: input{
+				selector: "$..password",
+				root: map[string]interface{}{
+					"password": "secret",
+					"users": []interface{}{
+						map[string]interface{}{"name": "one", "password": "first"},
+						map[string]interface{}{"name": "two"},
+						map[string]interface{}{"name": "three", "password": "third"},
+					},
+				},
+				value: "redacted",
+			},
+			expected: expected{
+				value: map[string]interface{}{
+					"password": "redacted",
+					"users": []interface{}{
+						map[string]interface{}{"name": "one", "password": "redacted"},
+						map[string]interface{}{"name": "two"},
+						map[string]interface{}{"name": "three", "password": "redacted"},
+					},
+				},
+			},
+		},
+		{
+			input: input{
+				selector: "$[?(@.enabled == false)].enabled",
+				root: []interface{}{
+					map[string]interface{}{"enabled": true},
+					map[string]interface{}{"enabled": false},
+				},
+				value: true,
+			},
+			expected: expected{
+				value: []interface{}{
+					map[string]interface{}{"enabled": true},
+					map[string]interface{}{"enabled": true},
+				},
+			},
+		},
+		{
+			input: input{
+				selector: "$[0:2]",
+				root:     []interface{}{1, 2, 3},
+				value:    0,
+			},
+			expected: expected{
+				value: []interface{}{0, 0, 3},
+			},
+		},
+		{
+			input: input{
+				selector: "$['a','c']",
+				root:     map[string]interface{}{"a": 1, "b": 2, "c": 3},
+				value:    0,
+			},
+			expected: expected{
+				value: map[string]interface{}{"a": 0, "b": 2, "c": 0},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[*].price",
+				root: &sampleData{
+					Store: &storeData{
+						Book: []*bookData{
+							{Title: "one", Price: 1},
+							{Title: "two", Price: 2},
+						},
+					},
+				},
+				value: 10,
+			},
+			expected: expected{
+				value: &sampleData{
+					Store: &storeData{
+						Book: []*bookData{
+							{Title: "one", Price: 10},
+							{Title: "two", Price: 10},
+						},
+					},
+				},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[0].price",
+				root: &sampleData{
+					Store: &storeData{
+						Book: []*bookData{{Title: "one", Price: 1}},
+					},
+				},
+				value: "free",
+			},
+			expected: expected{
+				err: "invalid path $['store']['book'][0]['price']. can not use value of type [string] as [float64]",
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.input.selector)
+			assert.Nil(t, err)
+
+			actual, err := selector.Set(test.input.root, test.input.value)
+			if test.expected.err != "" {
+				assert.EqualError(t, err, test.expected.err)
+				assert.Nil(t, actual)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected.value, actual)
+		})
+	}
+}
+
+func Test_Selector_Update(t *testing.T) {
+
+	t.Run("increment", func(t *testing.T) {
+		root := map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"version": float64(1)},
+				map[string]interface{}{"version": float64(4)},
+			},
+		}
+
+		selector, _ := Compile("$.items[*].version")
+		actual, err := selector.Update(root, func(value interface{}) interface{} {
+			return value.(float64) + 1
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"version": float64(2)},
+				map[string]interface{}{"version": float64(5)},
+			},
+		}, actual)
+		// maps and slices are modified in place
+		assert.Equal(t, actual, root)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		selector, _ := Compile("$[0,0,1]")
+		actual, err := selector.Update([]interface{}{1, 2}, func(value interface{}) interface{} {
+			return value.(int) * 10
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{10, 20}, actual)
+	})
+
+	t.Run("deepest first", func(t *testing.T) {
+		selector, _ := Compile("$..*")
+		actual, err := selector.Update(map[string]interface{}{
+			"a": map[string]interface{}{"b": "c"},
+		}, func(value interface{}) interface{} {
+			if str, ok := value.(string); ok {
+				return str + "!"
+			}
+			return value
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"a": map[string]interface{}{"b": "c!"},
+		}, actual)
+	})
+
+	t.Run("struct value", func(t *testing.T) {
+		selector, _ := Compile("$.title")
+		actual, err := selector.Update(bookData{Title: "old"}, func(value interface{}) interface{} {
+			return "new"
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, bookData{Title: "new"}, actual)
+	})
+}
+
+func Test_Selector_Delete(t *testing.T) {
+
+	type input struct {
+		selector string
+		root     interface{}
+	}
+
+	type expected struct {
+		value interface{}
+		err   string
+	}
+
+	tests := []struct {
+		input    input
+		expected expected
+	}{
+		{
+			input: input{
+				selector: "$.missing",
+				root:     map[string]interface{}{},
+			},
+			expected: expected{
+				err: "key: invalid token key 'missing' not found",
+			},
+		},
+		{
+			input: input{
+				selector: "$",
+				root:     map[string]interface{}{},
+			},
+			expected: expected{
+				value: nil,
+			},
+		},
+		{
+			input: input{
+				selector: "$.users[?(@.disabled==true)]",
+				root: map[string]interface{}{
+					"users": []interface{}{
+						map[string]interface{}{"name": "one", "disabled": true},
+						map[string]interface{}{"name": "two", "disabled": false},
+						map[string]interface{}{"name": "three", "disabled": true},
+						map[string]interface{}{"name": "four", "disabled": true},
+						map[string]interface{}{"name": "five"},
+					},
+				},
+			},
+			expected: expected{
+				value: map[string]interface{}{
+					"users": []interface{}{
+						map[string]interface{}{"name": "two", "disabled": false},
+						map[string]interface{}{"name": "five"},
+					},
+				},
+			},
+		},
+		{
+			input: input{
+				selector: "$..debug",
+				root: map[string]interface{}{
+					"debug": map[string]interface{}{
+						"debug": true,
+					},
+					"nested": []interface{}{
+						map[string]interface{}{"debug": 1, "keep": 1},
+						[]interface{}{
+							map[string]interface{}{"debug": 2, "keep": 2},
+						},
+					},
+				},
+			},
+			expected: expected{
+				value: map[string]interface{}{
+					"nested": []interface{}{
+						map[string]interface{}{"keep": 1},
+						[]interface{}{
+							map[string]interface{}{"keep": 2},
+						},
+					},
+				},
+			},
+		},
+		{
+			input: input{
+				selector: "$[0,2,-1]",
+				root:     []interface{}{0, 1, 2, 3, 4},
+			},
+			expected: expected{
+				value: []interface{}{1, 3},
+			},
+		},
+		{
+			input: input{
+				selector: "$[*][1:]",
+				root: []interface{}{
+					[]interface{}{"a", "b", "c"},
+					[]interface{}{"d"},
+				},
+			},
+			expected: expected{
+				value: []interface{}{
+					[]interface{}{"a"},
+					[]interface{}{"d"},
+				},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[?(@.price > 10)]",
+				root:     &sampleData{Store: &storeData{Book: []*bookData{{Price: 5}, {Price: 15}}}},
+			},
+			expected: expected{
+				value: &sampleData{Store: &storeData{Book: []*bookData{{Price: 5}}}},
+			},
+		},
+		{
+			input: input{
+				selector: "$.store.book[0].price",
+				root:     &sampleData{Store: &storeData{Book: []*bookData{{Price: 5}}}},
+			},
+			expected: expected{
+				err: "invalid path $['store']['book'][0]. unexpected target [struct]",
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, err := Compile(test.input.selector)
+			assert.Nil(t, err)
+
+			actual, err := selector.Delete(test.input.root)
+			if test.expected.err != "" {
+				assert.EqualError(t, err, test.expected.err)
+				assert.Nil(t, actual)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected.value, actual)
+		})
+	}
+}
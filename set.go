@@ -0,0 +1,122 @@
+package jsonpath
+
+import (
+	"context"
+	"sort"
+
+	"github.com/evilmonkeyinc/jsonpath/token"
+)
+
+// SelectorSet represents a set of named compiled selectors that are applied to the same data in a single pass,
+// the tokens at the start of the selectors that are the same, such as $.store.book, are only applied once.
+type SelectorSet struct {
+	names     []string
+	selectors []*Selector
+	set       *token.Set
+}
+
+// CompileSet will compile the JSONPath selectors, by name, as a set
+func CompileSet(selectors map[string]string, options ...Option) (*SelectorSet, error) {
+	names := make([]string, 0, len(selectors))
+	for name := range selectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	set := &SelectorSet{
+		names:     names,
+		selectors: make([]*Selector, len(names)),
+	}
+	tokens := make([][]token.Token, len(names))
+	for idx, name := range names {
+		selector, err := Compile(selectors[name], options...)
+		if err != nil {
+			return nil, getInvalidJSONPathSelectorWithReason(selectors[name], err)
+		}
+		set.selectors[idx] = selector
+		tokens[idx] = selector.tokens
+	}
+	set.set = token.NewSet(tokens)
+
+	return set, nil
+}
+
+// Names returns the names of the selectors in the set in sorted order
+func (set *SelectorSet) Names() []string {
+	names := make([]string, len(set.names))
+	copy(names, set.names)
+	return names
+}
+
+// Query will return the values matched by each selector of the set applied against the specified JSON data, by the name of the selector.
+//
+// Like QueryNodes, the result of each selector is a flat collection of the matched values, which is the same as the result of Query
+// for selectors compiled with the RFC9535 standard. If any of the selectors can not be applied, the results of the other selectors
+// are returned along with a SelectorSetError that includes the error of each selector that failed.
+func (set *SelectorSet) Query(root interface{}) (map[string]interface{}, error) {
+	return set.QueryContext(context.Background(), root)
+}
+
+// QueryContext is the equivalent of Query with a context, the query is stopped and the
+// context error returned if the context is cancelled or its deadline is exceeded.
+func (set *SelectorSet) QueryContext(ctx context.Context, root interface{}) (map[string]interface{}, error) {
+	nodes, err := set.QueryNodesContext(ctx, root)
+	if nodes == nil {
+		return nil, err
+	}
+
+	results := make(map[string]interface{}, len(nodes))
+	for name, matched := range nodes {
+		values := make([]interface{}, len(matched))
+		for idx, node := range matched {
+			values[idx] = node.Value
+		}
+		results[name] = values
+	}
+	return results, err
+}
+
+// QueryNodes will return the nodes matched by each selector of the set applied against the specified JSON data, by the name of the selector.
+//
+// The nodes of each selector are the same as those returned by QueryNodes. If any of the selectors can not be applied, the results
+// of the other selectors are returned along with a SelectorSetError that includes the error of each selector that failed.
+func (set *SelectorSet) QueryNodes(root interface{}) (map[string][]*token.Node, error) {
+	return set.QueryNodesContext(context.Background(), root)
+}
+
+// QueryNodesContext is the equivalent of QueryNodes with a context, the query is stopped and the
+// context error returned if the context is cancelled or its deadline is exceeded.
+func (set *SelectorSet) QueryNodesContext(ctx context.Context, root interface{}) (map[string][]*token.Node, error) {
+	matched, errs := set.set.ApplyNodes(ctx, root)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, getQueryCancelledError(ctxErr)
+	}
+
+	results := make(map[string][]*token.Node, len(set.names))
+	failed := make(map[string]error)
+	for idx, name := range set.names {
+		if errs[idx] != nil {
+			if !set.selectors[idx].suppressError(errs[idx]) {
+				failed[name] = errs[idx]
+				continue
+			}
+		}
+
+		nodes := matched[idx]
+		if nodes == nil {
+			nodes = make([]*token.Node, 0)
+		}
+		if options := set.selectors[idx].Options; options != nil {
+			if maximum := options.Limits.MaxResults; maximum > 0 && len(nodes) > maximum {
+				failed[name] = getLimitExceededError("result count", maximum)
+				continue
+			}
+		}
+		results[name] = nodes
+	}
+
+	if len(failed) > 0 {
+		return results, &SelectorSetError{Errors: failed}
+	}
+	return results, nil
+}
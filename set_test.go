@@ -0,0 +1,194 @@
+package jsonpath
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/stretchr/testify/assert"
+)
+
+var setSelectors = map[string]string{
+	"authors":      "$.store.book[*].author",
+	"allAuthors":   "$..author",
+	"store":        "$.store.*",
+	"prices":       "$.store..price",
+	"third":        "$..book[2]",
+	"last":         "$..book[-1:]",
+	"firstTwo":     "$..book[0,1]",
+	"firstCheap":   "$.store.book[?(@.price < 10)][0]",
+	"cheapTitles":  "$.store.book[?(@.price < 10)].title",
+	"isbn":         "$.store.book[*].isbn",
+	"anyIsbn":      "$..book[*].isbn",
+	"bicycle":      "$.store.bicycle.color",
+	"missing":      "$.store.missing",
+	"outOfRange":   "$.store.book[9].title",
+	"everything":   "$..*",
+	"bookCount":    "$.store.book.length",
+	"expensive":    "$.expensive",
+	"lastByScript": "$.store.book[(@.length-1)].title",
+}
+
+func Benchmark_SelectorSet(b *testing.B) {
+	selectors := make([]*Selector, 0)
+	for _, selector := range setSelectors {
+		compiled, _ := Compile(selector)
+		selectors = append(selectors, compiled)
+	}
+	set, _ := CompileSet(setSelectors)
+
+	b.Run("Selector.QueryNodes", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, selector := range selectors {
+				selector.QueryNodes(sampleDataObject)
+			}
+		}
+	})
+	b.Run("SelectorSet.QueryNodes", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			set.QueryNodes(sampleDataObject)
+		}
+	})
+}
+
+func Test_CompileSet(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		set, err := CompileSet(map[string]string{"b": "$.b", "a": "$.a"})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b"}, set.Names())
+	})
+	t.Run("empty", func(t *testing.T) {
+		set, err := CompileSet(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{}, set.Names())
+
+		results, err := set.Query(sampleDataObject)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{}, results)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		set, err := CompileSet(map[string]string{"a": "$.a", "b": "$.b["})
+		assert.EqualError(t, err, "invalid JSONPath selector '$.b[' invalid token. '[' does not match any token format")
+		assert.Nil(t, set)
+	})
+}
+
+func Test_SelectorSet_QueryNodes(t *testing.T) {
+	var data interface{}
+	json.Unmarshal([]byte(sampleDataString), &data)
+
+	configurations := map[string][]Option{
+		string(Goessner): {Standard(Goessner)},
+		string(RFC9535):  {Standard(RFC9535)},
+		"leaf to null":   {QueryOptions(&option.QueryOptions{DefaultPathLeafToNull: true})},
+		"require":        {QueryOptions(&option.QueryOptions{RequireProperties: true})},
+		"suppress":       {QueryOptions(&option.QueryOptions{SuppressExceptions: true})},
+	}
+
+	for configuration, options := range configurations {
+		options := options
+		t.Run(configuration, func(t *testing.T) {
+			selectors := make(map[string]string)
+			for name, selector := range setSelectors {
+				if _, err := Compile(selector, options...); err == nil {
+					selectors[name] = selector
+				}
+			}
+
+			set, err := CompileSet(selectors, options...)
+			assert.Nil(t, err)
+
+			results, err := set.QueryNodes(data)
+			failed := map[string]error{}
+			if err != nil {
+				setError, ok := err.(*SelectorSetError)
+				assert.True(t, ok)
+				failed = setError.Errors
+			}
+
+			for name, selector := range selectors {
+				t.Run(name, func(t *testing.T) {
+					compiled, _ := Compile(selector, options...)
+					expected, expectedErr := compiled.QueryNodes(data)
+					if expectedErr != nil {
+						assert.EqualError(t, failed[name], expectedErr.Error())
+						assert.Equal(t, expectedErr.(*QueryError).Path, failed[name].(*QueryError).Path)
+						assert.NotContains(t, results, name)
+						return
+					}
+					assert.Nil(t, failed[name])
+					assert.Equal(t, expected, results[name])
+				})
+			}
+		})
+	}
+}
+
+func Test_SelectorSet_Query(t *testing.T) {
+	var data interface{}
+	json.Unmarshal([]byte(sampleDataString), &data)
+
+	set, _ := CompileSet(map[string]string{
+		"authors": "$.store.book[0:2].author",
+		"first":   "$.store.book[0].title",
+		"colors":  "$..color",
+		"none":    "$.store.book[?(@.price > 100)]",
+		"missing": "$.store.missing",
+		"nil":     "$.store.book[*].missing",
+	})
+
+	results, err := set.Query(data)
+	assert.EqualError(t, err, "selector 'missing' failed. key: invalid token key 'missing' not found")
+	assert.Equal(t, map[string]interface{}{
+		"authors": []interface{}{"Nigel Rees", "Evelyn Waugh"},
+		"first":   []interface{}{"Sayings of the Century"},
+		"colors":  []interface{}{"red"},
+		"none":    []interface{}{},
+		"nil":     []interface{}{},
+	}, results)
+
+	t.Run("limit", func(t *testing.T) {
+		set, _ := CompileSet(map[string]string{
+			"authors": "$..author",
+			"color":   "$.store.bicycle.color",
+		}, QueryOptions(&option.QueryOptions{Limits: option.Limits{MaxResults: 2}}))
+
+		results, err := set.Query(data)
+		assert.EqualError(t, err, "selector 'authors' failed. limit exceeded. result count exceeds maximum of 2")
+		assert.Equal(t, map[string]interface{}{"color": []interface{}{"red"}}, results)
+	})
+	t.Run("require properties", func(t *testing.T) {
+		options := QueryOptions(&option.QueryOptions{RequireProperties: true})
+		set, _ := CompileSet(map[string]string{
+			"isbn":  "$..book[*].isbn",
+			"title": "$..book[*].title",
+		}, options)
+
+		results, err := set.Query(data)
+		assert.Nil(t, err)
+
+		expected, err := Query("$..book[*].isbn", data, options)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, results["isbn"])
+		assert.Equal(t, []interface{}{}, results["isbn"])
+		assert.Len(t, results["title"], 4)
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results, err := set.QueryContext(ctx, data)
+		assert.EqualError(t, err, "query cancelled. context canceled")
+		assert.Nil(t, results)
+	})
+}
+
+func Test_SelectorSetError(t *testing.T) {
+	err := &SelectorSetError{Errors: map[string]error{
+		"b": fmt.Errorf("second"),
+		"a": fmt.Errorf("first"),
+	}}
+	assert.EqualError(t, err, "selector 'a' failed. first, selector 'b' failed. second")
+}
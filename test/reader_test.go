@@ -0,0 +1,106 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_QueryReader checks that reading the data as a stream returns the same result as QueryString
+func Test_QueryReader(t *testing.T) {
+	sets := map[string][]testData{
+		"array":   arrayTests,
+		"bracket": bracketTests,
+		"dot":     dotTests,
+		"filter":  filterTests,
+		"misc":    miscTests,
+		"union":   unionTests,
+	}
+
+	standards := []jsonpath.Specification{jsonpath.Goessner, jsonpath.RFC9535}
+
+	for _, standard := range standards {
+		for name, tests := range sets {
+			for idx, test := range tests {
+				data := strings.TrimSpace(test.data)
+				if !strings.HasPrefix(data, "{") && !strings.HasPrefix(data, "[") {
+					// QueryString handles scalar values differently to json decoding
+					continue
+				}
+
+				t.Run(fmt.Sprintf("%s/%s/%d", standard, name, idx), func(t *testing.T) {
+					selector, err := jsonpath.Compile(test.selector, jsonpath.Standard(standard))
+					if err != nil {
+						return
+					}
+
+					expected, expectedErr := selector.QueryString(data)
+					actual, actualErr := selector.QueryReader(strings.NewReader(data))
+					if expectedErr != nil {
+						assert.EqualError(t, actualErr, expectedErr.Error(), test.selector)
+					} else {
+						assert.Nil(t, actualErr, test.selector)
+					}
+					assert.Equal(t, expected, actual, test.selector)
+				})
+			}
+		}
+	}
+}
+
+// Test_QueryBytes checks that querying the raw data returns the same values as QueryNodes
+func Test_QueryBytes(t *testing.T) {
+	sets := map[string][]testData{
+		"array":   arrayTests,
+		"bracket": bracketTests,
+		"dot":     dotTests,
+		"filter":  filterTests,
+		"misc":    miscTests,
+		"union":   unionTests,
+	}
+
+	standards := []jsonpath.Specification{jsonpath.Goessner, jsonpath.RFC9535}
+
+	for _, standard := range standards {
+		for name, tests := range sets {
+			for idx, test := range tests {
+				data := strings.TrimSpace(test.data)
+				if !strings.HasPrefix(data, "{") && !strings.HasPrefix(data, "[") {
+					// QueryString handles scalar values differently to json decoding
+					continue
+				}
+
+				t.Run(fmt.Sprintf("%s/%s/%d", standard, name, idx), func(t *testing.T) {
+					selector, err := jsonpath.Compile(test.selector, jsonpath.Standard(standard))
+					if err != nil {
+						return
+					}
+
+					var root interface{}
+					assert.Nil(t, json.Unmarshal([]byte(data), &root))
+
+					nodes, expectedErr := selector.QueryNodes(root)
+					actual, actualErr := selector.QueryBytes([]byte(data))
+					if expectedErr != nil {
+						assert.EqualError(t, actualErr, expectedErr.Error(), test.selector)
+						assert.Nil(t, actual, test.selector)
+						return
+					}
+					assert.Nil(t, actualErr, test.selector)
+
+					expected := make([]interface{}, len(nodes))
+					for idx, node := range nodes {
+						expected[idx] = node.Value
+					}
+					expectedJSON, _ := json.Marshal(expected)
+					actualJSON, _ := json.Marshal(actual)
+					assert.JSONEq(t, string(expectedJSON), string(actualJSON), test.selector)
+				})
+			}
+		}
+	}
+}
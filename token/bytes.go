@@ -0,0 +1,516 @@
+package token
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+)
+
+// DecodeBytes returns the JSON value in the data, the tokens should be the tokens of a
+// selector starting with the root token.
+//
+// Like Decode, object members and array elements that can not be selected by the tokens are
+// skipped without being decoded and skipped array elements are replaced with nil. The values
+// reached once all of the tokens are applied are not decoded, they are returned as a json.RawMessage
+// that references the data. Any token other than a key, index, wildcard, range, union, or slice token
+// causes the complete value it is applied to be decoded, or the complete data if the token may reference the root.
+// Numbers in decoded values are decoded as json.Number when the UseNumber option is enabled, and objects
+// are decoded as *ordered.Object when the PreserveKeyOrder option is enabled.
+func DecodeBytes(data []byte, tokens []Token, options *option.QueryOptions) (interface{}, error) {
+	decoder := &byteDecoder{
+		data:             data,
+		useNumber:        options != nil && options.UseNumber,
+		preserveKeyOrder: options != nil && options.PreserveKeyOrder,
+	}
+	if len(tokens) == 0 {
+		return decoder.decodeAll(data)
+	}
+	if _, ok := tokens[0].(*rootToken); !ok || requiresRoot(tokens[1:]) {
+		return decoder.decodeAll(data)
+	}
+
+	value, err := decoder.decodeSelection(tokens[1:])
+	if err != nil {
+		return nil, err
+	}
+	decoder.skipBlank()
+	if !decoder.atEnd() {
+		return nil, decoder.syntaxError()
+	}
+	return value, nil
+}
+
+// RawValue returns the raw JSON value at the path within the data,
+// false is returned if the path does not exist in the data.
+func RawValue(data []byte, path Path) (json.RawMessage, bool) {
+	raw := RawValues(data, []Path{path})[0]
+	return raw, raw != nil
+}
+
+// RawValues returns the raw JSON value at each of the paths within the data, reading the data once.
+// The value is nil for a path that does not exist in the data.
+func RawValues(data []byte, paths []Path) []json.RawMessage {
+	values := make([]json.RawMessage, len(paths))
+	tree := &rawPathTree{}
+	for idx, path := range paths {
+		tree.add(path, idx)
+	}
+
+	decoder := &byteDecoder{data: data}
+	decoder.readRawValues(tree, values)
+	return values
+}
+
+// rawPathTree holds the paths to read raw values for, grouped by their shared elements
+type rawPathTree struct {
+	indexes  []int
+	members  map[string]*rawPathTree
+	elements map[int]*rawPathTree
+}
+
+// add adds the path to the tree, the raw value of the path is stored at the index of the values
+func (tree *rawPathTree) add(path Path, index int) {
+	for _, element := range path {
+		var next *rawPathTree
+		switch typed := element.(type) {
+		case string:
+			if tree.members == nil {
+				tree.members = make(map[string]*rawPathTree)
+			}
+			if next = tree.members[typed]; next == nil {
+				next = &rawPathTree{}
+				tree.members[typed] = next
+			}
+		case int:
+			if tree.elements == nil {
+				tree.elements = make(map[int]*rawPathTree)
+			}
+			if next = tree.elements[typed]; next == nil {
+				next = &rawPathTree{}
+				tree.elements[typed] = next
+			}
+		default:
+			// path can not exist in JSON data
+			return
+		}
+		tree = next
+	}
+	tree.indexes = append(tree.indexes, index)
+}
+
+// clear removes the values read for the paths in the tree
+func (tree *rawPathTree) clear(values []json.RawMessage) {
+	for _, index := range tree.indexes {
+		values[index] = nil
+	}
+	for _, member := range tree.members {
+		member.clear(values)
+	}
+	for _, element := range tree.elements {
+		element.clear(values)
+	}
+}
+
+// readRawValues reads the next value, storing the raw values of the paths in the tree
+func (decoder *byteDecoder) readRawValues(tree *rawPathTree, values []json.RawMessage) bool {
+	decoder.skipBlank()
+	start := decoder.offset
+
+	switch next := decoder.peek(); {
+	case next == '{' && len(tree.members) > 0:
+		decoder.offset++
+		seen := make(map[string]bool)
+		for first := true; ; first = false {
+			decoder.skipBlank()
+			if decoder.peek() == '}' {
+				decoder.offset++
+				break
+			}
+			if !first && decoder.expect(',') != nil {
+				return false
+			}
+			name, err := decoder.readKey()
+			if err != nil || decoder.expect(':') != nil {
+				return false
+			}
+			member, ok := tree.members[name]
+			if !ok {
+				if decoder.skipValue() != nil {
+					return false
+				}
+				continue
+			}
+			if seen[name] {
+				// the last member with the key is used
+				member.clear(values)
+			}
+			seen[name] = true
+			if !decoder.readRawValues(member, values) {
+				return false
+			}
+		}
+	case next == '[' && len(tree.elements) > 0:
+		decoder.offset++
+		for idx := 0; ; idx++ {
+			decoder.skipBlank()
+			if decoder.peek() == ']' {
+				decoder.offset++
+				break
+			}
+			if idx > 0 && decoder.expect(',') != nil {
+				return false
+			}
+			element, ok := tree.elements[idx]
+			if !ok {
+				if decoder.skipValue() != nil {
+					return false
+				}
+				continue
+			}
+			if !decoder.readRawValues(element, values) {
+				return false
+			}
+		}
+	default:
+		if decoder.skipValue() != nil {
+			return false
+		}
+	}
+
+	raw := json.RawMessage(decoder.data[start:decoder.offset:decoder.offset])
+	for _, index := range tree.indexes {
+		values[index] = raw
+	}
+	return true
+}
+
+// byteDecoder reads JSON values from the data without decoding the values that are skipped
+type byteDecoder struct {
+	data             []byte
+	offset           int
+	useNumber        bool
+	preserveKeyOrder bool
+}
+
+// decodeAll decodes the complete value in the data
+func (decoder *byteDecoder) decodeAll(data []byte) (interface{}, error) {
+	if !decoder.useNumber && !decoder.preserveKeyOrder {
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+
+	if !json.Valid(data) {
+		return nil, decoder.syntaxError()
+	}
+	valueDecoder := json.NewDecoder(bytes.NewReader(data))
+	if decoder.useNumber {
+		valueDecoder.UseNumber()
+	}
+	return decodeAll(valueDecoder, decoder.preserveKeyOrder)
+}
+
+func (decoder *byteDecoder) atEnd() bool {
+	return decoder.offset >= len(decoder.data)
+}
+
+func (decoder *byteDecoder) peek() byte {
+	if decoder.atEnd() {
+		return 0
+	}
+	return decoder.data[decoder.offset]
+}
+
+func (decoder *byteDecoder) skipBlank() {
+	for !decoder.atEnd() {
+		switch decoder.peek() {
+		case ' ', '\t', '\n', '\r':
+			decoder.offset++
+			continue
+		}
+		return
+	}
+}
+
+// syntaxError returns the error reported by the json package for the data
+func (decoder *byteDecoder) syntaxError() error {
+	var value interface{}
+	if err := json.Unmarshal(decoder.data, &value); err != nil {
+		return err
+	}
+	return getUnexpectedTokenError(string(decoder.peek()), decoder.offset)
+}
+
+// expect reads the next character if it matches, otherwise returns an error
+func (decoder *byteDecoder) expect(char byte) error {
+	decoder.skipBlank()
+	if decoder.peek() != char {
+		return decoder.syntaxError()
+	}
+	decoder.offset++
+	return nil
+}
+
+// decodeSelection reads the next value, only including the children that may be selected by the tokens
+func (decoder *byteDecoder) decodeSelection(tokens []Token) (interface{}, error) {
+	decoder.skipBlank()
+	if len(tokens) == 0 {
+		return decoder.rawValue()
+	}
+
+	var selection *streamSelection
+	if stream, ok := tokens[0].(streamable); ok {
+		selection, _ = stream.streamSelection(tokens[1:])
+	}
+
+	next := decoder.peek()
+	if selection == nil || (next != '{' && next != '[') {
+		raw, err := decoder.rawValue()
+		if err != nil {
+			return nil, err
+		}
+		return decoder.decodeAll(raw)
+	}
+	decoder.offset++
+
+	first := true
+	// separator reads the comma between members or elements
+	separator := func() error {
+		if first {
+			first = false
+			return nil
+		}
+		return decoder.expect(',')
+	}
+	decode := func() (interface{}, error) {
+		return decoder.decodeSelection(selection.next)
+	}
+
+	if next == '{' {
+		more := func() bool {
+			decoder.skipBlank()
+			return decoder.peek() != '}'
+		}
+		key := func() (string, error) {
+			if err := separator(); err != nil {
+				return "", err
+			}
+			key, err := decoder.readKey()
+			if err != nil {
+				return "", err
+			}
+			return key, decoder.expect(':')
+		}
+		object, err := selection.decodeObject(more, key, decode, decoder.skipValue, decoder.preserveKeyOrder)
+		if err != nil {
+			return nil, err
+		}
+		return object, decoder.expect('}')
+	}
+
+	more := func() bool {
+		decoder.skipBlank()
+		return decoder.peek() != ']'
+	}
+	element := func() (interface{}, error) {
+		if err := separator(); err != nil {
+			return nil, err
+		}
+		return decode()
+	}
+	skip := func() error {
+		if err := separator(); err != nil {
+			return err
+		}
+		return decoder.skipValue()
+	}
+	array, err := selection.decodeArray(more, element, skip)
+	if err != nil {
+		return nil, err
+	}
+	return array, decoder.expect(']')
+}
+
+// rawValue reads the next value and returns the bytes that represent it
+func (decoder *byteDecoder) rawValue() (json.RawMessage, error) {
+	decoder.skipBlank()
+	start := decoder.offset
+	if err := decoder.skipValue(); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(decoder.data[start:decoder.offset:decoder.offset]), nil
+}
+
+// readKey reads an object member name
+func (decoder *byteDecoder) readKey() (string, error) {
+	decoder.skipBlank()
+	start := decoder.offset
+	escaped, err := decoder.skipString()
+	if err != nil {
+		return "", err
+	}
+
+	raw := decoder.data[start:decoder.offset]
+	if !escaped {
+		return string(raw[1 : len(raw)-1]), nil
+	}
+	var key string
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// skipValue reads the next value without decoding it, returning an error if it is not valid JSON
+func (decoder *byteDecoder) skipValue() error {
+	decoder.skipBlank()
+	switch next := decoder.peek(); {
+	case next == '{':
+		decoder.offset++
+		for first := true; ; first = false {
+			decoder.skipBlank()
+			if decoder.peek() == '}' {
+				decoder.offset++
+				return nil
+			}
+			if !first {
+				if err := decoder.expect(','); err != nil {
+					return err
+				}
+				decoder.skipBlank()
+			}
+			if _, err := decoder.skipString(); err != nil {
+				return err
+			}
+			if err := decoder.expect(':'); err != nil {
+				return err
+			}
+			if err := decoder.skipValue(); err != nil {
+				return err
+			}
+		}
+	case next == '[':
+		decoder.offset++
+		for first := true; ; first = false {
+			decoder.skipBlank()
+			if decoder.peek() == ']' {
+				decoder.offset++
+				return nil
+			}
+			if !first {
+				if err := decoder.expect(','); err != nil {
+					return err
+				}
+			}
+			if err := decoder.skipValue(); err != nil {
+				return err
+			}
+		}
+	case next == '"':
+		_, err := decoder.skipString()
+		return err
+	case next == '-' || (next >= '0' && next <= '9'):
+		return decoder.skipNumber()
+	case next == 't':
+		return decoder.skipLiteral("true")
+	case next == 'f':
+		return decoder.skipLiteral("false")
+	case next == 'n':
+		return decoder.skipLiteral("null")
+	}
+	return decoder.syntaxError()
+}
+
+// skipString reads a string, returning true if it contains escape sequences
+func (decoder *byteDecoder) skipString() (bool, error) {
+	if decoder.peek() != '"' {
+		return false, decoder.syntaxError()
+	}
+	decoder.offset++
+
+	escaped := false
+	for !decoder.atEnd() {
+		char := decoder.data[decoder.offset]
+		switch {
+		case char == '"':
+			decoder.offset++
+			return escaped, nil
+		case char < 0x20:
+			return false, decoder.syntaxError()
+		case char == '\\':
+			escaped = true
+			decoder.offset++
+			switch decoder.peek() {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+			case 'u':
+				for idx := 1; idx <= 4; idx++ {
+					if !isHexDigit(decoder.data, decoder.offset+idx) {
+						return false, decoder.syntaxError()
+					}
+				}
+				decoder.offset += 4
+			default:
+				return false, decoder.syntaxError()
+			}
+		}
+		decoder.offset++
+	}
+	return false, decoder.syntaxError()
+}
+
+// skipNumber reads a number in the format -?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?
+func (decoder *byteDecoder) skipNumber() error {
+	digits := func() int {
+		count := 0
+		for next := decoder.peek(); next >= '0' && next <= '9'; next = decoder.peek() {
+			decoder.offset++
+			count++
+		}
+		return count
+	}
+
+	if decoder.peek() == '-' {
+		decoder.offset++
+	}
+	if decoder.peek() == '0' {
+		decoder.offset++
+	} else if digits() == 0 {
+		return decoder.syntaxError()
+	}
+	if decoder.peek() == '.' {
+		decoder.offset++
+		if digits() == 0 {
+			return decoder.syntaxError()
+		}
+	}
+	if next := decoder.peek(); next == 'e' || next == 'E' {
+		decoder.offset++
+		if next := decoder.peek(); next == '-' || next == '+' {
+			decoder.offset++
+		}
+		if digits() == 0 {
+			return decoder.syntaxError()
+		}
+	}
+	return nil
+}
+
+func (decoder *byteDecoder) skipLiteral(literal string) error {
+	end := decoder.offset + len(literal)
+	if end > len(decoder.data) || string(decoder.data[decoder.offset:end]) != literal {
+		return decoder.syntaxError()
+	}
+	decoder.offset = end
+	return nil
+}
+
+func isHexDigit(data []byte, offset int) bool {
+	if offset >= len(data) {
+		return false
+	}
+	char := data[offset]
+	return (char >= '0' && char <= '9') || (char >= 'a' && char <= 'f') || (char >= 'A' && char <= 'F')
+}
@@ -0,0 +1,261 @@
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DecodeBytes(t *testing.T) {
+
+	data := `{"a": {"b": [1, {"c": 2, "d": 3}, [4, 5]], "e": "f"}, "g": [6, 7, 8, 9], "hi": true}`
+
+	parse := func(selector string, options *option.QueryOptions) []Token {
+		tokenStrings, err := Tokenize(selector, nil)
+		assert.Nil(t, err)
+		tokens := make([]Token, len(tokenStrings))
+		for idx, tokenString := range tokenStrings {
+			tokens[idx], err = Parse(tokenString, &testEngine{}, options)
+			assert.Nil(t, err)
+		}
+		return tokens
+	}
+
+	tests := []struct {
+		selector string
+		expected interface{}
+	}{
+		{
+			selector: "$",
+			expected: json.RawMessage(data),
+		},
+		{
+			selector: "$.a.e",
+			expected: map[string]interface{}{
+				"a": map[string]interface{}{"e": json.RawMessage(`"f"`)},
+			},
+		},
+		{
+			selector: "$.a.b[1].c",
+			expected: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": []interface{}{nil, map[string]interface{}{"c": json.RawMessage(`2`)}, nil},
+				},
+			},
+		},
+		{
+			selector: "$.a.b[-1]",
+			expected: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": []interface{}{nil, nil, json.RawMessage(`[4, 5]`)},
+				},
+			},
+		},
+		{
+			selector: "$.*.e",
+			expected: map[string]interface{}{
+				"a":  map[string]interface{}{"e": json.RawMessage(`"f"`)},
+				"g":  []interface{}{nil, nil, nil, nil},
+				"hi": true,
+			},
+		},
+		{
+			selector: "$.g[0].e",
+			expected: map[string]interface{}{
+				"g": []interface{}{float64(6), nil, nil, nil},
+			},
+		},
+		{
+			selector: "$.a.b[?(@.c)]",
+			expected: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": []interface{}{float64(1), map[string]interface{}{"c": float64(2), "d": float64(3)}, []interface{}{float64(4), float64(5)}},
+				},
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, err := DecodeBytes([]byte(data), parse(test.selector, nil), nil)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("no tokens", func(t *testing.T) {
+		actual, err := DecodeBytes([]byte(`[1]`), nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{float64(1)}, actual)
+	})
+	t.Run("root reference", func(t *testing.T) {
+		actual, err := DecodeBytes([]byte(`{"a":[1],"b":1}`), parse("$.a[?(@ == $.b)]", nil), nil)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"a": []interface{}{float64(1)}, "b": float64(1)}, actual)
+	})
+	t.Run("use number", func(t *testing.T) {
+		options := &option.QueryOptions{UseNumber: true}
+		actual, err := DecodeBytes([]byte(`{"a":[{"id":9007199254740993}],"b":1}`), parse("$.a[?(@.id > 1)]", nil), options)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"a": []interface{}{map[string]interface{}{"id": json.Number("9007199254740993")}}}, actual)
+
+		actual, err = DecodeBytes([]byte(`{"a":1.50}`), nil, options)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"a": json.Number("1.50")}, actual)
+
+		actual, err = DecodeBytes([]byte(`{"a":1} {}`), nil, options)
+		assert.NotNil(t, err)
+		assert.Nil(t, actual)
+	})
+	t.Run("preserve key order", func(t *testing.T) {
+		options := &option.QueryOptions{PreserveKeyOrder: true}
+		actual, err := DecodeBytes([]byte(`{"b":{"y":1,"x":2},"a":[{"d":1,"c":2}],"z":true}`), parse("$.*[?(@ > 1)]", nil), options)
+		assert.Nil(t, err)
+		assert.Equal(t, ordered.NewObject(
+			ordered.Member{Key: "b", Value: ordered.NewObject(
+				ordered.Member{Key: "y", Value: float64(1)},
+				ordered.Member{Key: "x", Value: float64(2)},
+			)},
+			ordered.Member{Key: "a", Value: []interface{}{
+				ordered.NewObject(ordered.Member{Key: "d", Value: float64(1)}, ordered.Member{Key: "c", Value: float64(2)}),
+			}},
+			ordered.Member{Key: "z", Value: true},
+		), actual)
+
+		actual, err = DecodeBytes([]byte(`{"b":1,"a":{"d":1,"c":2}}`), parse("$.a", nil), options)
+		assert.Nil(t, err)
+		assert.Equal(t, ordered.NewObject(ordered.Member{Key: "a", Value: json.RawMessage(`{"d":1,"c":2}`)}), actual)
+	})
+
+	errorTests := []string{
+		``,
+		`{"a":`,
+		`{"a":[1,`,
+		`{"b":[1,`,
+		`{"a":[1] "b":2}`,
+		`{"a":[1 2]}`,
+		`{"b":[1 2], "a":[1]}`,
+		`{"b":tru, "a":[1]}`,
+		`{"b":01, "a":[1]}`,
+		`{"b":-, "a":[1]}`,
+		`{"b":1., "a":[1]}`,
+		`{"b":1e, "a":[1]}`,
+		`{"b":"\x", "a":[1]}`,
+		`{"b":"\u00g0", "a":[1]}`,
+		"{\"b\":\"\n\", \"a\":[1]}",
+		`{"b":x, "a":[1]}`,
+		`{b:1, "a":[1]}`,
+		`{"a":[1]} {}`,
+		`{"a\x":[1]}`,
+	}
+	for idx, test := range errorTests {
+		t.Run(fmt.Sprintf("error %d", idx), func(t *testing.T) {
+			var value interface{}
+			expected := json.Unmarshal([]byte(test), &value)
+			assert.NotNil(t, expected)
+
+			actual, err := DecodeBytes([]byte(test), parse("$.a[0]", nil), nil)
+			assert.EqualError(t, err, expected.Error())
+			assert.Nil(t, actual)
+		})
+	}
+}
+
+func Test_RawValue(t *testing.T) {
+
+	data := []byte(`{"a": {"b": [1, {"c": 2}, [4, 5]], "e": "f", "e": "g"}, "hi": true}`)
+
+	tests := []struct {
+		path     Path
+		expected string
+		found    bool
+	}{
+		{path: Path{}, expected: string(data), found: true},
+		{path: Path{"a", "b", 2}, expected: `[4, 5]`, found: true},
+		{path: Path{"a", "b", 1, "c"}, expected: `2`, found: true},
+		{path: Path{"a", "e"}, expected: `"g"`, found: true},
+		{path: Path{"hi"}, expected: `true`, found: true},
+		{path: Path{"a", "b", 3}},
+		{path: Path{"a", "missing"}},
+		{path: Path{"a", 0}},
+		{path: Path{"a", "b", "c"}},
+		{path: Path{"a", "e", 0}},
+		{path: Path{int64(0)}},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, found := RawValue(data, test.path)
+			assert.Equal(t, test.found, found)
+			if test.found {
+				assert.Equal(t, test.expected, string(actual))
+			} else {
+				assert.Nil(t, actual)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		actual, found := RawValue([]byte(`{"a":[1,}`), Path{"a"})
+		assert.False(t, found)
+		assert.Nil(t, actual)
+	})
+}
+
+func Test_RawValues(t *testing.T) {
+
+	data := []byte(`{"a": {"b": [1, {"c": 2}, [4, 5]], "e": {"x": 1}, "e": "g"}, "hi": true}`)
+
+	tests := []struct {
+		paths    []Path
+		expected []json.RawMessage
+	}{
+		{
+			paths:    []Path{},
+			expected: []json.RawMessage{},
+		},
+		{
+			paths: []Path{
+				{"a", "b"},
+				{"a", "b", 0},
+				{"a", "b", 2, 1},
+				{"hi"},
+				{"a", "b", 0},
+			},
+			expected: []json.RawMessage{
+				json.RawMessage(`[1, {"c": 2}, [4, 5]]`),
+				json.RawMessage(`1`),
+				json.RawMessage(`5`),
+				json.RawMessage(`true`),
+				json.RawMessage(`1`),
+			},
+		},
+		{
+			paths: []Path{
+				{"a", "e", "x"},
+				{"a", "e"},
+				{"a", "missing"},
+				{"a", int64(1)},
+				{},
+			},
+			expected: []json.RawMessage{
+				nil,
+				json.RawMessage(`"g"`),
+				nil,
+				nil,
+				json.RawMessage(data),
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual := RawValues(data, test.paths)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
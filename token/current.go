@@ -1,5 +1,7 @@
 package token
 
+import "context"
+
 func newCurrentToken() *currentToken {
 	return &currentToken{}
 }
@@ -15,9 +17,10 @@ func (token *currentToken) Type() string {
 	return "current"
 }
 
-func (token *currentToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
-	if len(next) > 0 {
-		return next[0].Apply(root, current, next[1:])
-	}
-	return current, nil
+func (token *currentToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	return applyNext(ctx, root, current, next, "")
+}
+
+func (token *currentToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	return applyNodesNext(ctx, root, current, next)
 }
@@ -60,3 +60,32 @@ func Test_CurrentToken_Apply(t *testing.T) {
 func Benchmark_CurrentToken_Apply(b *testing.B) {
 	batchTokenBenchmarks(b, currentTests)
 }
+
+func Test_CurrentToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &currentToken{},
+			input: nodesInput{
+				root:    "root",
+				current: &Node{Path: Path{"key"}, Value: "current"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['key']"},
+				values: []interface{}{"current"},
+			},
+		},
+		{
+			token: &currentToken{},
+			input: nodesInput{
+				current: &Node{Path: Path{"key"}, Value: []interface{}{"one", "two"}},
+				tokens: []Token{
+					&indexToken{index: 1},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['key'][1]"},
+				values: []interface{}{"two"},
+			},
+		},
+	})
+}
@@ -0,0 +1,88 @@
+package token
+
+import (
+	"reflect"
+)
+
+// Delete will remove the map entry or slice element at the path location within the root.
+//
+// Slices are reallocated when an element is removed, and arrays and structs that can not be addressed
+// are copied, so the returned root should be used in place of the original. Deleting the root will return nil.
+func (path Path) Delete(root interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	parent := path[:len(path)-1]
+	element := path[len(path)-1]
+
+	updated, err := modifyValue(reflect.ValueOf(root), Path{}, parent, func(current reflect.Value, location Path) (reflect.Value, error) {
+		return deleteValue(current, location, element)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated.Interface(), nil
+}
+
+func deleteValue(current reflect.Value, location Path, element interface{}) (reflect.Value, error) {
+	if object, ok := getOrderedObjectValue(current); ok {
+		name, ok := element.(string)
+		if !ok {
+			return reflect.Value{}, getInvalidPathTargetError(location, reflect.Map)
+		}
+		if !object.Delete(name) {
+			return reflect.Value{}, getInvalidPathKeyNotFoundError(location, name)
+		}
+		return current, nil
+	}
+
+	switch current.Kind() {
+	case reflect.Interface:
+		if current.IsNil() {
+			return reflect.Value{}, getInvalidPathTargetError(location, reflect.Invalid)
+		}
+		return deleteValue(current.Elem(), location, element)
+	case reflect.Ptr:
+		if current.IsNil() {
+			return reflect.Value{}, getInvalidPathTargetError(location, reflect.Invalid)
+		}
+		target := current.Elem()
+		updated, err := deleteValue(target, location, element)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if err := assignValue(target, updated, location); err != nil {
+			return reflect.Value{}, err
+		}
+		return current, nil
+	case reflect.Map:
+		name, ok := element.(string)
+		if !ok {
+			return reflect.Value{}, getInvalidPathTargetError(location, current.Kind())
+		}
+		key, ok := findMapKey(current, name)
+		if !ok {
+			return reflect.Value{}, getInvalidPathKeyNotFoundError(location, name)
+		}
+		current.SetMapIndex(key, reflect.Value{})
+		return current, nil
+	case reflect.Slice:
+		index, ok := element.(int)
+		if !ok {
+			return reflect.Value{}, getInvalidPathTargetError(location, current.Kind())
+		}
+		length := current.Len()
+		if index < 0 || index >= length {
+			return reflect.Value{}, getInvalidPathOutOfRangeError(location, index)
+		}
+
+		updated := reflect.MakeSlice(current.Type(), 0, length-1)
+		updated = reflect.AppendSlice(updated, current.Slice(0, index))
+		updated = reflect.AppendSlice(updated, current.Slice(index+1, length))
+		return updated, nil
+	default:
+		// arrays, structs, and strings have a fixed set of members that can not be removed
+		return reflect.Value{}, getInvalidPathTargetError(location, current.Kind())
+	}
+}
@@ -0,0 +1,182 @@
+package token
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Path_Delete(t *testing.T) {
+
+	type input struct {
+		path Path
+		root interface{}
+	}
+
+	type expected struct {
+		value interface{}
+		err   string
+	}
+
+	tests := []struct {
+		input    input
+		expected expected
+	}{
+		{
+			input: input{
+				path: Path{},
+				root: map[string]interface{}{},
+			},
+			expected: expected{
+				value: nil,
+			},
+		},
+		{
+			input: input{
+				path: Path{"key"},
+				root: map[string]interface{}{"key": 1, "other": 2},
+			},
+			expected: expected{
+				value: map[string]interface{}{"other": 2},
+			},
+		},
+		{
+			input: input{
+				path: Path{1},
+				root: []interface{}{"one", "two", "three"},
+			},
+			expected: expected{
+				value: []interface{}{"one", "three"},
+			},
+		},
+		{
+			input: input{
+				path: Path{"key", 0},
+				root: map[string]interface{}{"key": []string{"one", "two"}},
+			},
+			expected: expected{
+				value: map[string]interface{}{"key": []string{"two"}},
+			},
+		},
+		{
+			input: input{
+				path: Path{"values", 0},
+				root: &updateStruct{Values: []interface{}{"one", "two"}},
+			},
+			expected: expected{
+				value: &updateStruct{Values: []interface{}{"two"}},
+			},
+		},
+		{
+			input: input{
+				path: Path{"nested"},
+				root: &updateStruct{Map: map[string]interface{}{}},
+			},
+			expected: expected{
+				err: "invalid path $. unexpected target [struct]",
+			},
+		},
+		{
+			input: input{
+				path: Path{"map", "key"},
+				root: &updateStruct{Map: map[string]interface{}{"key": 1}},
+			},
+			expected: expected{
+				value: &updateStruct{Map: map[string]interface{}{}},
+			},
+		},
+		{
+			input: input{
+				path: Path{0},
+				root: [2]string{"one", "two"},
+			},
+			expected: expected{
+				err: "invalid path $. unexpected target [array]",
+			},
+		},
+		{
+			input: input{
+				path: Path{"missing"},
+				root: map[string]interface{}{},
+			},
+			expected: expected{
+				err: "invalid path $. key 'missing' not found",
+			},
+		},
+		{
+			input: input{
+				path: Path{5},
+				root: []interface{}{},
+			},
+			expected: expected{
+				err: "invalid path $. index 5 out of range",
+			},
+		},
+		{
+			input: input{
+				path: Path{"key", "child"},
+				root: map[string]interface{}{"key": nil},
+			},
+			expected: expected{
+				err: "invalid path $['key']. unexpected target [invalid]",
+			},
+		},
+		{
+			input: input{
+				path: Path{"0"},
+				root: []interface{}{1},
+			},
+			expected: expected{
+				err: "invalid path $. unexpected target [slice]",
+			},
+		},
+		{
+			input: input{
+				path: Path{"b"},
+				root: ordered.NewObject(
+					ordered.Member{Key: "c", Value: 1},
+					ordered.Member{Key: "b", Value: 2},
+					ordered.Member{Key: "a", Value: 3},
+				),
+			},
+			expected: expected{
+				value: ordered.NewObject(
+					ordered.Member{Key: "c", Value: 1},
+					ordered.Member{Key: "a", Value: 3},
+				),
+			},
+		},
+		{
+			input: input{
+				path: Path{"missing"},
+				root: ordered.NewObject(ordered.Member{Key: "a", Value: 1}),
+			},
+			expected: expected{
+				err: "invalid path $. key 'missing' not found",
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, err := test.input.path.Delete(test.input.root)
+			if test.expected.err != "" {
+				assert.EqualError(t, err, test.expected.err)
+				assert.Nil(t, actual)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected.value, actual)
+		})
+	}
+
+	t.Run("original slice unchanged", func(t *testing.T) {
+		original := []interface{}{"one", "two", "three"}
+		actual, err := Path{0}.Delete(original)
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"two", "three"}, actual)
+		assert.Equal(t, []interface{}{"one", "two", "three"}, original)
+	})
+}
@@ -1,9 +1,11 @@
 package token
 
 import (
+	"context"
 	goErr "errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/evilmonkeyinc/jsonpath/errors"
 )
@@ -14,21 +16,56 @@ func isInvalidTokenTargetError(err error) bool {
 	return goErr.Is(err, errors.ErrInvalidTokenTarget)
 }
 
+func isKeyNotFoundError(err error) bool {
+	return goErr.Is(err, errors.ErrInvalidTokenKeyNotFound)
+}
+
+func isOutOfRangeError(err error) bool {
+	return goErr.Is(err, errors.ErrInvalidTokenOutOfRange)
+}
+
+func isLimitExceededError(err error) bool {
+	return goErr.Is(err, errors.ErrLimitExceeded)
+}
+
 func getInvalidExpressionEmptyError() error {
 	return fmt.Errorf("%w. is empty", errors.ErrInvalidExpression)
 }
 
 func getInvalidExpressionError(reason error) error {
-	if goErr.Is(reason, errors.ErrInvalidExpression) {
+	if goErr.Is(reason, errors.ErrInvalidExpression) || goErr.Is(reason, errors.ErrLimitExceeded) {
 		return reason
 	}
-	return fmt.Errorf("%w. %s", errors.ErrInvalidExpression, reason.Error())
+	return wrapSyntaxError(reason, fmt.Errorf("%w. %s", errors.ErrInvalidExpression, reason.Error()))
 }
 
 func getInvalidExpressionFormatError(format string) error {
 	return fmt.Errorf("%w. invalid format '%s'", errors.ErrInvalidExpression, format)
 }
 
+func getInvalidPathKeyNotFoundError(path Path, key string) error {
+	return fmt.Errorf("%w %s. key '%s' not found", errors.ErrInvalidPath, path, key)
+}
+
+func getInvalidPathNotSettableError(path Path) error {
+	return fmt.Errorf("%w %s. value can not be set", errors.ErrInvalidPath, path)
+}
+
+func getInvalidPathOutOfRangeError(path Path, index int) error {
+	return fmt.Errorf("%w %s. index %d out of range", errors.ErrInvalidPath, path, index)
+}
+
+func getInvalidPathTargetError(path Path, got reflect.Kind) error {
+	return fmt.Errorf("%w %s. unexpected target [%v]", errors.ErrInvalidPath, path, got)
+}
+
+func getInvalidPathValueError(path Path, got, expected reflect.Type) error {
+	if got == nil {
+		return fmt.Errorf("%w %s. can not use value of type [nil] as [%v]", errors.ErrInvalidPath, path, expected)
+	}
+	return fmt.Errorf("%w %s. can not use value of type [%v] as [%v]", errors.ErrInvalidPath, path, got, expected)
+}
+
 func getInvalidTokenArgumentError(tokenType string, got reflect.Kind, expected ...reflect.Kind) error {
 	return fmt.Errorf("%s: %w argument. expected %v got [%v]", tokenType, errors.ErrInvalidToken, expected, got)
 }
@@ -45,7 +82,7 @@ func getInvalidTokenError(tokenType string, reason error) error {
 	if goErr.Is(reason, errors.ErrInvalidToken) {
 		return reason
 	}
-	return fmt.Errorf("%s: %w %s", tokenType, errors.ErrInvalidToken, reason.Error())
+	return wrapSyntaxError(reason, fmt.Errorf("%s: %w %s", tokenType, errors.ErrInvalidToken, reason.Error()))
 }
 
 func getInvalidTokenFormatError(tokenString string) error {
@@ -53,11 +90,11 @@ func getInvalidTokenFormatError(tokenString string) error {
 }
 
 func getInvalidTokenKeyNotFoundError(tokenType, key string) error {
-	return fmt.Errorf("%s: %w key '%s' not found", tokenType, errors.ErrInvalidToken, key)
+	return fmt.Errorf("%s: %w '%s' not found", tokenType, errors.ErrInvalidTokenKeyNotFound, key)
 }
 
 func getInvalidTokenOutOfRangeError(tokenType string) error {
-	return fmt.Errorf("%s: %w out of range", tokenType, errors.ErrInvalidToken)
+	return fmt.Errorf("%s: %w", tokenType, errors.ErrInvalidTokenOutOfRange)
 }
 
 func getInvalidTokenTargetError(tokenType string, got reflect.Kind, expected ...reflect.Kind) error {
@@ -68,6 +105,10 @@ func getInvalidTokenTargetNilError(tokenType string, expected ...reflect.Kind) e
 	return fmt.Errorf("%s: %w. expected %v got [nil]", tokenType, errors.ErrInvalidTokenTarget, expected)
 }
 
+func getLimitExceededError(limit string, maximum int) error {
+	return fmt.Errorf("%w. %s exceeds maximum of %d", errors.ErrLimitExceeded, limit, maximum)
+}
+
 func getUnexpectedExpressionResultError(got reflect.Kind, expected ...reflect.Kind) error {
 	return fmt.Errorf("%w. expected %v got [%v]", errors.ErrUnexpectedExpressionResult, expected, got)
 }
@@ -79,3 +120,92 @@ func getUnexpectedExpressionResultNilError(expected ...reflect.Kind) error {
 func getUnexpectedTokenError(tokenType string, index int) error {
 	return fmt.Errorf("%w '%s' at index %d", errors.ErrUnexpectedToken, tokenType, index)
 }
+
+// NewSyntaxError returns the reason as a syntax error at the offset within the source
+func NewSyntaxError(reason error, source string, offset int, token string, expected ...string) error {
+	return &errors.SyntaxError{
+		Err:      reason,
+		Selector: source,
+		Offset:   offset,
+		Token:    token,
+		Expected: expected,
+	}
+}
+
+// RelocateSyntaxError returns the error as a syntax error positioned within the source. Syntax errors of a part of
+// the source, found at or after the offset, are moved to the position of that part, and other errors are positioned at the offset.
+func RelocateSyntaxError(err error, source string, offset int) error {
+	if isLimitExceededError(err) {
+		return err
+	}
+	if offset > len(source) {
+		offset = len(source)
+	}
+	syntaxError, ok := err.(*errors.SyntaxError)
+	if !ok {
+		return NewSyntaxError(err, source, offset, source[offset:])
+	}
+	if syntaxError.Selector == source {
+		return err
+	}
+
+	relocated := *syntaxError
+	relocated.Selector = source
+	if idx := strings.Index(source[offset:], syntaxError.Selector); idx >= 0 {
+		relocated.Offset += offset + idx
+	} else {
+		relocated.Offset = offset
+	}
+	return &relocated
+}
+
+// wrapSyntaxError returns the error with the position of the reason, if the reason is a syntax error
+func wrapSyntaxError(reason error, err error) error {
+	syntaxError, ok := reason.(*errors.SyntaxError)
+	if !ok {
+		return err
+	}
+	wrapped := *syntaxError
+	wrapped.Err = err
+	return &wrapped
+}
+
+// getQueryError returns the reason as a query error raised by the token applied to the value at the path.
+// Errors of the context, limit errors, and errors that already describe where the query failed, or where
+// the selectors of a set failed, are returned unchanged.
+func getQueryError(reason error, path string, token Token, value interface{}) error {
+	if goErr.Is(reason, context.Canceled) || goErr.Is(reason, context.DeadlineExceeded) || isLimitExceededError(reason) {
+		return reason
+	}
+	switch reason.(type) {
+	case *errors.QueryError, *setError:
+		return reason
+	}
+	return newQueryError(reason, path, token, value)
+}
+
+// newQueryError returns a query error for the reason the token failed when applied to the value at the path
+func newQueryError(reason error, path string, token Token, value interface{}) *errors.QueryError {
+	kind := reflect.Invalid
+	if valueType, _ := getTypeAndValue(value); valueType != nil {
+		kind = valueType.Kind()
+	}
+	return &errors.QueryError{
+		Err:       reason,
+		Path:      path,
+		TokenType: token.Type(),
+		Token:     token.String(),
+		Kind:      kind,
+	}
+}
+
+// prependQueryErrorPath returns the query error with the segment added to the start of its path
+func prependQueryErrorPath(err error, segment string) error {
+	queryError, ok := err.(*errors.QueryError)
+	if !ok || segment == "" {
+		return err
+	}
+	prepended := *queryError
+	prepended.Path = "$" + segment + strings.TrimPrefix(queryError.Path, "$")
+	return &prepended
+}
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"context"
 	goErr "errors"
 	"fmt"
 	"reflect"
@@ -23,6 +24,12 @@ func Test_error(t *testing.T) {
 		assert.EqualError(t, actual, "invalid token. token string is empty")
 		assert.True(t, goErr.Is(actual, errors.ErrInvalidToken))
 	})
+
+	t.Run("getLimitExceededError", func(t *testing.T) {
+		actual := getLimitExceededError("token count", 8)
+		assert.EqualError(t, actual, "limit exceeded. token count exceeds maximum of 8")
+		assert.True(t, goErr.Is(actual, errors.ErrLimitExceeded))
+	})
 }
 
 func Test_isInvalidTokenTargetError(t *testing.T) {
@@ -52,6 +59,168 @@ func Test_isInvalidTokenTargetError(t *testing.T) {
 	}
 }
 
+func Test_isKeyNotFoundError(t *testing.T) {
+	assert.False(t, isKeyNotFoundError(nil))
+	assert.False(t, isKeyNotFoundError(getInvalidTokenOutOfRangeError("index")))
+	assert.True(t, isKeyNotFoundError(getInvalidTokenKeyNotFoundError("key", "a")))
+	assert.True(t, isKeyNotFoundError(getQueryError(getInvalidTokenKeyNotFoundError("key", "a"), "$", &keyToken{key: "a"}, nil)))
+}
+
+func Test_isOutOfRangeError(t *testing.T) {
+	assert.False(t, isOutOfRangeError(nil))
+	assert.False(t, isOutOfRangeError(getInvalidTokenKeyNotFoundError("key", "a")))
+	assert.True(t, isOutOfRangeError(getInvalidTokenOutOfRangeError("index")))
+}
+
+func Test_isLimitExceededError(t *testing.T) {
+	assert.False(t, isLimitExceededError(nil))
+	assert.False(t, isLimitExceededError(fmt.Errorf("limit exceeded")))
+	assert.True(t, isLimitExceededError(getLimitExceededError("token count", 1)))
+}
+
+func Test_RelocateSyntaxError(t *testing.T) {
+
+	tests := []struct {
+		err      error
+		source   string
+		offset   int
+		expected error
+	}{
+		{
+			err:      getLimitExceededError("token count", 1),
+			source:   "$.a",
+			offset:   1,
+			expected: getLimitExceededError("token count", 1),
+		},
+		{
+			err:      fmt.Errorf("fail"),
+			source:   "$.a.b",
+			offset:   3,
+			expected: NewSyntaxError(fmt.Errorf("fail"), "$.a.b", 3, ".b"),
+		},
+		{
+			err:      fmt.Errorf("fail"),
+			source:   "$.a",
+			offset:   10,
+			expected: NewSyntaxError(fmt.Errorf("fail"), "$.a", 3, ""),
+		},
+		{
+			err:      NewSyntaxError(fmt.Errorf("fail"), "$.a", 2, "a"),
+			source:   "$.a",
+			offset:   0,
+			expected: NewSyntaxError(fmt.Errorf("fail"), "$.a", 2, "a"),
+		},
+		{
+			err:      NewSyntaxError(fmt.Errorf("fail"), "[1:x]", 3, "x", "]"),
+			source:   "$.a[1:x]",
+			offset:   1,
+			expected: NewSyntaxError(fmt.Errorf("fail"), "$.a[1:x]", 6, "x", "]"),
+		},
+		{
+			err:      NewSyntaxError(fmt.Errorf("fail"), "other", 3, "x"),
+			source:   "$.a[1:x]",
+			offset:   3,
+			expected: NewSyntaxError(fmt.Errorf("fail"), "$.a[1:x]", 3, "x"),
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual := RelocateSyntaxError(test.err, test.source, test.offset)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_wrapSyntaxError(t *testing.T) {
+	reason := NewSyntaxError(fmt.Errorf("fail"), "$.a", 2, "a")
+	actual := wrapSyntaxError(reason, fmt.Errorf("wrapped. fail"))
+	assert.Equal(t, NewSyntaxError(fmt.Errorf("wrapped. fail"), "$.a", 2, "a"), actual)
+
+	actual = wrapSyntaxError(fmt.Errorf("fail"), fmt.Errorf("wrapped. fail"))
+	assert.Equal(t, fmt.Errorf("wrapped. fail"), actual)
+}
+
+func Test_getQueryError(t *testing.T) {
+
+	tests := []struct {
+		reason   error
+		path     string
+		token    Token
+		value    interface{}
+		expected error
+	}{
+		{
+			reason:   context.Canceled,
+			path:     "$",
+			token:    &keyToken{key: "a"},
+			expected: context.Canceled,
+		},
+		{
+			reason:   getLimitExceededError("results", 1),
+			path:     "$",
+			token:    &keyToken{key: "a"},
+			expected: getLimitExceededError("results", 1),
+		},
+		{
+			reason:   &errors.QueryError{Err: fmt.Errorf("fail"), Path: "$['b']"},
+			path:     "$",
+			token:    &keyToken{key: "a"},
+			expected: &errors.QueryError{Err: fmt.Errorf("fail"), Path: "$['b']"},
+		},
+		{
+			reason:   &setError{errors: map[int]error{0: fmt.Errorf("fail")}},
+			path:     "$",
+			token:    &keyToken{key: "a"},
+			expected: &setError{errors: map[int]error{0: fmt.Errorf("fail")}},
+		},
+		{
+			reason: getInvalidTokenKeyNotFoundError("key", "a"),
+			path:   "$['b']",
+			token:  &keyToken{key: "a"},
+			value:  map[string]interface{}{},
+			expected: &errors.QueryError{
+				Err:       getInvalidTokenKeyNotFoundError("key", "a"),
+				Path:      "$['b']",
+				TokenType: "key",
+				Token:     "['a']",
+				Kind:      reflect.Map,
+			},
+		},
+		{
+			reason: getInvalidTokenTargetNilError("index", reflect.Slice),
+			path:   "$",
+			token:  &indexToken{index: 1},
+			value:  nil,
+			expected: &errors.QueryError{
+				Err:       getInvalidTokenTargetNilError("index", reflect.Slice),
+				Path:      "$",
+				TokenType: "index",
+				Token:     "[1]",
+				Kind:      reflect.Invalid,
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual := getQueryError(test.reason, test.path, test.token, test.value)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_prependQueryErrorPath(t *testing.T) {
+	actual := prependQueryErrorPath(&errors.QueryError{Err: fmt.Errorf("fail"), Path: "$[1]"}, "['a']")
+	assert.Equal(t, &errors.QueryError{Err: fmt.Errorf("fail"), Path: "$['a'][1]"}, actual)
+
+	actual = prependQueryErrorPath(&errors.QueryError{Err: fmt.Errorf("fail"), Path: "$[1]"}, "")
+	assert.Equal(t, &errors.QueryError{Err: fmt.Errorf("fail"), Path: "$[1]"}, actual)
+
+	actual = prependQueryErrorPath(fmt.Errorf("fail"), "['a']")
+	assert.Equal(t, fmt.Errorf("fail"), actual)
+}
+
 func Test_getInvalidExpressionError(t *testing.T) {
 
 	tests := []struct {
@@ -79,6 +248,12 @@ func Test_getInvalidExpressionError(t *testing.T) {
 			assert.True(t, goErr.Is(actual, errors.ErrInvalidExpression))
 		})
 	}
+
+	t.Run("limit exceeded", func(t *testing.T) {
+		reason := getLimitExceededError("expression depth", 2)
+		actual := getInvalidExpressionError(reason)
+		assert.Equal(t, reason, actual)
+	})
 }
 
 func Test_getInvalidExpressionFormatError(t *testing.T) {
@@ -301,6 +476,7 @@ func Test_getInvalidTokenKeyNotFoundError(t *testing.T) {
 			actual := getInvalidTokenKeyNotFoundError(test.input.tokenType, test.input.key)
 			assert.EqualError(t, actual, test.expected)
 			assert.True(t, goErr.Is(actual, errors.ErrInvalidToken))
+			assert.True(t, goErr.Is(actual, errors.ErrInvalidTokenKeyNotFound))
 		})
 	}
 }
@@ -330,6 +506,7 @@ func Test_getInvalidTokenOutOfRangeError(t *testing.T) {
 			actual := getInvalidTokenOutOfRangeError(test.input)
 			assert.EqualError(t, actual, test.expected)
 			assert.True(t, goErr.Is(actual, errors.ErrInvalidToken))
+			assert.True(t, goErr.Is(actual, errors.ErrInvalidTokenOutOfRange))
 		})
 	}
 }
@@ -576,3 +753,43 @@ func Test_getUnexpectedTokenError(t *testing.T) {
 		})
 	}
 }
+
+func Test_getInvalidPathErrors(t *testing.T) {
+
+	tests := []struct {
+		input    error
+		expected string
+	}{
+		{
+			input:    getInvalidPathKeyNotFoundError(Path{"one"}, "two"),
+			expected: "invalid path $['one']. key 'two' not found",
+		},
+		{
+			input:    getInvalidPathNotSettableError(Path{"one"}),
+			expected: "invalid path $['one']. value can not be set",
+		},
+		{
+			input:    getInvalidPathOutOfRangeError(Path{}, 2),
+			expected: "invalid path $. index 2 out of range",
+		},
+		{
+			input:    getInvalidPathTargetError(Path{0}, reflect.String),
+			expected: "invalid path $[0]. unexpected target [string]",
+		},
+		{
+			input:    getInvalidPathValueError(Path{0}, reflect.TypeOf(""), reflect.TypeOf(0)),
+			expected: "invalid path $[0]. can not use value of type [string] as [int]",
+		},
+		{
+			input:    getInvalidPathValueError(Path{0}, nil, reflect.TypeOf(0)),
+			expected: "invalid path $[0]. can not use value of type [nil] as [int]",
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.EqualError(t, test.input, test.expected)
+			assert.True(t, goErr.Is(test.input, errors.ErrInvalidPath))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/evilmonkeyinc/jsonpath/option"
@@ -34,19 +35,33 @@ func (token *expressionToken) Type() string {
 	return "expression"
 }
 
-func (token *expressionToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
-	if token.expression == "" {
-		return nil, getInvalidExpressionEmptyError()
+func (token *expressionToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	value, err := token.evaluate(ctx, root, current)
+	if err != nil {
+		return nil, err
 	}
 
-	value, err := token.compiledExpression.Evaluate(root, current)
+	return applyNext(ctx, root, value, next, "")
+}
+
+func (token *expressionToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	value, err := token.evaluate(ctx, root, current.Value)
 	if err != nil {
-		return nil, getInvalidExpressionError(err)
+		return nil, err
 	}
+	// the result of an expression is not part of the queried data
+	// so it is given the location of the node it was evaluated against
+	return applyNodesNext(ctx, root, &Node{Path: current.Path, Value: value}, next)
+}
 
-	if len(next) > 0 {
-		return next[0].Apply(root, value, next[1:])
+func (token *expressionToken) evaluate(ctx context.Context, root, current interface{}) (interface{}, error) {
+	if token.expression == "" {
+		return nil, getInvalidExpressionEmptyError()
 	}
 
+	value, err := evaluateExpression(ctx, token.compiledExpression, root, current)
+	if err != nil {
+		return nil, getInvalidExpressionError(err)
+	}
 	return value, nil
 }
@@ -121,3 +121,38 @@ func Test_ExpressionToken_Apply(t *testing.T) {
 func Benchmark_ExpressionToken_Apply(b *testing.B) {
 	batchTokenBenchmarks(b, expressionTests)
 }
+
+func Test_ExpressionToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &expressionToken{},
+			input: nodesInput{},
+			expected: nodesExpected{
+				err: "invalid expression. is empty",
+			},
+		},
+		{
+			token: &expressionToken{
+				expression:         "failed",
+				compiledExpression: &testCompiledExpression{err: fmt.Errorf("engine error")},
+			},
+			input: nodesInput{},
+			expected: nodesExpected{
+				err: "invalid expression. engine error",
+			},
+		},
+		{
+			token: &expressionToken{
+				expression:         "value",
+				compiledExpression: &testCompiledExpression{response: int64(3)},
+			},
+			input: nodesInput{
+				current: &Node{Path: Path{"key"}, Value: "current"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['key']"},
+				values: []interface{}{int64(3)},
+			},
+		},
+	})
+}
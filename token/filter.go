@@ -1,6 +1,7 @@
 package token
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -35,9 +36,64 @@ func (token *filterToken) Type() string {
 	return "filter"
 }
 
-func (token *filterToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
+func (token *filterToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	keys, elements, err := token.getElements(ctx, root, current, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(next) > 0 {
+		if _, ok := next[0].(*indexToken); ok {
+			// if next is asking for specific index
+			return applyNext(ctx, current, elements, next, token.String())
+		}
+		// any other token type
+		results := make([]interface{}, 0)
+		for idx, element := range elements {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			result, add, err := token.handleNext(ctx, root, keys[idx], element, next)
+			if err != nil {
+				return nil, err
+			}
+			if add {
+				results = append(results, result)
+			}
+		}
+		return results, nil
+	}
+	return elements, nil
+}
+
+func (token *filterToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	keys, values, err := token.getElements(ctx, root, current.Value, current.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([]*Node, len(values))
+	for idx, value := range values {
+		elements[idx] = current.child(keys[idx], value)
+	}
+
+	if len(next) > 0 {
+		if indexToken, ok := next[0].(*indexToken); ok {
+			// if next is asking for specific index
+			return traceNodes(ctx, next, len(elements), func() ([]*Node, error) {
+				return indexToken.applyToNodes(ctx, root, current.Path.String()+token.String(), elements, next[1:])
+			})
+		}
+	}
+
+	return collectElementNodes(ctx, root, elements, next, token.requireProperties())
+}
+
+// getElements returns the path elements and values of the child members that pass the filter,
+// the path of the current value is used to record the filter evaluations when the query is traced.
+func (token *filterToken) getElements(ctx context.Context, root, current interface{}, path Path) ([]interface{}, []interface{}, error) {
 	if token.expression == "" {
-		return nil, getInvalidExpressionEmptyError()
+		return nil, nil, getInvalidExpressionEmptyError()
 	}
 
 	shouldInclude := func(evaluation interface{}) bool {
@@ -45,6 +101,10 @@ func (token *filterToken) Apply(root, current interface{}, next []Token) (interf
 			return false
 		}
 
+		if object, ok := getOrderedObject(evaluation); ok {
+			return object.Len() > 0
+		}
+
 		objType, objValue := getTypeAndValue(evaluation)
 		if objType == nil {
 			return false
@@ -70,30 +130,73 @@ func (token *filterToken) Apply(root, current interface{}, next []Token) (interf
 		}
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	trace := getTrace(ctx)
+	keys := make([]interface{}, 0)
 	elements := make([]interface{}, 0)
 
 	objType, objVal := getTypeAndValue(current)
 	if objType == nil {
-		return nil, getInvalidTokenTargetNilError(token.Type(), reflect.Array, reflect.Map, reflect.Slice)
+		return nil, nil, getInvalidTokenTargetNilError(token.Type(), reflect.Array, reflect.Map, reflect.Slice)
 	}
 
-	switch objType.Kind() {
-	case reflect.Map:
-		keys := objVal.MapKeys()
-		sortMapKeys(keys)
+	// evaluate returns if the element passes the filter, or an error if the evaluation should stop
+	evaluate := func(key, element interface{}) (bool, error) {
+		evaluation, err := evaluateExpression(ctx, token.compiledExpression, root, element)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return false, ctxErr
+		}
+		if isLimitExceededError(err) {
+			return false, err
+		} else if err != nil {
+			// we ignore errors, it has failed evaluation
+			evaluation = nil
+		}
 
-		for _, kv := range keys {
-			element := objVal.MapIndex(kv).Interface()
+		included := shouldInclude(evaluation)
+		if trace != nil {
+			trace.evaluated(token, path.child(key), element, included, err)
+		}
+		return included, nil
+	}
 
-			evaluation, err := token.compiledExpression.Evaluate(root, element)
-			if err != nil {
-				// we ignore errors, it has failed evaluation
-				evaluation = nil
+	switch getKind(current, objType) {
+	case reflect.Map:
+		if _, ok := getOrderedObject(current); ok || trace != nil {
+			// the evaluations are in the order of the keys
+			mapKeys, mapValues := getMapElements(current, objVal)
+			for idx, key := range mapKeys {
+				included, err := evaluate(key, mapValues[idx])
+				if err != nil {
+					return nil, nil, err
+				}
+				if included {
+					keys = append(keys, key)
+					elements = append(elements, mapValues[idx])
+				}
 			}
+			break
+		}
 
-			if shouldInclude(evaluation) {
-				elements = append(elements, element)
+		// only the keys of the members that pass the filter are sorted, so large maps
+		// do not delay the evaluation or a cancelled context
+		included := make([]reflect.Value, 0)
+		iterator := objVal.MapRange()
+		for iterator.Next() {
+			include, err := evaluate(nil, iterator.Value().Interface())
+			if err != nil {
+				return nil, nil, err
 			}
+			if include {
+				included = append(included, iterator.Key())
+			}
+		}
+		for _, key := range sortMapKeys(included) {
+			keys = append(keys, key.name)
+			elements = append(elements, objVal.MapIndex(key.value).Interface())
 		}
 	case reflect.Array, reflect.Slice:
 		length := objVal.Len()
@@ -101,41 +204,46 @@ func (token *filterToken) Apply(root, current interface{}, next []Token) (interf
 		for i := 0; i < length; i++ {
 			element := objVal.Index(i).Interface()
 
-			evaluation, err := token.compiledExpression.Evaluate(root, element)
+			included, err := evaluate(i, element)
 			if err != nil {
-				// we ignore errors, it has failed evaluation
-				evaluation = nil
+				return nil, nil, err
 			}
-
-			if shouldInclude(evaluation) {
+			if included {
+				keys = append(keys, i)
 				elements = append(elements, element)
 			}
 		}
 	default:
-		return nil, getInvalidTokenTargetError(
+		return nil, nil, getInvalidTokenTargetError(
 			token.Type(),
 			objType.Kind(),
 			reflect.Array, reflect.Map, reflect.Slice,
 		)
 	}
 
-	if len(next) > 0 {
-		nextToken := next[0]
-		futureTokens := next[1:]
+	return keys, elements, nil
+}
 
-		if indexToken, ok := nextToken.(*indexToken); ok {
-			// if next is asking for specific index
-			return indexToken.Apply(current, elements, futureTokens)
-		}
-		// any other token type
-		results := make([]interface{}, 0)
-		for _, element := range elements {
-			result, _ := nextToken.Apply(root, element, futureTokens)
-			if result != nil {
-				results = append(results, result)
-			}
+func (token *filterToken) handleNext(ctx context.Context, root, key, item interface{}, next []Token) (interface{}, bool, error) {
+	result, err := applyNext(ctx, root, item, next, getPathSegment(key))
+	if err != nil {
+		if token.requireProperties() {
+			return nil, false, getRequiredError(err)
 		}
-		return results, nil
+		return nil, false, nil
 	}
-	return elements, nil
+	if result == nil && !token.leafToNull() {
+		return nil, false, nil
+	}
+	return result, true, nil
+}
+
+// leafToNull returns true if the nil results of the next tokens are kept
+func (token *filterToken) leafToNull() bool {
+	return token.options != nil && token.options.DefaultPathLeafToNull
+}
+
+// requireProperties returns true if the filter fails when a key that follows it is missing
+func (token *filterToken) requireProperties() bool {
+	return token.options != nil && token.options.RequireProperties
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/ordered"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -452,6 +454,83 @@ var filterTests = []*tokenTest{
 			err:   "",
 		},
 	},
+	{
+		token: &filterToken{
+			expression: "limit exceeded",
+			compiledExpression: &testCompiledExpression{
+				err: getLimitExceededError("regex complexity", 10),
+			},
+		},
+		input: input{
+			current: []interface{}{1, 2, 3},
+		},
+		expected: expected{
+			value: nil,
+			err:   "limit exceeded. regex complexity exceeds maximum of 10",
+		},
+	},
+	{
+		token: &filterToken{
+			expression:         "include all",
+			compiledExpression: &testCompiledExpression{response: true},
+			options:            &option.QueryOptions{DefaultPathLeafToNull: true},
+		},
+		input: input{
+			current: []interface{}{
+				map[string]interface{}{"key": "one"},
+				map[string]interface{}{"other": "two"},
+			},
+			tokens: []Token{&keyToken{key: "key", leafToNull: true}},
+		},
+		expected: expected{
+			value: []interface{}{"one", nil},
+		},
+	},
+	{
+		token: &filterToken{
+			expression:         "include all",
+			compiledExpression: &testCompiledExpression{response: true},
+			options:            &option.QueryOptions{RequireProperties: true},
+		},
+		input: input{
+			current: []interface{}{
+				map[string]interface{}{"key": "one"},
+				map[string]interface{}{"other": "two"},
+			},
+			tokens: []Token{&keyToken{key: "key"}},
+		},
+		expected: expected{
+			err: "key: invalid token key 'key' not found",
+		},
+	},
+	{
+		token: &filterToken{
+			expression:         "include all",
+			compiledExpression: &testCompiledExpression{response: true},
+		},
+		input: input{
+			current: ordered.NewObject(
+				ordered.Member{Key: "three", Value: 3},
+				ordered.Member{Key: "one", Value: 1},
+				ordered.Member{Key: "two", Value: 2},
+			),
+		},
+		expected: expected{
+			value: []interface{}{3, 1, 2},
+		},
+	},
+	{
+		token: &filterToken{
+			expression:         "include all",
+			compiledExpression: &testCompiledExpression{response: true},
+		},
+		input: input{
+			current: map[int]string{10: "ten", 9: "nine"},
+		},
+		expected: expected{
+			value: []interface{}{"nine", "ten"},
+		},
+	},
 }
 
 func Test_FilterToken_Apply(t *testing.T) {
@@ -461,3 +540,87 @@ func Test_FilterToken_Apply(t *testing.T) {
 func Benchmark_FilterToken_Apply(b *testing.B) {
 	batchTokenBenchmarks(b, filterTests)
 }
+
+func Test_FilterToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &filterToken{},
+			input: nodesInput{},
+			expected: nodesExpected{
+				err: "invalid expression. is empty",
+			},
+		},
+		{
+			token: &filterToken{
+				expression:         "invalid current",
+				compiledExpression: &testCompiledExpression{},
+			},
+			input: nodesInput{
+				root: "string",
+			},
+			expected: nodesExpected{
+				err: "filter: invalid token target. expected [array map slice] got [string]",
+			},
+		},
+		{
+			token: &filterToken{
+				expression:         "failed evaluation",
+				compiledExpression: &testCompiledExpression{err: fmt.Errorf("failed")},
+			},
+			input: nodesInput{
+				root: []interface{}{1, 2, 3},
+			},
+			expected: nodesExpected{
+				paths: []string{},
+			},
+		},
+		{
+			token: &filterToken{
+				expression:         "include all",
+				compiledExpression: &testCompiledExpression{response: true},
+			},
+			input: nodesInput{
+				root: map[string]interface{}{"b": 2, "a": 1},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['a']", "$['b']"},
+				values: []interface{}{1, 2},
+			},
+		},
+		{
+			token: &filterToken{
+				expression:         "include all",
+				compiledExpression: &testCompiledExpression{response: true},
+			},
+			input: nodesInput{
+				root: []interface{}{"one", "two", "three"},
+				tokens: []Token{
+					&indexToken{index: 1},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[1]"},
+				values: []interface{}{"two"},
+			},
+		},
+		{
+			token: &filterToken{
+				expression:         "include all",
+				compiledExpression: &testCompiledExpression{response: true},
+			},
+			input: nodesInput{
+				root: []interface{}{
+					map[string]interface{}{"key": "one"},
+					map[string]interface{}{"other": "two"},
+				},
+				tokens: []Token{
+					&keyToken{key: "key"},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[0]['key']"},
+				values: []interface{}{"one"},
+			},
+		},
+	})
+}
@@ -1,21 +1,57 @@
 package token
 
 import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/ordered"
+	"github.com/evilmonkeyinc/jsonpath/script"
 )
 
+// isInteger returns the value as an int64 if it is a whole number that can be represented without losing precision
 func isInteger(obj interface{}) (int64, bool) {
+	switch typed := obj.(type) {
+	case json.Number:
+		if intVal, err := typed.Int64(); err == nil {
+			return intVal, true
+		}
+		number, ok := new(big.Float).SetString(typed.String())
+		if !ok {
+			return 0, false
+		}
+		return isInteger(number)
+	case *big.Float:
+		if typed == nil || !typed.IsInt() {
+			return 0, false
+		}
+		if intVal, accuracy := typed.Int64(); accuracy == big.Exact {
+			return intVal, true
+		}
+		return 0, false
+	}
+
 	objType, objVal := getTypeAndValue(obj)
 	if objType == nil {
 		return 0, false
 	}
 
 	switch objType.Kind() {
-	case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return objVal.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if uintVal := objVal.Uint(); uintVal <= math.MaxInt64 {
+			return int64(uintVal), true
+		}
 	case reflect.Float32, reflect.Float64:
 		float := objVal.Float()
 		if trunc := math.Trunc(float); trunc == float {
@@ -26,6 +62,31 @@ func isInteger(obj interface{}) (int64, bool) {
 	return 0, false
 }
 
+// evaluateExpression returns the result of the compiled expression, the context is passed to
+// expressions that support it and is otherwise only checked before the expression is evaluated.
+// The queries evaluated by the expression are not included in the trace of the query.
+func evaluateExpression(ctx context.Context, expression script.CompiledExpression, root, current interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ctx = withoutTrace(ctx)
+	if contextual, ok := expression.(script.CompiledExpressionContext); ok {
+		return contextual.EvaluateContext(ctx, root, current)
+	}
+	return expression.Evaluate(root, current)
+}
+
+// Limits returns the resource limits of the options, which are not enforced if the options are nil
+func Limits(options *option.QueryOptions) option.Limits {
+	if options == nil {
+		return option.Limits{}
+	}
+	return options.Limits
+}
+
+// timeType the type of time.Time, which is a single value rather than a struct with fields to select
+var timeType = reflect.TypeOf(time.Time{})
+
 func getStructFields(obj reflect.Value, omitempty bool) map[string]reflect.StructField {
 	objType := obj.Type()
 	if objType.Kind() != reflect.Struct {
@@ -37,6 +98,10 @@ func getStructFields(obj reflect.Value, omitempty bool) map[string]reflect.Struc
 	for i := 0; i < objType.NumField(); i++ {
 		field := objType.Field(i)
 		fieldName := field.Name
+		if field.PkgPath != "" {
+			// unexported fields can not be read or set
+			continue
+		}
 
 		switch jsonTag := field.Tag.Get("json"); jsonTag {
 		case "-":
@@ -73,6 +138,18 @@ func getStructFields(obj reflect.Value, omitempty bool) map[string]reflect.Struc
 	return fields
 }
 
+// getStructFieldNames returns the names of the struct fields in the order they are declared
+func getStructFieldNames(fields map[string]reflect.StructField) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return fields[names[i]].Index[0] < fields[names[j]].Index[0]
+	})
+	return names
+}
+
 func getTypeAndValue(obj interface{}) (reflect.Type, reflect.Value) {
 	objType := reflect.TypeOf(obj)
 	if objType == nil {
@@ -92,11 +169,216 @@ func getTypeAndValue(obj interface{}) (reflect.Type, reflect.Value) {
 	return objType, objVal
 }
 
-func sortMapKeys(mapKeys []reflect.Value) {
-	sort.SliceStable(mapKeys, func(i, j int) bool {
-		one := mapKeys[i]
-		two := mapKeys[j]
+// mapKey is a key of a map and the string it is selected by
+type mapKey struct {
+	value  reflect.Value
+	name   string
+	number *big.Float
+}
+
+// getSortedMapKeys returns the keys of the map in sorted order, keys with a number kind are sorted by
+// their value before all other keys, which are sorted by the string they are selected by.
+func getSortedMapKeys(objVal reflect.Value) []mapKey {
+	return sortMapKeys(objVal.MapKeys())
+}
 
-		return one.String() < two.String()
+// sortMapKeys returns the map keys in the sorted order of getSortedMapKeys
+func sortMapKeys(mapKeys []reflect.Value) []mapKey {
+	keys := make([]mapKey, len(mapKeys))
+	for idx, key := range mapKeys {
+		keys[idx] = mapKey{
+			value:  key,
+			name:   MapKeyString(key),
+			number: getMapKeyNumber(key),
+		}
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		one := keys[i]
+		two := keys[j]
+
+		if one.number != nil && two.number != nil {
+			if comparison := one.number.Cmp(two.number); comparison != 0 {
+				return comparison < 0
+			}
+		} else if one.number != nil || two.number != nil {
+			return one.number != nil
+		}
+		return one.name < two.name
 	})
+	return keys
+}
+
+// MapKeyString returns the key of a map as the string it is selected by, which is the same name
+// encoding/json uses for the key. String kinds use their value, then keys that implement encoding.TextMarshaler
+// use their text, and integer kinds are formatted in base 10. Keys of kinds that encoding/json does not support
+// use their text if they implement fmt.Stringer or are otherwise formatted, and nil keys are named null.
+// The keys of maps with an interface key type, such as map[interface{}]interface{} decoded from YAML, use the value they hold.
+func MapKeyString(key reflect.Value) string {
+	if key.Kind() == reflect.Interface {
+		if key.IsNil() {
+			return "null"
+		}
+		key = key.Elem()
+	}
+	if key.Kind() == reflect.String {
+		return key.String()
+	}
+	if marshaler, ok := key.Interface().(encoding.TextMarshaler); ok {
+		if key.Kind() == reflect.Ptr && key.IsNil() {
+			return ""
+		}
+		if text, err := marshaler.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10)
+	case reflect.Ptr:
+		if key.IsNil() {
+			return "null"
+		}
+	}
+
+	// kinds that encoding/json does not support, such as the float and bool keys decoded from YAML
+	if stringer, ok := key.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("%v", key.Interface())
+}
+
+// findMapKey returns the key of the map that is selected by the name, if more than one key is selected
+// by the name, such as the keys 1 and "1" of a map[interface{}]interface{}, the first key in sorted order is returned.
+func findMapKey(mapValue reflect.Value, name string) (reflect.Value, bool) {
+	if keyType := mapValue.Type().Key(); keyType.Kind() == reflect.String {
+		key := reflect.ValueOf(name).Convert(keyType)
+		return key, mapValue.MapIndex(key).IsValid()
+	}
+	for _, key := range getSortedMapKeys(mapValue) {
+		if key.name == name {
+			return key.value, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// getMapValues returns the values of the map by the string their key is selected by,
+// if more than one key is selected by the same string the first key in sorted order is used.
+func getMapValues(mapValue reflect.Value) map[string]interface{} {
+	values := make(map[string]interface{}, mapValue.Len())
+	if mapValue.Type().Key().Kind() == reflect.String {
+		iterator := mapValue.MapRange()
+		for iterator.Next() {
+			values[iterator.Key().String()] = iterator.Value().Interface()
+		}
+		return values
+	}
+	for _, key := range getSortedMapKeys(mapValue) {
+		if _, ok := values[key.name]; !ok {
+			values[key.name] = mapValue.MapIndex(key.value).Interface()
+		}
+	}
+	return values
+}
+
+// getMapKeyNumber returns the value of a key with a number kind, or nil for any other key
+func getMapKeyNumber(key reflect.Value) *big.Float {
+	if key.Kind() == reflect.Interface && !key.IsNil() {
+		key = key.Elem()
+	}
+
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(key.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Float).SetUint64(key.Uint())
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(key.Float()) {
+			return nil
+		}
+		return new(big.Float).SetFloat64(key.Float())
+	}
+	return nil
+}
+
+// getOrderedObject returns the value as an ordered object, which tokens treat as a map with its keys in the order of its members
+func getOrderedObject(value interface{}) (*ordered.Object, bool) {
+	switch typed := value.(type) {
+	case *ordered.Object:
+		return typed, typed != nil
+	case ordered.Object:
+		return &typed, true
+	}
+	return nil, false
+}
+
+// getKind returns the kind of the type of the value, or reflect.Map for ordered objects
+func getKind(value interface{}, valueType reflect.Type) reflect.Kind {
+	if _, ok := getOrderedObject(value); ok {
+		return reflect.Map
+	}
+	return valueType.Kind()
+}
+
+// getMapElements returns the keys and values of a map in the sorted order of its keys,
+// or of an ordered object in the order of its members
+func getMapElements(current interface{}, objVal reflect.Value) ([]string, []interface{}) {
+	if object, ok := getOrderedObject(current); ok {
+		members := object.Members()
+		keys := make([]string, len(members))
+		values := make([]interface{}, len(members))
+		for idx, member := range members {
+			keys[idx] = member.Key
+			values[idx] = member.Value
+		}
+		return keys, values
+	}
+
+	if objVal.Type().Key().Kind() == reflect.String {
+		// the keys are unique strings, so the keys and values are read together and sorted by key
+		elements := make([]mapElement, 0, objVal.Len())
+		iterator := objVal.MapRange()
+		for iterator.Next() {
+			elements = append(elements, mapElement{key: iterator.Key().String(), value: iterator.Value().Interface()})
+		}
+		sort.Slice(elements, func(i, j int) bool {
+			return elements[i].key < elements[j].key
+		})
+
+		keys := make([]string, len(elements))
+		values := make([]interface{}, len(elements))
+		for idx, element := range elements {
+			keys[idx] = element.key
+			values[idx] = element.value
+		}
+		return keys, values
+	}
+
+	mapKeys := getSortedMapKeys(objVal)
+	keys := make([]string, len(mapKeys))
+	values := make([]interface{}, len(mapKeys))
+	for idx, key := range mapKeys {
+		keys[idx] = key.name
+		values[idx] = objVal.MapIndex(key.value).Interface()
+	}
+	return keys, values
+}
+
+// mapElement is a key and value of a map with a string key type
+type mapElement struct {
+	key   string
+	value interface{}
+}
+
+// ObjectValues returns the values of the members of an ordered object by key
+func ObjectValues(object *ordered.Object) map[string]interface{} {
+	values := make(map[string]interface{}, object.Len())
+	for _, member := range object.Members() {
+		values[member.Key] = member.Value
+	}
+	return values
 }
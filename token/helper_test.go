@@ -1,13 +1,46 @@
 package token
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// sampleTextKey is a map key that implements encoding.TextMarshaler
+type sampleTextKey struct {
+	id int
+}
+
+func (key sampleTextKey) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("text-%d", key.id)), nil
+}
+
+// sampleStringerKey is a map key with an integer kind, its value is used rather than its String function
+type sampleStringerKey int
+
+func (key sampleStringerKey) String() string {
+	return fmt.Sprintf("stringer-%d", int(key))
+}
+
+// sampleStringerFloatKey is a map key with a float kind, which encoding/json does not support, so its String function is used
+type sampleStringerFloatKey float64
+
+func (key sampleStringerFloatKey) String() string {
+	return fmt.Sprintf("stringer-%v", float64(key))
+}
+
+// sampleStringKey is a map key with a string kind, its value is used rather than its String function
+type sampleStringKey string
+
+func (key sampleStringKey) String() string {
+	return "ignored"
+}
+
 type sampleStruct struct {
 	One   string `json:"one"`
 	Two   string `json:"two,omitempty"`
@@ -105,6 +138,76 @@ func Test_isInteger(t *testing.T) {
 				ok:    false,
 			},
 		},
+		{
+			input: int8(100),
+			expected: expected{
+				value: 100,
+				ok:    true,
+			},
+		},
+		{
+			input: uint(100),
+			expected: expected{
+				value: 100,
+				ok:    true,
+			},
+		},
+		{
+			input: uint64(math.MaxUint64),
+			expected: expected{
+				value: 0,
+				ok:    false,
+			},
+		},
+		{
+			input: json.Number("9007199254740993"),
+			expected: expected{
+				value: 9007199254740993,
+				ok:    true,
+			},
+		},
+		{
+			input: json.Number("1e2"),
+			expected: expected{
+				value: 100,
+				ok:    true,
+			},
+		},
+		{
+			input: json.Number("3.14"),
+			expected: expected{
+				value: 0,
+				ok:    false,
+			},
+		},
+		{
+			input: json.Number("9223372036854775808"),
+			expected: expected{
+				value: 0,
+				ok:    false,
+			},
+		},
+		{
+			input: big.NewFloat(100),
+			expected: expected{
+				value: 100,
+				ok:    true,
+			},
+		},
+		{
+			input: big.NewFloat(3.14),
+			expected: expected{
+				value: 0,
+				ok:    false,
+			},
+		},
+		{
+			input: (*big.Float)(nil),
+			expected: expected{
+				value: 0,
+				ok:    false,
+			},
+		},
 	}
 
 	for idx, test := range tests {
@@ -197,6 +300,34 @@ func Test_getStructFields(t *testing.T) {
 	}
 }
 
+func Test_getStructFieldNames(t *testing.T) {
+	tests := []struct {
+		input    interface{}
+		expected []string
+	}{
+		{
+			input:    sampleStruct{},
+			expected: []string{"one", "two", "three", "Five", "Six"},
+		},
+		{
+			input: struct {
+				B      string
+				a      string
+				A      string `json:"a"`
+				hidden string
+			}{},
+			expected: []string{"B", "a"},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual := getStructFieldNames(getStructFields(reflect.ValueOf(test.input), false))
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func Test_getTypeAndValue(t *testing.T) {
 
 	getNilPointer := func() *sampleStruct {
@@ -275,3 +406,142 @@ func Test_getTypeAndValue(t *testing.T) {
 	}
 
 }
+
+func Test_MapKeyString(t *testing.T) {
+	tests := []struct {
+		input    interface{}
+		expected string
+	}{
+		{input: map[string]int{"key": 1}, expected: "key"},
+		{input: map[interface{}]int{"key": 1}, expected: "key"},
+		{input: map[interface{}]int{200: 1}, expected: "200"},
+		{input: map[interface{}]int{true: 1}, expected: "true"},
+		{input: map[interface{}]int{nil: 1}, expected: "null"},
+		{input: map[int]int{-1: 1}, expected: "-1"},
+		{input: map[uint8]int{7: 1}, expected: "7"},
+		{input: map[float64]int{1.5: 1}, expected: "1.5"},
+		{input: map[sampleTextKey]int{{id: 1}: 1}, expected: "text-1"},
+		{input: map[*sampleTextKey]int{{id: 2}: 1}, expected: "text-2"},
+		{input: map[*sampleTextKey]int{nil: 1}, expected: ""},
+		{input: map[*int]int{nil: 1}, expected: "null"},
+		{input: map[sampleStringerKey]int{3: 1}, expected: "3"},
+		{input: map[sampleStringerFloatKey]int{3.5: 1}, expected: "stringer-3.5"},
+		{input: map[sampleStringKey]int{"key": 1}, expected: "key"},
+		{input: map[interface{}]int{sampleTextKey{id: 4}: 1}, expected: "text-4"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			key := reflect.ValueOf(test.input).MapKeys()[0]
+			assert.Equal(t, test.expected, MapKeyString(key))
+		})
+	}
+}
+
+func Test_getSortedMapKeys(t *testing.T) {
+	tests := []struct {
+		input    interface{}
+		expected []string
+	}{
+		{
+			input:    map[string]int{"b": 1, "c": 2, "a": 3},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			input:    map[int]string{10: "ten", 9: "nine", -1: "minus one", 100: "hundred"},
+			expected: []string{"-1", "9", "10", "100"},
+		},
+		{
+			input:    map[uint64]string{math.MaxUint64: "max", 2: "two"},
+			expected: []string{"2", "18446744073709551615"},
+		},
+		{
+			input:    map[float64]string{2.5: "two", 10: "ten", math.NaN(): "nan"},
+			expected: []string{"2.5", "10", "NaN"},
+		},
+		{
+			input:    map[interface{}]string{"b": "b", 10: "ten", 2: "two", "a": "a", 1.5: "one"},
+			expected: []string{"1.5", "2", "10", "a", "b"},
+		},
+		{
+			input:    map[sampleTextKey]string{{id: 2}: "two", {id: 1}: "one"},
+			expected: []string{"text-1", "text-2"},
+		},
+		{
+			input:    map[sampleStringKey]string{"b": "b", "a": "a"},
+			expected: []string{"a", "b"},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			keys := getSortedMapKeys(reflect.ValueOf(test.input))
+			actual := make([]string, len(keys))
+			for idx, key := range keys {
+				actual[idx] = key.name
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_findMapKey(t *testing.T) {
+	type expected struct {
+		key interface{}
+		ok  bool
+	}
+
+	tests := []struct {
+		input    interface{}
+		name     string
+		expected expected
+	}{
+		{
+			input:    map[string]int{"key": 1},
+			name:     "key",
+			expected: expected{key: "key", ok: true},
+		},
+		{
+			input:    map[string]int{"key": 1},
+			name:     "missing",
+			expected: expected{ok: false},
+		},
+		{
+			input:    map[sampleStringKey]int{"key": 1},
+			name:     "key",
+			expected: expected{key: sampleStringKey("key"), ok: true},
+		},
+		{
+			input:    map[int]int{10: 1},
+			name:     "10",
+			expected: expected{key: 10, ok: true},
+		},
+		{
+			input:    map[sampleTextKey]int{{id: 1}: 1},
+			name:     "text-1",
+			expected: expected{key: sampleTextKey{id: 1}, ok: true},
+		},
+		{
+			input:    map[interface{}]int{"1": 1, 1: 2},
+			name:     "1",
+			expected: expected{key: 1, ok: true},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			key, ok := findMapKey(reflect.ValueOf(test.input), test.name)
+			assert.Equal(t, test.expected.ok, ok)
+			if test.expected.ok {
+				assert.Equal(t, test.expected.key, key.Interface())
+			}
+		})
+	}
+}
+
+func Test_getMapValues(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"a": 1, "b": 2}, getMapValues(reflect.ValueOf(map[string]int{"a": 1, "b": 2})))
+	assert.Equal(t, map[string]interface{}{"1": "one", "text-2": "two"}, getMapValues(reflect.ValueOf(map[interface{}]string{1: "one", sampleTextKey{id: 2}: "two"})))
+	// the first key in sorted order is used when keys are selected by the same string
+	assert.Equal(t, map[string]interface{}{"1": "number"}, getMapValues(reflect.ValueOf(map[interface{}]string{"1": "string", 1: "number"})))
+}
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
@@ -20,6 +21,7 @@ func newIndexToken(index int64, options *option.QueryOptions) *indexToken {
 		index:       index,
 		allowMap:    allowMap,
 		allowString: allowString,
+		leafToNull:  options != nil && options.DefaultPathLeafToNull,
 	}
 }
 
@@ -27,6 +29,8 @@ type indexToken struct {
 	index       int64
 	allowMap    bool
 	allowString bool
+	// leafToNull return nil rather than an error when the index is out of range and is at the end of the selector
+	leafToNull bool
 }
 
 func (token *indexToken) String() string {
@@ -37,7 +41,55 @@ func (token *indexToken) Type() string {
 	return "index"
 }
 
-func (token *indexToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
+func (token *indexToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	key, value, err := token.getValue(current)
+	if err != nil {
+		if token.isMissingLeaf(err, next) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return applyNext(ctx, root, value, next, getPathSegment(key))
+}
+
+func (token *indexToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	key, value, err := token.getValue(current.Value)
+	if err != nil {
+		if token.isMissingLeaf(err, next) {
+			reason := getQueryError(err, current.Path.String(), token, current.Value)
+			return applyNodesMissingLeaf(current.child(int(token.index), nil), next, reason)
+		}
+		return nil, err
+	}
+	return applyNodesNext(ctx, root, current.child(key, value), next)
+}
+
+// isMissingLeaf returns true if the index is out of range and is at the end of the selector, and should return nil
+func (token *indexToken) isMissingLeaf(err error, next []Token) bool {
+	return token.leafToNull && isOutOfRangeError(err) && isLeaf(next)
+}
+
+// applyToNodes will select the node at the index from a collection of nodes
+// returned by a previous token, such as a filter, range, or union.
+// The path describes the collection of nodes for any error returned.
+func (token *indexToken) applyToNodes(ctx context.Context, root interface{}, path string, nodes []*Node, next []Token) ([]*Node, error) {
+	idx := token.index
+	length := int64(len(nodes))
+	if idx < 0 {
+		idx = length + idx
+	}
+	if idx < 0 || idx >= length {
+		if token.leafToNull && isLeaf(next) {
+			// there is no location for the missing element of the collection
+			return nil, nil
+		}
+		return nil, getQueryError(getInvalidTokenOutOfRangeError(token.Type()), path, token, nodes)
+	}
+	return applyNodesNext(ctx, root, nodes[idx], next)
+}
+
+// getValue returns the path element and value of the indexed item
+func (token *indexToken) getValue(current interface{}) (interface{}, interface{}, error) {
 	idx := token.index
 
 	allowedType := []reflect.Kind{
@@ -53,31 +105,31 @@ func (token *indexToken) Apply(root, current interface{}, next []Token) (interfa
 
 	objType, objVal := getTypeAndValue(current)
 	if objType == nil {
-		return nil, getInvalidTokenTargetNilError(
+		return nil, nil, getInvalidTokenTargetNilError(
 			token.Type(),
 			allowedType...,
 		)
 	}
 
 	var length int64
-	var mapKeys []reflect.Value
+	var mapKeys []string
+	var mapValues []interface{}
 	isString := false
 
-	switch objType.Kind() {
+	switch getKind(current, objType) {
 	case reflect.Map:
 		if !token.allowMap {
-			return nil, getInvalidTokenTargetError(
+			return nil, nil, getInvalidTokenTargetError(
 				token.Type(),
-				objType.Kind(),
+				reflect.Map,
 				allowedType...,
 			)
 		}
-		length = int64(objVal.Len())
-		mapKeys = objVal.MapKeys()
-		sortMapKeys(mapKeys)
+		mapKeys, mapValues = getMapElements(current, objVal)
+		length = int64(len(mapKeys))
 	case reflect.String:
 		if !token.allowString {
-			return nil, getInvalidTokenTargetError(
+			return nil, nil, getInvalidTokenTargetError(
 				token.Type(),
 				objType.Kind(),
 				allowedType...,
@@ -89,7 +141,7 @@ func (token *indexToken) Apply(root, current interface{}, next []Token) (interfa
 		length = int64(objVal.Len())
 		mapKeys = nil
 	default:
-		return nil, getInvalidTokenTargetError(
+		return nil, nil, getInvalidTokenTargetError(
 			token.Type(),
 			objType.Kind(),
 			allowedType...,
@@ -101,27 +153,18 @@ func (token *indexToken) Apply(root, current interface{}, next []Token) (interfa
 	}
 
 	if idx < 0 || idx >= length {
-		return nil, getInvalidTokenOutOfRangeError(token.Type())
+		return nil, nil, getInvalidTokenOutOfRangeError(token.Type())
 	}
 
-	var value interface{}
-
 	if mapKeys != nil {
-		key := mapKeys[idx]
-		value = objVal.MapIndex(key).Interface()
-
-	} else if isString {
-		value = objVal.Index(int(idx)).Interface()
+		return mapKeys[idx], mapValues[idx], nil
+	}
 
+	value := objVal.Index(int(idx)).Interface()
+	if isString {
 		if u, ok := value.(uint8); ok {
 			value = fmt.Sprintf("%c", u)
 		}
-	} else {
-		value = objVal.Index(int(idx)).Interface()
-	}
-
-	if len(next) > 0 {
-		return next[0].Apply(root, value, next[1:])
 	}
-	return value, nil
+	return int(idx), value, nil
 }
@@ -1,10 +1,14 @@
 package token
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/evilmonkeyinc/jsonpath/errors"
 	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/ordered"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -115,6 +119,18 @@ func Test_newIndexToken(t *testing.T) {
 				allowString: true,
 			},
 		},
+		{
+			input: input{
+				index: 0,
+				options: &option.QueryOptions{
+					DefaultPathLeafToNull: true,
+				},
+			},
+			expected: &indexToken{
+				index:      0,
+				leafToNull: true,
+			},
+		},
 	}
 
 	for idx, test := range tests {
@@ -365,6 +381,62 @@ var indexTest = []*tokenTest{
 			},
 		},
 	},
+	{
+		token: &indexToken{index: 5, leafToNull: true},
+		input: input{
+			current: []interface{}{"one"},
+		},
+		expected: expected{
+			value: nil,
+		},
+	},
+	{
+		token: &indexToken{index: 5, leafToNull: true},
+		input: input{
+			current: []interface{}{"one"},
+			tokens: []Token{
+				&keyToken{key: "key"},
+			},
+		},
+		expected: expected{
+			err: "index: invalid token out of range",
+		},
+	},
+	{
+		token: &indexToken{index: 0, leafToNull: true},
+		input: input{
+			current: "string",
+		},
+		expected: expected{
+			err: "index: invalid token target. expected [array slice] got [string]",
+		},
+	},
+	{
+		token: &indexToken{index: 0, allowMap: true},
+		input: input{
+			current: ordered.NewObject(
+				ordered.Member{Key: "three", Value: 3},
+				ordered.Member{Key: "one", Value: 1},
+				ordered.Member{Key: "two", Value: 2},
+			),
+		},
+		expected: expected{
+			value: 3,
+		},
+	},
+	{
+		token: &indexToken{index: 0},
+		input: input{
+			current: ordered.NewObject(
+				ordered.Member{Key: "three", Value: 3},
+				ordered.Member{Key: "one", Value: 1},
+				ordered.Member{Key: "two", Value: 2},
+			),
+		},
+		expected: expected{
+			err: "index: invalid token target. expected [array slice] got [map]",
+		},
+	},
 }
 
 func Test_IndexToken_Apply(t *testing.T) {
@@ -374,3 +446,116 @@ func Test_IndexToken_Apply(t *testing.T) {
 func Benchmark_IndexToken_Apply(b *testing.B) {
 	batchTokenBenchmarks(b, indexTest)
 }
+
+func Test_IndexToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &indexToken{index: 0},
+			input: nodesInput{
+				root: "string",
+			},
+			expected: nodesExpected{
+				err: "index: invalid token target. expected [array slice] got [string]",
+			},
+		},
+		{
+			token: &indexToken{index: 5},
+			input: nodesInput{
+				root: []interface{}{"one"},
+			},
+			expected: nodesExpected{
+				err: "index: invalid token out of range",
+			},
+		},
+		{
+			token: &indexToken{index: -1},
+			input: nodesInput{
+				root: []interface{}{"one", "two", "three"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[2]"},
+				values: []interface{}{"three"},
+			},
+		},
+		{
+			token: &indexToken{index: 1, allowMap: true},
+			input: nodesInput{
+				root: map[string]interface{}{"b": 2, "a": 1},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['b']"},
+				values: []interface{}{2},
+			},
+		},
+		{
+			token: &indexToken{index: 1, allowString: true},
+			input: nodesInput{
+				root: "string",
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[1]"},
+				values: []interface{}{"t"},
+			},
+		},
+		{
+			token: &indexToken{index: 0},
+			input: nodesInput{
+				root: []interface{}{map[string]interface{}{"key": "value"}},
+				tokens: []Token{
+					&keyToken{key: "key"},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[0]['key']"},
+				values: []interface{}{"value"},
+			},
+		},
+		{
+			token: &indexToken{index: 5, leafToNull: true},
+			input: nodesInput{
+				root: []interface{}{"one"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[5]"},
+				values: []interface{}{nil},
+			},
+		},
+		{
+			token: &indexToken{index: 5, leafToNull: true},
+			input: nodesInput{
+				root: []interface{}{"one"},
+				tokens: []Token{
+					&keyToken{key: "key"},
+				},
+			},
+			expected: nodesExpected{
+				err: "index: invalid token out of range",
+			},
+		},
+	})
+}
+
+func Test_IndexToken_applyToNodes(t *testing.T) {
+	nodes := []*Node{
+		{Path: Path{"a", 1}, Value: "one"},
+		{Path: Path{"b", 3}, Value: "three"},
+	}
+
+	actual, err := (&indexToken{index: -1}).applyToNodes(context.Background(), nil, "$[*]", nodes, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []*Node{nodes[1]}, actual)
+
+	actual, err = (&indexToken{index: 2}).applyToNodes(context.Background(), nil, "$[*]", nodes, nil)
+	assert.EqualError(t, err, "index: invalid token out of range")
+	assert.Nil(t, actual)
+
+	queryError, ok := err.(*errors.QueryError)
+	assert.True(t, ok)
+	assert.Equal(t, "$[*]", queryError.Path)
+	assert.Equal(t, "[2]", queryError.Token)
+	assert.Equal(t, reflect.Slice, queryError.Kind)
+
+	actual, err = (&indexToken{index: 2, leafToNull: true}).applyToNodes(context.Background(), nil, "$[*]", nodes, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+}
@@ -1,17 +1,28 @@
 package token
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
 )
 
-func newKeyToken(key string) *keyToken {
-	return &keyToken{key: key}
+func newKeyToken(key string, options *option.QueryOptions) *keyToken {
+	return &keyToken{
+		key:        key,
+		leafToNull: options != nil && options.DefaultPathLeafToNull,
+		matcher:    newKeyMatcher(options),
+	}
 }
 
 type keyToken struct {
 	key string
+	// leafToNull return nil rather than an error when the key is missing and is at the end of the selector
+	leafToNull bool
+	// matcher compares the key with the keys of maps and fields of structs
+	matcher keyMatcher
 }
 
 func (token *keyToken) String() string {
@@ -24,42 +35,76 @@ func (token *keyToken) Type() string {
 	return "key"
 }
 
-func (token *keyToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
+func (token *keyToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	key, value, err := token.getValue(current)
+	if err != nil {
+		if token.isMissingLeaf(err, next) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return applyNext(ctx, root, value, next, getPathSegment(key))
+}
+
+func (token *keyToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	key, value, err := token.getValue(current.Value)
+	if err != nil {
+		if token.isMissingLeaf(err, next) {
+			reason := getQueryError(err, current.Path.String(), token, current.Value)
+			return applyNodesMissingLeaf(current.child(token.key, nil), next, reason)
+		}
+		return nil, err
+	}
+	return applyNodesNext(ctx, root, current.child(key, value), next)
+}
+
+// isMissingLeaf returns true if the key is missing and is at the end of the selector, and should return nil
+func (token *keyToken) isMissingLeaf(err error, next []Token) bool {
+	return token.leafToNull && isKeyNotFoundError(err) && isLeaf(next)
+}
+
+// getValue returns the key of the map or name of the struct field that matches the key of the token, and its value
+func (token *keyToken) getValue(current interface{}) (string, interface{}, error) {
 	objType, objVal := getTypeAndValue(current)
 	if objType == nil {
-		return nil, getInvalidTokenTargetNilError(
+		return "", nil, getInvalidTokenTargetNilError(
 			token.Type(),
 			reflect.Map,
 		)
 	}
 
-	switch objType.Kind() {
+	switch getKind(current, objType) {
 	case reflect.Map:
-		keys := objVal.MapKeys()
-		for _, kv := range keys {
-			if kv.String() == token.key {
-				value := objVal.MapIndex(kv).Interface()
+		if object, ok := getOrderedObject(current); ok {
+			if value, ok := object.Get(token.key); ok {
+				return token.key, value, nil
+			}
+			values := ObjectValues(object)
+			if key, ok := matchKey(token.matcher, token.key, values); ok {
+				return key, values[key], nil
+			}
+			return "", nil, getInvalidTokenKeyNotFoundError(token.Type(), token.key)
+		}
 
-				if len(next) > 0 {
-					return next[0].Apply(root, value, next[1:])
-				}
-				return value, nil
+		if key, ok := findMapKey(objVal, token.key); ok {
+			return token.key, objVal.MapIndex(key).Interface(), nil
+		}
+		if token.matcher.enabled() {
+			values := getMapValues(objVal)
+			if key, ok := matchKey(token.matcher, token.key, values); ok {
+				return key, values[key], nil
 			}
 		}
-		return nil, getInvalidTokenKeyNotFoundError(token.Type(), token.key)
+		return "", nil, getInvalidTokenKeyNotFoundError(token.Type(), token.key)
 	case reflect.Struct:
 		fields := getStructFields(objVal, false)
-		if field, ok := fields[token.key]; ok {
-			value := objVal.FieldByName(field.Name).Interface()
-
-			if len(next) > 0 {
-				return next[0].Apply(root, value, next[1:])
-			}
-			return value, nil
+		if key, ok := matchKey(token.matcher, token.key, fields); ok {
+			return key, objVal.FieldByName(fields[key].Name).Interface(), nil
 		}
-		return nil, getInvalidTokenKeyNotFoundError(token.Type(), token.key)
+		return "", nil, getInvalidTokenKeyNotFoundError(token.Type(), token.key)
 	default:
-		return nil, getInvalidTokenTargetError(
+		return "", nil, getInvalidTokenTargetError(
 			token.Type(),
 			objType.Kind(),
 			reflect.Map)
@@ -3,6 +3,8 @@ package token
 import (
 	"testing"
 
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/ordered"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -10,7 +12,10 @@ import (
 var _ Token = &keyToken{}
 
 func Test_newKeyToken(t *testing.T) {
-	assert.IsType(t, &keyToken{}, newKeyToken(""))
+	assert.IsType(t, &keyToken{}, newKeyToken("", nil))
+	assert.Equal(t, &keyToken{key: "key"}, newKeyToken("key", &option.QueryOptions{}))
+	assert.Equal(t, &keyToken{key: "key", leafToNull: true}, newKeyToken("key", &option.QueryOptions{DefaultPathLeafToNull: true}))
+	assert.Equal(t, &keyToken{key: "key", matcher: keyMatcher{caseInsensitive: true}}, newKeyToken("key", &option.QueryOptions{CaseInsensitiveKeys: true}))
 }
 
 func Test_KeyToken_String(t *testing.T) {
@@ -185,6 +190,169 @@ var keyTests = []*tokenTest{
 			err: "key: invalid token key 'other' not found",
 		},
 	},
+	{
+		token: &keyToken{key: "missing", leafToNull: true},
+		input: input{
+			current: map[string]interface{}{
+				"key": true,
+			},
+		},
+		expected: expected{
+			value: nil,
+		},
+	},
+	{
+		token: &keyToken{key: "missing", leafToNull: true},
+		input: input{
+			current: map[string]interface{}{
+				"key": true,
+			},
+			tokens: []Token{
+				&keyToken{key: "next"},
+			},
+		},
+		expected: expected{
+			err: "key: invalid token key 'missing' not found",
+		},
+	},
+	{
+		token: &keyToken{key: "missing", leafToNull: true},
+		input: input{
+			current: "",
+		},
+		expected: expected{
+			err: "key: invalid token target. expected [map] got [string]",
+		},
+	},
+	{
+		token: &keyToken{key: "userid", matcher: keyMatcher{caseInsensitive: true}},
+		input: input{
+			current: map[string]interface{}{"userId": 1, "UserID": 2},
+		},
+		expected: expected{
+			value: 2,
+		},
+	},
+	{
+		token: &keyToken{key: "userId", matcher: keyMatcher{caseInsensitive: true}},
+		input: input{
+			current: map[string]interface{}{"userId": 1, "UserID": 2},
+		},
+		expected: expected{
+			value: 1,
+		},
+	},
+	{
+		token: &keyToken{key: "ONE", matcher: keyMatcher{caseInsensitive: true}},
+		input: input{
+			current: sampleStruct{One: "value"},
+		},
+		expected: expected{
+			value: "value",
+		},
+	},
+	{
+		token: &keyToken{key: "one"},
+		input: input{
+			current: ordered.NewObject(
+				ordered.Member{Key: "three", Value: 3},
+				ordered.Member{Key: "one", Value: 1},
+				ordered.Member{Key: "two", Value: 2},
+			),
+		},
+		expected: expected{
+			value: 1,
+		},
+	},
+	{
+		token: &keyToken{key: "missing"},
+		input: input{
+			current: ordered.NewObject(
+				ordered.Member{Key: "three", Value: 3},
+				ordered.Member{Key: "one", Value: 1},
+				ordered.Member{Key: "two", Value: 2},
+			),
+		},
+		expected: expected{
+			err: "key: invalid token key 'missing' not found",
+		},
+	},
+	{
+		token: &keyToken{key: "ONE", matcher: keyMatcher{caseInsensitive: true}},
+		input: input{
+			current: ordered.NewObject(
+				ordered.Member{Key: "three", Value: 3},
+				ordered.Member{Key: "one", Value: 1},
+				ordered.Member{Key: "two", Value: 2},
+			),
+		},
+		expected: expected{
+			value: 1,
+		},
+	},
+	{
+		token: &keyToken{key: "key"},
+		input: input{
+			current: map[interface{}]interface{}{1: "one", "key": "value"},
+		},
+		expected: expected{
+			value: "value",
+		},
+	},
+	{
+		token: &keyToken{key: "1"},
+		input: input{
+			current: map[interface{}]interface{}{1: "one", "key": "value"},
+		},
+		expected: expected{
+			value: "one",
+		},
+	},
+	{
+		token: &keyToken{key: "10"},
+		input: input{
+			current: map[int]string{9: "nine", 10: "ten"},
+		},
+		expected: expected{
+			value: "ten",
+		},
+	},
+	{
+		token: &keyToken{key: "text-1"},
+		input: input{
+			current: map[sampleTextKey]string{{id: 1}: "one"},
+		},
+		expected: expected{
+			value: "one",
+		},
+	},
+	{
+		token: &keyToken{key: "2"},
+		input: input{
+			current: map[sampleStringerKey]string{2: "two"},
+		},
+		expected: expected{
+			value: "two",
+		},
+	},
+	{
+		token: &keyToken{key: "key"},
+		input: input{
+			current: map[sampleStringKey]string{"key": "value"},
+		},
+		expected: expected{
+			value: "value",
+		},
+	},
+	{
+		token: &keyToken{key: "TEXT-1", matcher: keyMatcher{caseInsensitive: true}},
+		input: input{
+			current: map[sampleTextKey]string{{id: 1}: "one"},
+		},
+		expected: expected{
+			value: "one",
+		},
+	},
 }
 
 func Test_KeyToken_Apply(t *testing.T) {
@@ -194,3 +362,96 @@ func Test_KeyToken_Apply(t *testing.T) {
 func Benchmark_KeyToken_Apply(b *testing.B) {
 	batchTokenBenchmarks(b, keyTests)
 }
+
+func Test_KeyToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &keyToken{key: "key"},
+			input: nodesInput{
+				root: nil,
+			},
+			expected: nodesExpected{
+				err: "key: invalid token target. expected [map] got [nil]",
+			},
+		},
+		{
+			token: &keyToken{key: "missing"},
+			input: nodesInput{
+				root: map[string]interface{}{"key": true},
+			},
+			expected: nodesExpected{
+				err: "key: invalid token key 'missing' not found",
+			},
+		},
+		{
+			token: &keyToken{key: "KEY", matcher: keyMatcher{caseInsensitive: true}},
+			input: nodesInput{
+				root: map[string]interface{}{"key": true},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['key']"},
+				values: []interface{}{true},
+			},
+		},
+		{
+			token: &keyToken{key: "missing", leafToNull: true},
+			input: nodesInput{
+				root: map[string]interface{}{"key": true},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['missing']"},
+				values: []interface{}{nil},
+			},
+		},
+		{
+			token: &keyToken{key: "missing", leafToNull: true},
+			input: nodesInput{
+				root:   map[string]interface{}{"key": true},
+				tokens: []Token{&keyToken{key: "next"}},
+			},
+			expected: nodesExpected{
+				err: "key: invalid token key 'missing' not found",
+			},
+		},
+		{
+			token: &keyToken{key: "key's"},
+			input: nodesInput{
+				root: map[string]interface{}{"key's": nil},
+			},
+			expected: nodesExpected{
+				paths:  []string{`$['key\'s']`},
+				values: []interface{}{nil},
+			},
+		},
+		{
+			token: &keyToken{key: "key"},
+			input: nodesInput{
+				root: map[string]interface{}{
+					"key": map[string]interface{}{
+						"next": "nested target",
+					},
+				},
+				tokens: []Token{
+					&keyToken{key: "next"},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['key']['next']"},
+				values: []interface{}{"nested target"},
+			},
+		},
+		{
+			token: &keyToken{key: "three"},
+			input: nodesInput{
+				current: &Node{
+					Path:  Path{"parent"},
+					Value: &sampleStruct{Four: 100},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['parent']['three']"},
+				values: []interface{}{int64(100)},
+			},
+		},
+	})
+}
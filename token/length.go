@@ -1,6 +1,7 @@
 package token
 
 import (
+	"context"
 	"reflect"
 )
 
@@ -19,11 +20,31 @@ func (token *lengthToken) Type() string {
 	return "length"
 }
 
-func (token *lengthToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
+func (token *lengthToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	value, _, err := token.getValue(current)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyNext(ctx, root, value, next, getPathSegment("length"))
+}
+
+func (token *lengthToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	value, member, err := token.getValue(current.Value)
+	if err != nil {
+		return nil, err
+	}
+	child := current.child("length", value)
+	// the length of the collection is not a location in the data unless it is the value of a length key
+	child.Computed = !member
+	return applyNodesNext(ctx, root, child, next)
+}
 
+// getValue returns the length of the collection, or the value of the length key of a map, and true if it is the value of a key
+func (token *lengthToken) getValue(current interface{}) (interface{}, bool, error) {
 	objType, objVal := getTypeAndValue(current)
 	if objType == nil {
-		return nil, getInvalidTokenTargetNilError(
+		return nil, false, getInvalidTokenTargetNilError(
 			token.Type(),
 			reflect.Array,
 			reflect.Map,
@@ -32,30 +53,25 @@ func (token *lengthToken) Apply(root, current interface{}, next []Token) (interf
 		)
 	}
 
-	switch objType.Kind() {
+	switch getKind(current, objType) {
 	case reflect.Map:
-		current = int64(objVal.Len())
-
-		keys := objVal.MapKeys()
-		for _, kv := range keys {
-			if kv.String() == "length" {
-				current = objVal.MapIndex(kv).Interface()
+		if object, ok := getOrderedObject(current); ok {
+			if value, ok := object.Get("length"); ok {
+				return value, true, nil
 			}
+			return int64(object.Len()), false, nil
+		}
+		if key, ok := findMapKey(objVal, "length"); ok {
+			return objVal.MapIndex(key).Interface(), true, nil
 		}
-		break
+		return int64(objVal.Len()), false, nil
 	case reflect.Array, reflect.Slice, reflect.String:
-		current = int64(objVal.Len())
-		break
+		return int64(objVal.Len()), false, nil
 	default:
-		return nil, getInvalidTokenTargetError(
+		return nil, false, getInvalidTokenTargetError(
 			token.Type(),
 			objType.Kind(),
 			reflect.Array, reflect.Map, reflect.Slice, reflect.String,
 		)
 	}
-
-	if len(next) > 0 {
-		return next[0].Apply(root, current, next[1:])
-	}
-	return current, nil
 }
@@ -1,8 +1,10 @@
 package token
 
 import (
+	"context"
 	"testing"
 
+	"github.com/evilmonkeyinc/jsonpath/ordered"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -116,6 +118,37 @@ var lengthTests = []*tokenTest{
 			value: "this would be the length",
 		},
 	},
+	{
+		token: &lengthToken{},
+		input: input{
+			current: ordered.NewObject(
+				ordered.Member{Key: "three", Value: 3},
+				ordered.Member{Key: "one", Value: 1},
+				ordered.Member{Key: "two", Value: 2},
+			),
+		},
+		expected: expected{
+			value: int64(3),
+		},
+	},
+	{
+		token: &lengthToken{},
+		input: input{
+			current: ordered.NewObject(ordered.Member{Key: "length", Value: "this would be the length"}),
+		},
+		expected: expected{
+			value: "this would be the length",
+		},
+	},
+	{
+		token: &lengthToken{},
+		input: input{
+			current: map[interface{}]interface{}{"length": "this would be the length", 1: "one"},
+		},
+		expected: expected{
+			value: "this would be the length",
+		},
+	},
 }
 
 func Test_LengthToken_Apply(t *testing.T) {
@@ -125,3 +158,47 @@ func Test_LengthToken_Apply(t *testing.T) {
 func Benchmark_LengthToken_Apply(b *testing.B) {
 	batchTokenBenchmarks(b, lengthTests)
 }
+
+func Test_LengthToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &lengthToken{},
+			input: nodesInput{
+				root: 1,
+			},
+			expected: nodesExpected{
+				err: "length: invalid token target. expected [array map slice string] got [int]",
+			},
+		},
+		{
+			token: &lengthToken{},
+			input: nodesInput{
+				root: []interface{}{1, 2, 3},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['length']"},
+				values: []interface{}{int64(3)},
+			},
+		},
+		{
+			token: &lengthToken{},
+			input: nodesInput{
+				root: map[string]interface{}{"length": "value"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['length']"},
+				values: []interface{}{"value"},
+			},
+		},
+	})
+
+	t.Run("computed", func(t *testing.T) {
+		nodes, err := (&lengthToken{}).ApplyNodes(context.Background(), nil, &Node{Path: Path{"a"}, Value: "abc"}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, []*Node{{Path: Path{"a", "length"}, Value: int64(3), Computed: true}}, nodes)
+
+		nodes, err = (&lengthToken{}).ApplyNodes(context.Background(), nil, &Node{Path: Path{"a"}, Value: map[string]interface{}{"length": 1}}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, []*Node{{Path: Path{"a", "length"}, Value: 1}}, nodes)
+	})
+}
@@ -0,0 +1,68 @@
+package token
+
+import (
+	"strings"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"golang.org/x/text/unicode/norm"
+)
+
+func newKeyMatcher(options *option.QueryOptions) keyMatcher {
+	if options == nil {
+		return keyMatcher{}
+	}
+	return keyMatcher{
+		caseInsensitive: options.CaseInsensitiveKeys,
+		normalize:       options.NormalizeKeys,
+	}
+}
+
+// keyMatcher compares the keys requested by key and union tokens with the keys of maps and fields of structs
+type keyMatcher struct {
+	// caseInsensitive match keys regardless of case
+	caseInsensitive bool
+	// normalize match keys after Unicode NFC normalization
+	normalize bool
+}
+
+// enabled returns true if keys can match other than exactly
+func (matcher keyMatcher) enabled() bool {
+	return matcher.caseInsensitive || matcher.normalize
+}
+
+// equal returns true if the requested key matches the key
+func (matcher keyMatcher) equal(requested, key string) bool {
+	if requested == key {
+		return true
+	}
+	if matcher.normalize {
+		requested = norm.NFC.String(requested)
+		key = norm.NFC.String(key)
+	}
+	if matcher.caseInsensitive {
+		return strings.EqualFold(requested, key)
+	}
+	return requested == key
+}
+
+// matchKey returns the key of the map that the requested key matches.
+//
+// An exact match is always preferred, otherwise when more than one key matches, such as 'userId' and 'UserID'
+// when matching regardless of case, the first key in sorted order is returned so the match does not depend
+// on the order of the map.
+func matchKey[T any](matcher keyMatcher, requested string, keys map[string]T) (string, bool) {
+	if _, ok := keys[requested]; ok {
+		return requested, true
+	}
+	if !matcher.enabled() {
+		return "", false
+	}
+
+	match, found := "", false
+	for key := range keys {
+		if matcher.equal(requested, key) && (!found || key < match) {
+			match, found = key, true
+		}
+	}
+	return match, found
+}
@@ -0,0 +1,78 @@
+package token
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newKeyMatcher(t *testing.T) {
+	assert.Equal(t, keyMatcher{}, newKeyMatcher(nil))
+	assert.Equal(t, keyMatcher{}, newKeyMatcher(&option.QueryOptions{}))
+	assert.Equal(t, keyMatcher{caseInsensitive: true, normalize: true}, newKeyMatcher(&option.QueryOptions{
+		CaseInsensitiveKeys: true,
+		NormalizeKeys:       true,
+	}))
+}
+
+func Test_keyMatcher_equal(t *testing.T) {
+	// café with a precomposed é, and with an e followed by a combining acute accent
+	composed := "caf\u00e9"
+	decomposed := "cafe\u0301"
+
+	tests := []struct {
+		matcher   keyMatcher
+		requested string
+		key       string
+		expected  bool
+	}{
+		{matcher: keyMatcher{}, requested: "userId", key: "userId", expected: true},
+		{matcher: keyMatcher{}, requested: "userId", key: "UserID", expected: false},
+		{matcher: keyMatcher{caseInsensitive: true}, requested: "userId", key: "UserID", expected: true},
+		{matcher: keyMatcher{caseInsensitive: true}, requested: "userId", key: "user", expected: false},
+		{matcher: keyMatcher{}, requested: composed, key: decomposed, expected: false},
+		{matcher: keyMatcher{normalize: true}, requested: composed, key: decomposed, expected: true},
+		{matcher: keyMatcher{normalize: true}, requested: "CAF\u00c9", key: decomposed, expected: false},
+		{matcher: keyMatcher{caseInsensitive: true}, requested: "CAF\u00c9", key: decomposed, expected: false},
+		{matcher: keyMatcher{caseInsensitive: true, normalize: true}, requested: "CAF\u00c9", key: decomposed, expected: true},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, test.matcher.equal(test.requested, test.key))
+		})
+	}
+}
+
+func Test_matchKey(t *testing.T) {
+	keys := map[string]int{
+		"userId": 1,
+		"UserID": 2,
+		"USERID": 3,
+		"name":   4,
+	}
+
+	tests := []struct {
+		matcher   keyMatcher
+		requested string
+		key       string
+		found     bool
+	}{
+		{matcher: keyMatcher{}, requested: "UserID", key: "UserID", found: true},
+		{matcher: keyMatcher{}, requested: "userid", found: false},
+		{matcher: keyMatcher{caseInsensitive: true}, requested: "userId", key: "userId", found: true},
+		{matcher: keyMatcher{caseInsensitive: true}, requested: "userid", key: "USERID", found: true},
+		{matcher: keyMatcher{caseInsensitive: true}, requested: "Name", key: "name", found: true},
+		{matcher: keyMatcher{caseInsensitive: true}, requested: "missing", found: false},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			key, found := matchKey(test.matcher, test.requested, keys)
+			assert.Equal(t, test.key, key)
+			assert.Equal(t, test.found, found)
+		})
+	}
+}
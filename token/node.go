@@ -0,0 +1,214 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Node represents a value matched by a selector along with its location in the queried data
+type Node struct {
+	// Path the location of the value relative to the root of the queried data
+	Path Path
+	// Value the matched value
+	Value interface{}
+	// Missing true if the key or index does not exist in the data, the node is included with a nil
+	// value because the DefaultPathLeafToNull option is enabled and there is no location to modify
+	Missing bool
+	// Computed true if the value is calculated from the data, such as the length of a collection,
+	// and the path is not a location in the data
+	Computed bool
+}
+
+// Path represents the location of a value as the ordered keys and indices used to reach it from the root.
+//
+// Map keys and struct field names are represented as strings, array, slice, and string indices as integers.
+type Path []interface{}
+
+// String returns the normalized path representation, for example $['store']['book'][2]
+func (path Path) String() string {
+	builder := strings.Builder{}
+	builder.WriteString("$")
+	for _, element := range path {
+		switch value := element.(type) {
+		case string:
+			builder.WriteString("['")
+			builder.WriteString(escapeNormalizedPathName(value))
+			builder.WriteString("']")
+		default:
+			builder.WriteString(fmt.Sprintf("[%v]", value))
+		}
+	}
+	return builder.String()
+}
+
+func (path Path) child(element interface{}) Path {
+	child := make(Path, len(path), len(path)+1)
+	copy(child, path)
+	return append(child, element)
+}
+
+func (node *Node) child(element interface{}, value interface{}) *Node {
+	return &Node{
+		Path:  node.Path.child(element),
+		Value: value,
+	}
+}
+
+func escapeNormalizedPathName(name string) string {
+	builder := strings.Builder{}
+	for _, rne := range name {
+		switch rne {
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\'':
+			builder.WriteString(`\'`)
+		case '\b':
+			builder.WriteString(`\b`)
+		case '\f':
+			builder.WriteString(`\f`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			if rne < 0x20 {
+				builder.WriteString(fmt.Sprintf(`\u%04x`, rne))
+				continue
+			}
+			builder.WriteRune(rne)
+		}
+	}
+	return builder.String()
+}
+
+func applyNodesNext(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	if len(next) > 0 {
+		nodes, err := traceNodes(ctx, next, 1, func() ([]*Node, error) {
+			return next[0].ApplyNodes(ctx, root, current, next[1:])
+		})
+		if err != nil {
+			return nil, getQueryError(err, current.Path.String(), next[0], current.Value)
+		}
+		return nodes, nil
+	}
+	return []*Node{current}, nil
+}
+
+// applyNext applies the next tokens against the value, which is found at the segment relative to the
+// current value, returning errors as a query error with a path relative to the current value
+func applyNext(ctx context.Context, root, value interface{}, next []Token, segment string) (interface{}, error) {
+	if len(next) == 0 {
+		return value, nil
+	}
+	result, err := next[0].Apply(ctx, root, value, next[1:])
+	if err != nil {
+		return nil, prependQueryErrorPath(getQueryError(err, "$", next[0], value), segment)
+	}
+	return result, nil
+}
+
+// getPathSegment returns the normalized path segment of the key or index
+func getPathSegment(element interface{}) string {
+	return strings.TrimPrefix(Path{element}.String(), "$")
+}
+
+// isLeaf returns true if the next tokens are empty, or only the branch token of a set that records
+// the result for the selectors that end with the token, so the token is at the end of the selector
+func isLeaf(next []Token) bool {
+	if len(next) == 0 {
+		return true
+	}
+	_, isBranch := next[0].(*branchToken)
+	return isBranch && len(next) == 1
+}
+
+// applyNodesMissingLeaf returns the node of a missing key or index at the end of the selector, which has a nil value.
+// The branch token of a set records the node for the selectors that end with the key or index, and returns the reason
+// it is missing as the error of the selectors that continue past it.
+func applyNodesMissingLeaf(current *Node, next []Token, reason error) ([]*Node, error) {
+	current.Missing = true
+	if len(next) > 0 {
+		if branch, ok := next[0].(*branchToken); ok {
+			return nil, branch.state.applyMissing(branch.node, current, reason)
+		}
+	}
+	return []*Node{current}, nil
+}
+
+// getRequiredError returns the error, or the errors of a set, caused by a missing key that tokens which
+// require the keys that follow them return rather than ignore, nil is returned for any other error
+func getRequiredError(err error) error {
+	if failed, ok := err.(*setError); ok {
+		required := &setError{errors: make(map[int]error)}
+		for idx, failure := range failed.errors {
+			if isKeyNotFoundError(failure) {
+				required.errors[idx] = failure
+			}
+		}
+		if len(required.errors) > 0 {
+			return required
+		}
+		return nil
+	}
+	if isKeyNotFoundError(err) {
+		return err
+	}
+	return nil
+}
+
+// collectElementNodes applies the next tokens against each element of a token that returns multiple nodes, ignoring
+// any errors in the same way as collectNodes unless the keys that follow the token are required, when errors caused
+// by a missing key are returned. The errors of a set are collected from every element so the selectors that have
+// not failed are still applied to the remaining elements.
+func collectElementNodes(ctx context.Context, root interface{}, elements []*Node, next []Token, requireProperties bool) ([]*Node, error) {
+	nodes := make([]*Node, 0)
+	var failed *setError
+	for _, element := range elements {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		elementNodes, err := applyNodesNext(ctx, root, element, next)
+		if err == nil {
+			nodes = append(nodes, elementNodes...)
+			continue
+		}
+		if !requireProperties {
+			continue
+		}
+
+		required := getRequiredError(err)
+		if required == nil {
+			continue
+		}
+		requiredSet, ok := required.(*setError)
+		if !ok {
+			return nil, required
+		}
+		if failed == nil {
+			failed = &setError{errors: make(map[int]error)}
+		}
+		for idx, failure := range requiredSet.errors {
+			// keep the first error of each selector, as the selector on its own would return it
+			if _, found := failed.errors[idx]; !found {
+				failed.errors[idx] = failure
+			}
+		}
+	}
+	if failed != nil {
+		return nil, failed
+	}
+	return nodes, nil
+}
+
+// collectNodes applies the next tokens against the node, ignoring any errors
+// as tokens that return multiple nodes skip elements that fail to match
+func collectNodes(ctx context.Context, root interface{}, current *Node, next []Token) []*Node {
+	nodes, err := applyNodesNext(ctx, root, current, next)
+	if err != nil {
+		return nil
+	}
+	return nodes
+}
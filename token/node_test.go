@@ -0,0 +1,194 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Path_String(t *testing.T) {
+	tests := []struct {
+		input    Path
+		expected string
+	}{
+		{
+			input:    nil,
+			expected: "$",
+		},
+		{
+			input:    Path{},
+			expected: "$",
+		},
+		{
+			input:    Path{"store", "book", 2},
+			expected: "$['store']['book'][2]",
+		},
+		{
+			input:    Path{"key's"},
+			expected: `$['key\'s']`,
+		},
+		{
+			input:    Path{`back\slash`},
+			expected: `$['back\\slash']`,
+		},
+		{
+			input:    Path{"line\nbreak\ttab"},
+			expected: `$['line\nbreak\ttab']`,
+		},
+		{
+			input:    Path{"\u0001"},
+			expected: `$['\u0001']`,
+		},
+		{
+			input:    Path{"with space", 0, "ünïcode"},
+			expected: "$['with space'][0]['ünïcode']",
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, test.input.String())
+		})
+	}
+}
+
+func Test_Path_child(t *testing.T) {
+	parent := Path{"one"}
+
+	first := parent.child("two")
+	second := parent.child(3)
+
+	assert.Equal(t, Path{"one"}, parent)
+	assert.Equal(t, Path{"one", "two"}, first)
+	assert.Equal(t, Path{"one", 3}, second)
+}
+
+func Test_Node_child(t *testing.T) {
+	node := &Node{Path: Path{"one"}, Value: map[string]interface{}{"two": 2}}
+	child := node.child("two", 2)
+
+	assert.Equal(t, &Node{Path: Path{"one", "two"}, Value: 2}, child)
+	assert.Equal(t, Path{"one"}, node.Path)
+}
+
+func Test_applyNext(t *testing.T) {
+	ctx := context.Background()
+	value := map[string]interface{}{"a": "one"}
+
+	actual, err := applyNext(ctx, nil, value, nil, "['b']")
+	assert.Nil(t, err)
+	assert.Equal(t, value, actual)
+
+	actual, err = applyNext(ctx, nil, value, []Token{&keyToken{key: "a"}}, "['b']")
+	assert.Nil(t, err)
+	assert.Equal(t, "one", actual)
+
+	actual, err = applyNext(ctx, nil, value, []Token{&keyToken{key: "a"}, &keyToken{key: "c"}}, "['b']")
+	assert.Nil(t, actual)
+	assert.EqualError(t, err, "key: invalid token target. expected [map] got [string]")
+	assert.Equal(t, &errors.QueryError{
+		Err:       err.(*errors.QueryError).Err,
+		Path:      "$['b']['a']",
+		TokenType: "key",
+		Token:     "['c']",
+		Kind:      reflect.String,
+	}, err)
+}
+
+func Test_applyNodesNext_error(t *testing.T) {
+	current := &Node{Path: Path{"b"}, Value: map[string]interface{}{"a": "one"}}
+
+	actual, err := applyNodesNext(context.Background(), nil, current, []Token{&keyToken{key: "c"}})
+	assert.Nil(t, actual)
+	assert.EqualError(t, err, "key: invalid token key 'c' not found")
+	assert.Equal(t, &errors.QueryError{
+		Err:       err.(*errors.QueryError).Err,
+		Path:      "$['b']",
+		TokenType: "key",
+		Token:     "['c']",
+		Kind:      reflect.Map,
+	}, err)
+}
+
+func Test_getPathSegment(t *testing.T) {
+	assert.Equal(t, "['a']", getPathSegment("a"))
+	assert.Equal(t, "[1]", getPathSegment(1))
+}
+
+func Test_isLeaf(t *testing.T) {
+	branch := &branchToken{}
+
+	assert.True(t, isLeaf(nil))
+	assert.True(t, isLeaf([]Token{branch}))
+	assert.False(t, isLeaf([]Token{&keyToken{key: "a"}}))
+	assert.False(t, isLeaf([]Token{branch, &keyToken{key: "a"}}))
+}
+
+func Test_applyNodesMissingLeaf(t *testing.T) {
+	current := &Node{Path: Path{"a"}}
+	reason := fmt.Errorf("missing")
+
+	actual, err := applyNodesMissingLeaf(current, nil, reason)
+	assert.Nil(t, err)
+	assert.Equal(t, []*Node{current}, actual)
+
+	node := &setNode{selectors: []int{0}, children: []*setNode{{descendants: []int{1}}}}
+	state := &setState{results: make([][]*Node, 2)}
+
+	actual, err = applyNodesMissingLeaf(current, []Token{&branchToken{node: node, state: state}}, reason)
+	assert.Nil(t, actual)
+	assert.Equal(t, &setError{errors: map[int]error{1: reason}}, err)
+	assert.Equal(t, [][]*Node{{current}, nil}, state.results)
+}
+
+func Test_getRequiredError(t *testing.T) {
+	notFound := getInvalidTokenKeyNotFoundError("key", "a")
+	other := getInvalidTokenOutOfRangeError("index")
+
+	assert.Equal(t, notFound, getRequiredError(notFound))
+	assert.Nil(t, getRequiredError(other))
+	assert.Equal(t, &setError{errors: map[int]error{0: notFound}}, getRequiredError(&setError{errors: map[int]error{0: notFound, 1: other}}))
+	assert.Nil(t, getRequiredError(&setError{errors: map[int]error{1: other}}))
+}
+
+func Test_collectElementNodes(t *testing.T) {
+	ctx := context.Background()
+	elements := []*Node{
+		{Path: Path{0}, Value: map[string]interface{}{"a": "one"}},
+		{Path: Path{1}, Value: map[string]interface{}{"b": "two"}},
+	}
+
+	actual, err := collectElementNodes(ctx, nil, elements, []Token{&keyToken{key: "a"}}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []*Node{{Path: Path{0, "a"}, Value: "one"}}, actual)
+
+	actual, err = collectElementNodes(ctx, nil, elements, []Token{&keyToken{key: "a"}}, true)
+	assert.Nil(t, actual)
+	assert.EqualError(t, err, "key: invalid token key 'a' not found")
+
+	actual, err = collectElementNodes(ctx, nil, elements, []Token{&indexToken{index: 0}}, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []*Node{}, actual)
+
+	t.Run("set", func(t *testing.T) {
+		set := NewSet([][]Token{
+			{&wildcardToken{requireProperties: true}, &keyToken{key: "a"}},
+			{&wildcardToken{requireProperties: true}, &keyToken{key: "b"}},
+		})
+
+		results, errs := set.ApplyNodes(ctx, []interface{}{
+			map[string]interface{}{"a": "one"},
+			map[string]interface{}{"a": "two", "b": "three"},
+		})
+		assert.Equal(t, [][]*Node{
+			{{Path: Path{0, "a"}, Value: "one"}, {Path: Path{1, "a"}, Value: "two"}},
+			nil,
+		}, results)
+		assert.Nil(t, errs[0])
+		assert.EqualError(t, errs[1], "key: invalid token key 'b' not found")
+	})
+}
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
@@ -10,18 +11,24 @@ import (
 func newRangeToken(from, to, step interface{}, options *option.QueryOptions) *rangeToken {
 	allowMap := false
 	allowString := false
+	leafToNull := false
+	requireProperties := false
 
 	if options != nil {
 		allowMap = options.AllowMapReferenceByIndex || options.AllowMapReferenceByIndexInRange
 		allowString = options.AllowStringReferenceByIndex || options.AllowStringReferenceByIndexInRange
+		leafToNull = options.DefaultPathLeafToNull
+		requireProperties = options.RequireProperties
 	}
 
 	return &rangeToken{
-		from:        from,
-		to:          to,
-		step:        step,
-		allowMap:    allowMap,
-		allowString: allowString,
+		from:              from,
+		to:                to,
+		step:              step,
+		allowMap:          allowMap,
+		allowString:       allowString,
+		leafToNull:        leafToNull,
+		requireProperties: requireProperties,
 	}
 }
 
@@ -29,6 +36,10 @@ type rangeToken struct {
 	from, to, step interface{}
 	allowMap       bool
 	allowString    bool
+	// leafToNull keep the nil results of the next tokens
+	leafToNull bool
+	// requireProperties fail when a key that follows the range is missing
+	requireProperties bool
 }
 
 func (token *rangeToken) String() string {
@@ -52,7 +63,79 @@ func (token *rangeToken) Type() string {
 	return "range"
 }
 
-func (token *rangeToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
+func (token *rangeToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	keys, values, isString, err := token.getElements(ctx, root, current)
+	if err != nil {
+		return nil, err
+	}
+
+	if isString {
+		substring := ""
+		for _, value := range values {
+			substring += value.(string)
+		}
+
+		return applyNext(ctx, root, substring, next, token.String())
+	}
+
+	var nextToken Token
+	forEach := false
+
+	if len(next) > 0 {
+		nextToken = next[0]
+
+		if _, ok := nextToken.(*indexToken); !ok {
+			forEach = true
+		}
+	}
+
+	elements := make([]interface{}, 0)
+	for idx, value := range values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		item, add, err := token.handleNext(ctx, root, keys[idx], value, forEach, next)
+		if err != nil {
+			return nil, err
+		}
+		if add {
+			elements = append(elements, item)
+		}
+	}
+
+	if !forEach && nextToken != nil {
+		return applyNext(ctx, root, elements, next, token.String())
+	}
+
+	return elements, nil
+}
+
+func (token *rangeToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	keys, values, _, err := token.getElements(ctx, root, current.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([]*Node, len(values))
+	for idx, value := range values {
+		elements[idx] = current.child(keys[idx], value)
+	}
+
+	if len(next) > 0 {
+		if indexToken, ok := next[0].(*indexToken); ok {
+			return traceNodes(ctx, next, len(elements), func() ([]*Node, error) {
+				return indexToken.applyToNodes(ctx, root, current.Path.String()+token.String(), elements, next[1:])
+			})
+		}
+	}
+
+	return collectElementNodes(ctx, root, elements, next, token.requireProperties)
+}
+
+// getElements returns the path elements and values of the items within the range.
+//
+// if the current value is a string the values will be the individual characters of the substring.
+func (token *rangeToken) getElements(ctx context.Context, root, current interface{}) ([]interface{}, []interface{}, bool, error) {
 
 	allowedType := []reflect.Kind{
 		reflect.Array,
@@ -67,32 +150,32 @@ func (token *rangeToken) Apply(root, current interface{}, next []Token) (interfa
 
 	objType, objVal := getTypeAndValue(current)
 	if objType == nil {
-		return nil, getInvalidTokenTargetNilError(
+		return nil, nil, false, getInvalidTokenTargetNilError(
 			token.Type(),
 			allowedType...,
 		)
 	}
 
 	var length int64
-	var mapKeys []reflect.Value
+	var mapKeys []string
+	var mapValues []interface{}
 	isString := false
 
-	switch objType.Kind() {
+	switch getKind(current, objType) {
 	case reflect.Map:
 		if !token.allowMap {
-			return nil, getInvalidTokenTargetError(
+			return nil, nil, false, getInvalidTokenTargetError(
 				token.Type(),
-				objType.Kind(),
+				reflect.Map,
 				allowedType...,
 			)
 		}
-		length = int64(objVal.Len())
-		mapKeys = objVal.MapKeys()
-		sortMapKeys(mapKeys)
+		mapKeys, mapValues = getMapElements(current, objVal)
+		length = int64(len(mapKeys))
 		break
 	case reflect.String:
 		if !token.allowString {
-			return nil, getInvalidTokenTargetError(
+			return nil, nil, false, getInvalidTokenTargetError(
 				token.Type(),
 				objType.Kind(),
 				allowedType...,
@@ -107,7 +190,7 @@ func (token *rangeToken) Apply(root, current interface{}, next []Token) (interfa
 		mapKeys = nil
 		break
 	default:
-		return nil, getInvalidTokenTargetError(
+		return nil, nil, false, getInvalidTokenTargetError(
 			token.Type(),
 			objType.Kind(),
 			allowedType...,
@@ -117,9 +200,9 @@ func (token *rangeToken) Apply(root, current interface{}, next []Token) (interfa
 	var from int64 = 0
 	if token.from != nil {
 		var err error
-		from, err = token.parseArgument(root, current, token.from)
+		from, err = token.parseArgument(ctx, root, current, token.from)
 		if err != nil {
-			return nil, err
+			return nil, nil, false, err
 		}
 		if from < 0 {
 			from = length + from
@@ -135,9 +218,9 @@ func (token *rangeToken) Apply(root, current interface{}, next []Token) (interfa
 	to := length
 	if token.to != nil {
 		var err error
-		to, err = token.parseArgument(root, current, token.to)
+		to, err = token.parseArgument(ctx, root, current, token.to)
 		if err != nil {
-			return nil, err
+			return nil, nil, false, err
 		}
 		if to < 0 {
 			to = length + to
@@ -154,109 +237,65 @@ func (token *rangeToken) Apply(root, current interface{}, next []Token) (interfa
 	var step int64 = 1
 	if token.step != nil {
 		var err error
-		step, err = token.parseArgument(root, current, token.step)
+		step, err = token.parseArgument(ctx, root, current, token.step)
 		if err != nil {
-			return nil, err
+			return nil, nil, false, err
 		}
 		if step == 0 {
-			return nil, getInvalidTokenOutOfRangeError(token.Type())
+			return nil, nil, false, getInvalidTokenOutOfRangeError(token.Type())
 		}
 	}
 
-	var nextToken Token
-	var futureTokens []Token
-	forEach := false
-
-	if len(next) > 0 {
-		nextToken = next[0]
-		futureTokens = next[1:]
-
-		if _, ok := nextToken.(*indexToken); !ok {
-			forEach = true
+	indices := make([]int64, 0)
+	if step < 0 {
+		for i := to - 1; i >= from; i += step {
+			indices = append(indices, i)
 		}
-	}
-
-	elements := make([]interface{}, 0)
-
-	if mapKeys != nil {
-		if step < 0 {
-			for i := to - 1; i >= from; i += step {
-				key := mapKeys[i]
-				item, add := token.handleNext(root, objVal.MapIndex(key).Interface(), forEach, nextToken, futureTokens)
-				if add {
-					elements = append(elements, item)
-				}
-			}
-		} else {
-			for i := from; i < to; i += step {
-				key := mapKeys[i]
-				item, add := token.handleNext(root, objVal.MapIndex(key).Interface(), forEach, nextToken, futureTokens)
-				if add {
-					elements = append(elements, item)
-				}
-			}
-		}
-	} else if isString {
-		substring := ""
-		if step < 0 {
-			for i := to - 1; i >= from; i += step {
-				value := objVal.Index(int(i)).Uint()
-				substring += fmt.Sprintf("%c", value)
-			}
-		} else {
-			for i := from; i < to; i += step {
-				value := objVal.Index(int(i)).Uint()
-				substring += fmt.Sprintf("%c", value)
-			}
+	} else {
+		for i := from; i < to; i += step {
+			indices = append(indices, i)
 		}
+	}
 
-		if len(next) > 0 {
-			return next[0].Apply(root, substring, next[1:])
-		}
+	keys := make([]interface{}, len(indices))
+	values := make([]interface{}, len(indices))
 
-		return substring, nil
-	} else {
-		if step < 0 {
-			for i := to - 1; i >= from; i += step {
-				item, add := token.handleNext(root, objVal.Index(int(i)).Interface(), forEach, nextToken, futureTokens)
-				if add {
-					elements = append(elements, item)
-				}
-			}
+	for idx, i := range indices {
+		if mapKeys != nil {
+			keys[idx] = mapKeys[i]
+			values[idx] = mapValues[i]
+		} else if isString {
+			keys[idx] = int(i)
+			values[idx] = fmt.Sprintf("%c", objVal.Index(int(i)).Uint())
 		} else {
-			for i := from; i < to; i += step {
-				item, add := token.handleNext(root, objVal.Index(int(i)).Interface(), forEach, nextToken, futureTokens)
-				if add {
-					elements = append(elements, item)
-				}
-			}
+			keys[idx] = int(i)
+			values[idx] = objVal.Index(int(i)).Interface()
 		}
 	}
 
-	if !forEach && nextToken != nil {
-		return nextToken.Apply(root, elements, futureTokens)
-	}
-
-	return elements, nil
+	return keys, values, isString, nil
 }
 
-func (token *rangeToken) handleNext(root, item interface{}, forEach bool, nextToken Token, futureTokens []Token) (interface{}, bool) {
+func (token *rangeToken) handleNext(ctx context.Context, root, key, item interface{}, forEach bool, next []Token) (interface{}, bool, error) {
 	if !forEach {
-		return item, true
+		return item, true, nil
 	}
-	val, err := nextToken.Apply(root, item, futureTokens)
+	val, err := applyNext(ctx, root, item, next, getPathSegment(key))
 	if err != nil {
-		return nil, false
+		if token.requireProperties {
+			return nil, false, getRequiredError(err)
+		}
+		return nil, false, nil
 	}
-	if val == nil {
-		return nil, false
+	if val == nil && !token.leafToNull {
+		return nil, false, nil
 	}
-	return val, true
+	return val, true, nil
 }
 
-func (token *rangeToken) parseArgument(root, current interface{}, argument interface{}) (int64, error) {
+func (token *rangeToken) parseArgument(ctx context.Context, root, current interface{}, argument interface{}) (int64, error) {
 	if script, ok := argument.(Token); ok {
-		result, err := script.Apply(root, current, nil)
+		result, err := script.Apply(ctx, root, current, nil)
 		if err != nil {
 			return 0, getInvalidTokenError(token.Type(), err)
 		}
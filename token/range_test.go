@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/ordered"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -103,6 +104,18 @@ func Test_newRangeToken(t *testing.T) {
 				allowString: true,
 			},
 		},
+		{
+			input: input{
+				options: &option.QueryOptions{
+					DefaultPathLeafToNull: true,
+					RequireProperties:     true,
+				},
+			},
+			expected: &rangeToken{
+				leafToNull:        true,
+				requireProperties: true,
+			},
+		},
 	}
 
 	for idx, test := range tests {
@@ -1185,7 +1198,7 @@ var rangeTests = []*tokenTest{
 		token: &rangeToken{},
 		input: input{
 			current: []string{"one", "two", "three", "four", "five"},
-			tokens:  []Token{&keyToken{"key"}},
+			tokens:  []Token{&keyToken{key: "key"}},
 		},
 		expected: expected{
 			value: []interface{}{},
@@ -1201,4 +1214,127 @@ var rangeTests = []*tokenTest{
 			value: []interface{}{},
 		},
 	},
+	{
+		token: &rangeToken{from: 0, to: 2, leafToNull: true},
+		input: input{
+			current: []interface{}{
+				map[string]interface{}{"key": "one"},
+				map[string]interface{}{"other": "two"},
+			},
+			tokens: []Token{&keyToken{key: "key", leafToNull: true}},
+		},
+		expected: expected{
+			value: []interface{}{"one", nil},
+		},
+	},
+	{
+		token: &rangeToken{from: 0, to: 2, requireProperties: true},
+		input: input{
+			current: []interface{}{
+				map[string]interface{}{"key": "one"},
+				map[string]interface{}{"other": "two"},
+			},
+			tokens: []Token{&keyToken{key: "key"}},
+		},
+		expected: expected{
+			err: "key: invalid token key 'key' not found",
+		},
+	},
+	{
+		token: &rangeToken{from: 1, to: nil, allowMap: true},
+		input: input{
+			current: ordered.NewObject(
+				ordered.Member{Key: "three", Value: 3},
+				ordered.Member{Key: "one", Value: 1},
+				ordered.Member{Key: "two", Value: 2},
+			),
+		},
+		expected: expected{
+			value: []interface{}{1, 2},
+		},
+	},
+}
+
+func Test_RangeToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &rangeToken{from: 0, to: 1},
+			input: nodesInput{
+				root: map[string]interface{}{},
+			},
+			expected: nodesExpected{
+				err: "range: invalid token target. expected [array slice] got [map]",
+			},
+		},
+		{
+			token: &rangeToken{from: 1, to: nil},
+			input: nodesInput{
+				root: []interface{}{"one", "two", "three"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[1]", "$[2]"},
+				values: []interface{}{"two", "three"},
+			},
+		},
+		{
+			token: &rangeToken{from: nil, to: nil, step: -2},
+			input: nodesInput{
+				root: []interface{}{"one", "two", "three"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[2]", "$[0]"},
+				values: []interface{}{"three", "one"},
+			},
+		},
+		{
+			token: &rangeToken{from: 0, to: 2, allowMap: true},
+			input: nodesInput{
+				root: map[string]interface{}{"c": 3, "b": 2, "a": 1},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['a']", "$['b']"},
+				values: []interface{}{1, 2},
+			},
+		},
+		{
+			token: &rangeToken{from: 0, to: 3, allowString: true},
+			input: nodesInput{
+				root: "string",
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[0]", "$[1]", "$[2]"},
+				values: []interface{}{"s", "t", "r"},
+			},
+		},
+		{
+			token: &rangeToken{from: 1, to: nil},
+			input: nodesInput{
+				root: []interface{}{"one", "two", "three"},
+				tokens: []Token{
+					&indexToken{index: -1},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[2]"},
+				values: []interface{}{"three"},
+			},
+		},
+		{
+			token: &rangeToken{from: 0, to: nil},
+			input: nodesInput{
+				root: []interface{}{
+					map[string]interface{}{"key": "one"},
+					"two",
+					map[string]interface{}{"key": "three"},
+				},
+				tokens: []Token{
+					&keyToken{key: "key"},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[0]['key']", "$[2]['key']"},
+				values: []interface{}{"one", "three"},
+			},
+		},
+	})
 }
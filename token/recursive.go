@@ -1,14 +1,25 @@
 package token
 
 import (
+	"context"
 	"reflect"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
 )
 
-func newRecursiveToken() *recursiveToken {
-	return &recursiveToken{}
+func newRecursiveToken(options *option.QueryOptions) *recursiveToken {
+	limits := Limits(options)
+	return &recursiveToken{
+		maxDepth:   limits.MaxRecursionDepth,
+		maxResults: limits.MaxResults,
+	}
 }
 
 type recursiveToken struct {
+	// maxDepth the maximum depth below the current value to descend to, not enforced if zero
+	maxDepth int
+	// maxResults the maximum number of results to collect, not enforced if zero
+	maxResults int
 }
 
 func (token *recursiveToken) String() string {
@@ -19,21 +30,30 @@ func (token *recursiveToken) Type() string {
 	return "recursive"
 }
 
-func (token *recursiveToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
-	return token.recursiveApply(root, current, next), nil
+func (token *recursiveToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	return token.recursiveApply(ctx, root, current, next, 0)
 }
 
-func (token *recursiveToken) recursiveApply(root, current interface{}, next []Token) []interface{} {
+func (token *recursiveToken) recursiveApply(ctx context.Context, root, current interface{}, next []Token, depth int) ([]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := checkRecursionDepth(depth, token.maxDepth); err != nil {
+		return nil, err
+	}
 
 	slice := make([]interface{}, 0)
 
 	objType, objVal := getTypeAndValue(current)
 	if objType == nil {
-		return slice
+		return slice, nil
 	}
 
 	if len(next) > 0 {
-		result, _ := next[0].Apply(root, objVal.Interface(), next[1:])
+		result, _ := next[0].Apply(ctx, root, objVal.Interface(), next[1:])
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		objType, objVal := getTypeAndValue(result)
 		if objType != nil {
 			switch objType.Kind() {
@@ -52,33 +72,142 @@ func (token *recursiveToken) recursiveApply(root, current interface{}, next []To
 		slice = append(slice, objVal.Interface())
 	}
 
-	switch objType.Kind() {
+	switch getKind(current, objType) {
 	case reflect.Map:
-		keys := objVal.MapKeys()
-		sortMapKeys(keys)
-		for _, kv := range keys {
-			value := objVal.MapIndex(kv).Interface()
-			result := token.recursiveApply(root, value, next)
+		_, values := getMapElements(current, objVal)
+		for _, value := range values {
+			result, err := token.recursiveApply(ctx, root, value, next, depth+1)
+			if err != nil {
+				return nil, err
+			}
 			slice = append(slice, result...)
+			if err := checkResultCount(len(slice), token.maxResults); err != nil {
+				return nil, err
+			}
 		}
 	case reflect.Array, reflect.Slice:
 		length := objVal.Len()
 		for i := 0; i < length; i++ {
 			value := objVal.Index(i).Interface()
-			result := token.recursiveApply(root, value, next)
+			result, err := token.recursiveApply(ctx, root, value, next, depth+1)
+			if err != nil {
+				return nil, err
+			}
 			slice = append(slice, result...)
+			if err := checkResultCount(len(slice), token.maxResults); err != nil {
+				return nil, err
+			}
 		}
 	case reflect.Struct:
+		if objType == timeType {
+			break
+		}
 		fields := getStructFields(objVal, true)
-		for _, field := range fields {
-			value := objVal.FieldByName(field.Name).Interface()
-			result := token.recursiveApply(root, value, next)
+		for _, name := range getStructFieldNames(fields) {
+			value := objVal.FieldByName(fields[name].Name).Interface()
+			result, err := token.recursiveApply(ctx, root, value, next, depth+1)
+			if err != nil {
+				return nil, err
+			}
 			slice = append(slice, result...)
+			if err := checkResultCount(len(slice), token.maxResults); err != nil {
+				return nil, err
+			}
 
 		}
 	default:
 		break
 	}
 
-	return slice
+	return slice, nil
+}
+
+func (token *recursiveToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	return token.recursiveApplyNodes(ctx, root, current, next, 0)
+}
+
+func (token *recursiveToken) recursiveApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token, depth int) ([]*Node, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := checkRecursionDepth(depth, token.maxDepth); err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*Node, 0)
+
+	objType, objVal := getTypeAndValue(current.Value)
+	if objType == nil {
+		return nodes, nil
+	}
+
+	nodes = append(nodes, collectNodes(ctx, root, current, next)...)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	switch getKind(current.Value, objType) {
+	case reflect.Map:
+		keys, values := getMapElements(current.Value, objVal)
+		for idx, key := range keys {
+			child := current.child(key, values[idx])
+			children, err := token.recursiveApplyNodes(ctx, root, child, next, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, children...)
+			if err := checkResultCount(len(nodes), token.maxResults); err != nil {
+				return nil, err
+			}
+		}
+	case reflect.Array, reflect.Slice:
+		length := objVal.Len()
+		for i := 0; i < length; i++ {
+			child := current.child(i, objVal.Index(i).Interface())
+			children, err := token.recursiveApplyNodes(ctx, root, child, next, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, children...)
+			if err := checkResultCount(len(nodes), token.maxResults); err != nil {
+				return nil, err
+			}
+		}
+	case reflect.Struct:
+		if objType == timeType {
+			break
+		}
+		fields := getStructFields(objVal, true)
+		for _, name := range getStructFieldNames(fields) {
+			child := current.child(name, objVal.FieldByName(fields[name].Name).Interface())
+			children, err := token.recursiveApplyNodes(ctx, root, child, next, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, children...)
+			if err := checkResultCount(len(nodes), token.maxResults); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		break
+	}
+
+	return nodes, nil
+}
+
+// checkRecursionDepth returns an error if the depth exceeds the maximum, which is not enforced if zero
+func checkRecursionDepth(depth, maximum int) error {
+	if maximum > 0 && depth > maximum {
+		return getLimitExceededError("recursion depth", maximum)
+	}
+	return nil
+}
+
+// checkResultCount returns an error if the count exceeds the maximum, which is not enforced if zero
+func checkResultCount(count, maximum int) error {
+	if maximum > 0 && count > maximum {
+		return getLimitExceededError("result count", maximum)
+	}
+	return nil
 }
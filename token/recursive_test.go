@@ -1,8 +1,14 @@
 package token
 
 import (
+	"context"
+	goErr "errors"
+	"fmt"
 	"testing"
 
+	"github.com/evilmonkeyinc/jsonpath/errors"
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/ordered"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -10,7 +16,7 @@ import (
 var _ Token = &recursiveToken{}
 
 func Test_newRecursiveToken(t *testing.T) {
-	assert.IsType(t, &recursiveToken{}, newRecursiveToken())
+	assert.IsType(t, &recursiveToken{}, newRecursiveToken(nil))
 }
 
 func Test_RecursiveToken_String(t *testing.T) {
@@ -284,6 +290,32 @@ var recursiveTokenTests = []*tokenTest{
 			},
 		},
 	},
+	{
+		token: &recursiveToken{},
+		input: input{
+			current: ordered.NewObject(
+				ordered.Member{Key: "b", Value: ordered.NewObject(ordered.Member{Key: "key", Value: "first"})},
+				ordered.Member{Key: "a", Value: []interface{}{ordered.NewObject(ordered.Member{Key: "key", Value: "second"})}},
+			),
+			tokens: []Token{&keyToken{key: "key"}},
+		},
+		expected: expected{
+			value: []interface{}{"first", "second"},
+		},
+	},
+	{
+		token: &recursiveToken{},
+		input: input{
+			current: map[int]interface{}{
+				10: map[string]interface{}{"key": "ten"},
+				9:  map[string]interface{}{"key": "nine"},
+			},
+			tokens: []Token{&keyToken{key: "key"}},
+		},
+		expected: expected{
+			value: []interface{}{"nine", "ten"},
+		},
+	},
 }
 
 func Test_RecursiveToken_Apply(t *testing.T) {
@@ -293,3 +325,92 @@ func Test_RecursiveToken_Apply(t *testing.T) {
 func Benchmark_RecursiveToken_Apply(b *testing.B) {
 	batchTokenBenchmarks(b, recursiveTokenTests)
 }
+
+func Test_RecursiveToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &recursiveToken{},
+			input: nodesInput{
+				root: nil,
+			},
+			expected: nodesExpected{
+				paths: []string{},
+			},
+		},
+		{
+			token: &recursiveToken{},
+			input: nodesInput{
+				root: map[string]interface{}{
+					"a": []interface{}{"one"},
+					"b": "two",
+				},
+			},
+			expected: nodesExpected{
+				paths: []string{"$", "$['a']", "$['a'][0]", "$['b']"},
+			},
+		},
+		{
+			token: &recursiveToken{},
+			input: nodesInput{
+				root: map[string]interface{}{
+					"key": "one",
+					"nested": []interface{}{
+						map[string]interface{}{"key": "two"},
+						map[string]interface{}{"other": "three"},
+					},
+				},
+				tokens: []Token{
+					&keyToken{key: "key"},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['key']", "$['nested'][0]['key']"},
+				values: []interface{}{"one", "two"},
+			},
+		},
+	})
+}
+
+func Test_RecursiveToken_limits(t *testing.T) {
+
+	input := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": map[string]interface{}{
+				"c": "value",
+			},
+		},
+		"d": []interface{}{1, 2, 3},
+	}
+
+	tests := []struct {
+		limits   option.Limits
+		expected int
+		err      string
+	}{
+		{limits: option.Limits{}, expected: 8},
+		{limits: option.Limits{MaxRecursionDepth: 3, MaxResults: 8}, expected: 8},
+		{limits: option.Limits{MaxRecursionDepth: 2}, err: "limit exceeded. recursion depth exceeds maximum of 2"},
+		{limits: option.Limits{MaxResults: 4}, err: "limit exceeded. result count exceeds maximum of 4"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			token := newRecursiveToken(&option.QueryOptions{Limits: test.limits})
+
+			actual, err := token.Apply(context.Background(), input, input, nil)
+			nodes, nodesErr := token.ApplyNodes(context.Background(), input, &Node{Path: Path{}, Value: input}, nil)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.True(t, goErr.Is(err, errors.ErrLimitExceeded))
+				assert.Nil(t, actual)
+				assert.EqualError(t, nodesErr, test.err)
+				assert.Nil(t, nodes)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, actual, test.expected)
+			assert.Nil(t, nodesErr)
+			assert.Len(t, nodes, test.expected)
+		})
+	}
+}
@@ -0,0 +1,503 @@
+package token
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/script"
+)
+
+const (
+	// the I-JSON range for integers, as required for RFC 9535 indices and slices
+	maxRFC9535Integer int64 = 1<<53 - 1
+	minRFC9535Integer int64 = -(1<<53 - 1)
+)
+
+// ParseRFC9535 will parse the JSONPath selector as an RFC 9535 query and return the actionable tokens.
+//
+// The first token will represent the root identifier and each following token a child or descendant segment.
+func ParseRFC9535(selector string, engine script.Engine, options *option.QueryOptions) ([]Token, error) {
+	if !strings.HasPrefix(selector, "$") {
+		return nil, NewSyntaxError(getUnexpectedTokenError(firstCharacter(selector), 0), selector, 0, firstCharacter(selector), "$")
+	}
+	limits := Limits(options)
+	if limits.MaxSelectorLength > 0 && len(selector) > limits.MaxSelectorLength {
+		return nil, getLimitExceededError("selector length", limits.MaxSelectorLength)
+	}
+
+	parser := &rfc9535Parser{
+		source:  selector,
+		engine:  engine,
+		options: options,
+	}
+
+	tokens, err := parser.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	if !parser.atEnd() {
+		return nil, parser.unexpected()
+	}
+	if limits.MaxTokens > 0 && len(tokens) > limits.MaxTokens {
+		return nil, getLimitExceededError("token count", limits.MaxTokens)
+	}
+	return tokens, nil
+}
+
+// ParseRFC9535Query will parse the RFC 9535 query at the start of the expression, as used within filter expressions,
+// returning the actionable tokens and the number of bytes of the expression that make up the query.
+//
+// The query can start with either the root $ or current @ identifier.
+func ParseRFC9535Query(expression string, engine script.Engine, options *option.QueryOptions) ([]Token, int, error) {
+	if !strings.HasPrefix(expression, "$") && !strings.HasPrefix(expression, "@") {
+		return nil, 0, NewSyntaxError(getUnexpectedTokenError(firstCharacter(expression), 0), expression, 0, firstCharacter(expression), "$", "@")
+	}
+
+	parser := &rfc9535Parser{
+		source:  expression,
+		engine:  engine,
+		options: options,
+	}
+
+	tokens, err := parser.parseQuery()
+	if err != nil {
+		return nil, 0, err
+	}
+	return tokens, parser.idx, nil
+}
+
+// IsSingularQuery returns true if the tokens represent an RFC 9535 singular query,
+// one which can only ever return a single node, made up of only name and index selectors.
+func IsSingularQuery(tokens []Token) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	for _, token := range tokens[1:] {
+		segment, ok := token.(*segmentToken)
+		if !ok || segment.descendant || len(segment.selectors) != 1 {
+			return false
+		}
+		switch segment.selectors[0].(type) {
+		case *keyToken, *indexToken:
+			continue
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+type rfc9535Parser struct {
+	source  string
+	idx     int
+	engine  script.Engine
+	options *option.QueryOptions
+}
+
+func (parser *rfc9535Parser) atEnd() bool {
+	return parser.idx >= len(parser.source)
+}
+
+func (parser *rfc9535Parser) peek() byte {
+	if parser.atEnd() {
+		return 0
+	}
+	return parser.source[parser.idx]
+}
+
+func (parser *rfc9535Parser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(parser.source[parser.idx:], prefix)
+}
+
+func (parser *rfc9535Parser) skipBlank() {
+	for !parser.atEnd() && isRFC9535Blank(parser.peek()) {
+		parser.idx++
+	}
+}
+
+func (parser *rfc9535Parser) unexpected(expected ...string) error {
+	found := firstCharacter(parser.source[parser.idx:])
+	return NewSyntaxError(getUnexpectedTokenError(found, parser.idx), parser.source, parser.idx, found, expected...)
+}
+
+func (parser *rfc9535Parser) expect(expected byte) error {
+	if parser.peek() != expected {
+		return parser.unexpected(string(expected))
+	}
+	parser.idx++
+	return nil
+}
+
+// parseQuery parses the identifier and all following segments
+func (parser *rfc9535Parser) parseQuery() ([]Token, error) {
+	tokens := make([]Token, 0)
+
+	switch parser.peek() {
+	case '$':
+		tokens = append(tokens, newRootToken())
+	case '@':
+		tokens = append(tokens, newCurrentToken())
+	default:
+		return nil, parser.unexpected()
+	}
+	parser.idx++
+
+	for {
+		// blank space is allowed between segments, but not after the last segment
+		start := parser.idx
+		parser.skipBlank()
+		if next := parser.peek(); next != '.' && next != '[' {
+			parser.idx = start
+			break
+		}
+
+		segment, err := parser.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, segment)
+	}
+
+	return tokens, nil
+}
+
+func (parser *rfc9535Parser) parseSegment() (Token, error) {
+	descendant := false
+	if parser.hasPrefix("..") {
+		descendant = true
+		parser.idx += 2
+	} else if parser.peek() == '.' {
+		parser.idx++
+	} else {
+		selectors, err := parser.parseBracketedSelection()
+		if err != nil {
+			return nil, err
+		}
+		return newSegmentToken(selectors, false, parser.options), nil
+	}
+
+	switch next := parser.peek(); {
+	case next == '[' && descendant:
+		selectors, err := parser.parseBracketedSelection()
+		if err != nil {
+			return nil, err
+		}
+		return newSegmentToken(selectors, true, parser.options), nil
+	case next == '*':
+		parser.idx++
+		return newSegmentToken([]Token{newWildcardToken(nil)}, descendant, parser.options), nil
+	default:
+		name, err := parser.parseMemberNameShorthand()
+		if err != nil {
+			return nil, err
+		}
+		return newSegmentToken([]Token{newKeyToken(name, nil)}, descendant, parser.options), nil
+	}
+}
+
+func (parser *rfc9535Parser) parseMemberNameShorthand() (string, error) {
+	start := parser.idx
+	for idx, rne := range parser.source[start:] {
+		isFirst := rne == '_' || (rne >= 'a' && rne <= 'z') || (rne >= 'A' && rne <= 'Z') || rne >= 0x80
+		isDigit := rne >= '0' && rne <= '9'
+		if isFirst || (isDigit && idx > 0) {
+			parser.idx = start + idx + len(string(rne))
+			continue
+		}
+		break
+	}
+	if parser.idx == start {
+		return "", parser.unexpected()
+	}
+	return parser.source[start:parser.idx], nil
+}
+
+func (parser *rfc9535Parser) parseBracketedSelection() ([]Token, error) {
+	if err := parser.expect('['); err != nil {
+		return nil, err
+	}
+
+	selectors := make([]Token, 0)
+	for {
+		parser.skipBlank()
+		selector, err := parser.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+
+		parser.skipBlank()
+		switch parser.peek() {
+		case ',':
+			parser.idx++
+			continue
+		case ']':
+			parser.idx++
+			return selectors, nil
+		default:
+			return nil, parser.unexpected()
+		}
+	}
+}
+
+func (parser *rfc9535Parser) parseSelector() (Token, error) {
+	switch next := parser.peek(); {
+	case next == '\'' || next == '"':
+		name, err := parser.parseStringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return newKeyToken(name, nil), nil
+	case next == '*':
+		parser.idx++
+		return newWildcardToken(nil), nil
+	case next == '?':
+		parser.idx++
+		parser.skipBlank()
+		start := parser.idx
+		end := findRFC9535FilterEnd(parser.source, start)
+		expression := strings.TrimRightFunc(parser.source[start:end], func(rne rune) bool {
+			return rne < 0x80 && isRFC9535Blank(byte(rne))
+		})
+		if expression == "" {
+			return nil, parser.unexpected()
+		}
+		parser.idx = start + len(expression)
+
+		filter, err := newFilterToken(expression, parser.engine, parser.options)
+		if err != nil {
+			return nil, getInvalidExpressionError(RelocateSyntaxError(err, parser.source, start))
+		}
+		return filter, nil
+	case next == '-' || next == ':' || (next >= '0' && next <= '9'):
+		return parser.parseIndexOrSlice()
+	default:
+		return nil, parser.unexpected()
+	}
+}
+
+func (parser *rfc9535Parser) parseIndexOrSlice() (Token, error) {
+	var start, end, step *int64
+
+	if parser.peek() != ':' {
+		index, err := parser.parseInteger()
+		if err != nil {
+			return nil, err
+		}
+		start = &index
+
+		parser.skipBlank()
+		if parser.peek() != ':' {
+			return newIndexToken(index, nil), nil
+		}
+	}
+
+	// slice selector
+	parser.idx++
+	parser.skipBlank()
+	if next := parser.peek(); next == '-' || (next >= '0' && next <= '9') {
+		value, err := parser.parseInteger()
+		if err != nil {
+			return nil, err
+		}
+		end = &value
+		parser.skipBlank()
+	}
+
+	if parser.peek() == ':' {
+		parser.idx++
+		parser.skipBlank()
+		if next := parser.peek(); next == '-' || (next >= '0' && next <= '9') {
+			value, err := parser.parseInteger()
+			if err != nil {
+				return nil, err
+			}
+			step = &value
+		}
+	}
+
+	return newSliceToken(start, end, step), nil
+}
+
+func (parser *rfc9535Parser) parseInteger() (int64, error) {
+	start := parser.idx
+	if parser.peek() == '-' {
+		parser.idx++
+	}
+	digitsStart := parser.idx
+	for !parser.atEnd() && parser.peek() >= '0' && parser.peek() <= '9' {
+		parser.idx++
+	}
+
+	digits := parser.source[digitsStart:parser.idx]
+	if digits == "" {
+		return 0, parser.unexpected()
+	}
+	if len(digits) > 1 && digits[0] == '0' {
+		// leading zeros are not allowed
+		parser.idx = digitsStart + 1
+		return 0, parser.unexpected()
+	}
+
+	text := parser.source[start:parser.idx]
+	if text == "-0" {
+		parser.idx = start
+		return 0, parser.unexpected()
+	}
+
+	value, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || value > maxRFC9535Integer || value < minRFC9535Integer {
+		parser.idx = start
+		return 0, getInvalidTokenOutOfRangeError("index")
+	}
+	return value, nil
+}
+
+func (parser *rfc9535Parser) parseStringLiteral() (string, error) {
+	value, length, err := parseRFC9535StringLiteral(parser.source[parser.idx:])
+	if err != nil {
+		parser.idx += length
+		return "", parser.unexpected()
+	}
+	parser.idx += length
+	return value, nil
+}
+
+// parseRFC9535StringLiteral parses the quoted string literal at the start of the source and
+// returns the unescaped value and the length of the literal, or the offset of the invalid character.
+func parseRFC9535StringLiteral(source string) (string, int, error) {
+	if source == "" || (source[0] != '\'' && source[0] != '"') {
+		return "", 0, getUnexpectedTokenError(firstCharacter(source), 0)
+	}
+	quote := rune(source[0])
+
+	builder := strings.Builder{}
+	escaped := false
+	var highSurrogate rune = -1
+
+	for idx := 1; idx < len(source); {
+		rne, size := utf8.DecodeRuneInString(source[idx:])
+
+		if highSurrogate >= 0 && !escaped && !strings.HasPrefix(source[idx:], `\u`) {
+			return "", idx, getUnexpectedTokenError(string(rne), idx)
+		}
+
+		if escaped {
+			escaped = false
+			switch rne {
+			case 'b':
+				builder.WriteRune('\b')
+			case 'f':
+				builder.WriteRune('\f')
+			case 'n':
+				builder.WriteRune('\n')
+			case 'r':
+				builder.WriteRune('\r')
+			case 't':
+				builder.WriteRune('\t')
+			case '/', '\\':
+				builder.WriteRune(rne)
+			case 'u':
+				if len(source) < idx+5 {
+					return "", idx, getUnexpectedTokenError(string(rne), idx)
+				}
+				code, err := strconv.ParseUint(source[idx+1:idx+5], 16, 32)
+				if err != nil {
+					return "", idx, getUnexpectedTokenError(string(rne), idx)
+				}
+				point := rune(code)
+				switch {
+				case highSurrogate >= 0:
+					if point < 0xDC00 || point > 0xDFFF {
+						return "", idx, getUnexpectedTokenError(string(rne), idx)
+					}
+					builder.WriteRune(utf16.DecodeRune(highSurrogate, point))
+					highSurrogate = -1
+				case point >= 0xD800 && point <= 0xDBFF:
+					highSurrogate = point
+				case point >= 0xDC00 && point <= 0xDFFF:
+					return "", idx, getUnexpectedTokenError(string(rne), idx)
+				default:
+					builder.WriteRune(point)
+				}
+				idx += 5
+				continue
+			default:
+				if rne != quote {
+					return "", idx, getUnexpectedTokenError(string(rne), idx)
+				}
+				builder.WriteRune(rne)
+			}
+			idx += size
+			continue
+		}
+
+		switch {
+		case rne == '\\':
+			escaped = true
+		case rne == quote:
+			return builder.String(), idx + size, nil
+		case rne < 0x20:
+			return "", idx, getUnexpectedTokenError(string(rne), idx)
+		default:
+			builder.WriteRune(rne)
+		}
+		idx += size
+	}
+
+	return "", len(source), getUnexpectedTokenError("", len(source))
+}
+
+// findRFC9535FilterEnd returns the index of the end of the filter expression that starts at the start index,
+// which is the first comma or closing bracket that is not part of a string literal or nested brackets.
+func findRFC9535FilterEnd(source string, start int) int {
+	depth := 0
+	var quote byte
+	escaped := false
+
+	for idx := start; idx < len(source); idx++ {
+		char := source[idx]
+		if quote != 0 {
+			if escaped {
+				escaped = false
+			} else if char == '\\' {
+				escaped = true
+			} else if char == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch char {
+		case '\'', '"':
+			quote = char
+		case '(', '[':
+			depth++
+		case ')', ']':
+			if depth == 0 {
+				return idx
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				return idx
+			}
+		}
+	}
+	return len(source)
+}
+
+func isRFC9535Blank(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == '\r'
+}
+
+func firstCharacter(source string) string {
+	if source == "" {
+		return ""
+	}
+	rne, _ := utf8.DecodeRuneInString(source)
+	return string(rne)
+}
@@ -0,0 +1,192 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseRFC9535(t *testing.T) {
+
+	tests := []struct {
+		selector string
+		expected string
+		err      string
+	}{
+		{selector: "$", expected: "$"},
+		{selector: "$.store.book[*].author", expected: "$['store']['book'][*]['author']"},
+		{selector: "$..author", expected: "$..['author']"},
+		{selector: "$.*", expected: "$[*]"},
+		{selector: "$..*", expected: "$..[*]"},
+		{selector: "$..[0,'a']", expected: "$..[0,'a']"},
+		{selector: "$[ 'a' , \"b\" ][ 0 ]", expected: "$['a','b'][0]"},
+		{selector: "$ .a [0]", expected: "$['a'][0]"},
+		{selector: "$['it\\'s']", expected: "$['it\\'s']"},
+		{selector: "$[\"\\u00e9\\n\"]", expected: "$['é\\n']"},
+		{selector: "$['\\uD83D\\uDE00']", expected: "$['😀']"},
+		{selector: "$.é_1", expected: "$['é_1']"},
+		{selector: "$[-1]", expected: "$[-1]"},
+		{selector: "$[1:3]", expected: "$[1:3]"},
+		{selector: "$[::-1]", expected: "$[::-1]"},
+		{selector: "$[ 1 : 5 : 2 ]", expected: "$[1:5:2]"},
+		{selector: "$[:]", expected: "$[:]"},
+		{selector: "$[?@.a]", expected: "$[?@.a]"},
+		{selector: "$[?@.a == 'x,]', 1]", expected: "$[?@.a == 'x,]',1]"},
+		{selector: "$[?(@.a[0])]", expected: "$[?(@.a[0])]"},
+		{selector: "", err: "unexpected token '' at index 0"},
+		{selector: " $", err: "unexpected token ' ' at index 0"},
+		{selector: "$ ", err: "unexpected token ' ' at index 1"},
+		{selector: "@.a", err: "unexpected token '@' at index 0"},
+		{selector: "$.", err: "unexpected token '' at index 2"},
+		{selector: "$..", err: "unexpected token '' at index 3"},
+		{selector: "$. a", err: "unexpected token ' ' at index 2"},
+		{selector: "$.1a", err: "unexpected token '1' at index 2"},
+		{selector: "$.length()", err: "unexpected token '(' at index 8"},
+		{selector: "$[]", err: "unexpected token ']' at index 2"},
+		{selector: "$['a'", err: "unexpected token '' at index 5"},
+		{selector: "$['a',]", err: "unexpected token ']' at index 6"},
+		{selector: "$['a\\x']", err: "unexpected token 'x' at index 5"},
+		{selector: "$[\"a\\'\"]", err: "unexpected token ''' at index 5"},
+		{selector: "$['\\uDE00']", err: "unexpected token 'u' at index 4"},
+		{selector: "$['\\uD83D']", err: "unexpected token ''' at index 9"},
+		{selector: "$['\n']", err: "unexpected token '\n' at index 3"},
+		{selector: "$[01]", err: "unexpected token '1' at index 3"},
+		{selector: "$[-0]", err: "unexpected token '-' at index 2"},
+		{selector: "$[-]", err: "unexpected token ']' at index 3"},
+		{selector: "$[9007199254740992]", err: "index: invalid token out of range"},
+		{selector: "$[(@.length-1)]", err: "unexpected token '(' at index 2"},
+		{selector: "$[?]", err: "unexpected token ']' at index 3"},
+		{selector: "$[?@.a", err: "unexpected token '' at index 6"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			tokens, err := ParseRFC9535(test.selector, &testEngine{compiledExpression: &testCompiledExpression{}}, nil)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.Nil(t, tokens)
+				return
+			}
+			assert.Nil(t, err)
+
+			builder := strings.Builder{}
+			for _, token := range tokens {
+				builder.WriteString(token.String())
+			}
+			assert.Equal(t, test.expected, builder.String())
+		})
+	}
+
+	t.Run("engine error", func(t *testing.T) {
+		tokens, err := ParseRFC9535("$[?@.a]", &testEngine{err: fmt.Errorf("engine error")}, nil)
+		assert.EqualError(t, err, "invalid expression. engine error")
+		assert.Nil(t, tokens)
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		options := &option.QueryOptions{Limits: option.Limits{MaxSelectorLength: 12, MaxTokens: 3}}
+
+		tokens, err := ParseRFC9535("$.a.b", nil, options)
+		assert.Nil(t, err)
+		assert.Len(t, tokens, 3)
+
+		tokens, err = ParseRFC9535("$.a.b.c", nil, options)
+		assert.EqualError(t, err, "limit exceeded. token count exceeds maximum of 3")
+		assert.Nil(t, tokens)
+
+		tokens, err = ParseRFC9535("$['abcdefgh']", nil, options)
+		assert.EqualError(t, err, "limit exceeded. selector length exceeds maximum of 12")
+		assert.Nil(t, tokens)
+	})
+}
+
+func Test_ParseRFC9535Query(t *testing.T) {
+
+	tests := []struct {
+		expression string
+		expected   string
+		length     int
+		err        string
+	}{
+		{expression: "@", expected: "@", length: 1},
+		{expression: "@.a == 1", expected: "@['a']", length: 3},
+		{expression: "$.a[0] && @", expected: "$['a'][0]", length: 6},
+		{expression: "@ .a .b)", expected: "@['a']['b']", length: 7},
+		{expression: "1 == @", err: "unexpected token '1' at index 0"},
+		{expression: "@.", err: "unexpected token '' at index 2"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			tokens, length, err := ParseRFC9535Query(test.expression, &testEngine{}, nil)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.Nil(t, tokens)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.length, length)
+
+			builder := strings.Builder{}
+			for _, token := range tokens {
+				builder.WriteString(token.String())
+			}
+			assert.Equal(t, test.expected, builder.String())
+		})
+	}
+}
+
+func Test_IsSingularQuery(t *testing.T) {
+
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{expression: "@", expected: true},
+		{expression: "$.a[0]['b']", expected: true},
+		{expression: "@.a[0,1]", expected: false},
+		{expression: "@.*", expected: false},
+		{expression: "@..a", expected: false},
+		{expression: "@[1:2]", expected: false},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			tokens, _, err := ParseRFC9535Query(test.expression, &testEngine{}, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, IsSingularQuery(tokens))
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		assert.False(t, IsSingularQuery(nil))
+	})
+	t.Run("legacy", func(t *testing.T) {
+		assert.False(t, IsSingularQuery([]Token{&rootToken{}, &keyToken{key: "a"}}))
+	})
+}
+
+func Test_findRFC9535FilterEnd(t *testing.T) {
+
+	tests := []struct {
+		source   string
+		expected int
+	}{
+		{source: "", expected: 0},
+		{source: "@.a]", expected: 3},
+		{source: "@.a,1]", expected: 3},
+		{source: "@[0,1]]", expected: 6},
+		{source: "(@.a || @.b),1]", expected: 12},
+		{source: "@.a == ']'", expected: 10},
+		{source: "@.a == \"\\\",\"]", expected: 12},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, findRFC9535FilterEnd(test.source, 0))
+		})
+	}
+}
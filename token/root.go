@@ -1,5 +1,7 @@
 package token
 
+import "context"
+
 func newRootToken() *rootToken {
 	return &rootToken{}
 }
@@ -15,9 +17,10 @@ func (token *rootToken) Type() string {
 	return "root"
 }
 
-func (token *rootToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
-	if len(next) > 0 {
-		return next[0].Apply(root, root, next[1:])
-	}
-	return root, nil
+func (token *rootToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	return applyNext(ctx, root, root, next, "")
+}
+
+func (token *rootToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	return applyNodesNext(ctx, root, &Node{Path: Path{}, Value: root}, next)
 }
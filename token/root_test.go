@@ -58,3 +58,32 @@ func Test_RootToken_Apply(t *testing.T) {
 func Benchmark_RootToken_Apply(b *testing.B) {
 	batchTokenBenchmarks(b, rootTests)
 }
+
+func Test_RootToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &rootToken{},
+			input: nodesInput{
+				root:    "root",
+				current: &Node{Path: Path{"key"}, Value: "current"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$"},
+				values: []interface{}{"root"},
+			},
+		},
+		{
+			token: &rootToken{},
+			input: nodesInput{
+				root: map[string]interface{}{"key": "value"},
+				tokens: []Token{
+					&keyToken{key: "key"},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['key']"},
+				values: []interface{}{"value"},
+			},
+		},
+	})
+}
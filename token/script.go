@@ -1,6 +1,7 @@
 package token
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
@@ -34,12 +35,37 @@ func (token *scriptToken) Type() string {
 	return "script"
 }
 
-func (token *scriptToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
+func (token *scriptToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	nextToken, err := token.getNextToken(ctx, root, current)
+	if err != nil {
+		return nil, err
+	}
+	value, err := nextToken.Apply(ctx, root, current, next)
+	if err != nil {
+		return nil, getQueryError(err, "$", token, current)
+	}
+	return value, nil
+}
+
+func (token *scriptToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	nextToken, err := token.getNextToken(ctx, root, current.Value)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := nextToken.ApplyNodes(ctx, root, current, next)
+	if err != nil {
+		return nil, getQueryError(err, current.Path.String(), token, current.Value)
+	}
+	return nodes, nil
+}
+
+// getNextToken evaluates the script and returns the key or index token it represents
+func (token *scriptToken) getNextToken(ctx context.Context, root, current interface{}) (Token, error) {
 	if token.expression == "" {
 		return nil, getInvalidExpressionEmptyError()
 	}
 
-	value, err := token.compiledExpression.Evaluate(root, current)
+	value, err := evaluateExpression(ctx, token.compiledExpression, root, current)
 	if err != nil {
 		return nil, getInvalidExpressionError(err)
 	}
@@ -49,11 +75,9 @@ func (token *scriptToken) Apply(root, current interface{}, next []Token) (interf
 	}
 
 	if strValue, ok := value.(string); ok {
-		nextToken := &keyToken{key: strValue}
-		return nextToken.Apply(root, current, next)
+		return newKeyToken(strValue, token.options), nil
 	} else if intValue, ok := isInteger(value); ok {
-		nextToken := newIndexToken(intValue, token.options)
-		return nextToken.Apply(root, current, next)
+		return newIndexToken(intValue, token.options), nil
 	}
 
 	valueType := reflect.TypeOf(value)
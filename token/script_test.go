@@ -120,3 +120,51 @@ func Test_ScriptToken_Apply(t *testing.T) {
 func Benchmark_ScriptToken_Apply(b *testing.B) {
 	batchTokenBenchmarks(b, scriptTests)
 }
+
+func Test_ScriptToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &scriptToken{},
+			input: nodesInput{},
+			expected: nodesExpected{
+				err: "invalid expression. is empty",
+			},
+		},
+		{
+			token: &scriptToken{
+				expression:         "bool response",
+				compiledExpression: &testCompiledExpression{response: true},
+			},
+			input: nodesInput{},
+			expected: nodesExpected{
+				err: "unexpected expression result. expected [int string] got [bool]",
+			},
+		},
+		{
+			token: &scriptToken{
+				expression:         "string response",
+				compiledExpression: &testCompiledExpression{response: "key"},
+			},
+			input: nodesInput{
+				root: map[string]interface{}{"key": "value"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['key']"},
+				values: []interface{}{"value"},
+			},
+		},
+		{
+			token: &scriptToken{
+				expression:         "int response",
+				compiledExpression: &testCompiledExpression{response: -1},
+			},
+			input: nodesInput{
+				root: []interface{}{"one", "two", "three"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[2]"},
+				values: []interface{}{"three"},
+			},
+		},
+	})
+}
@@ -0,0 +1,133 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+)
+
+func newSegmentToken(selectors []Token, descendant bool, options *option.QueryOptions) *segmentToken {
+	limits := Limits(options)
+	return &segmentToken{
+		selectors:  selectors,
+		descendant: descendant,
+		maxDepth:   limits.MaxRecursionDepth,
+		maxResults: limits.MaxResults,
+	}
+}
+
+// segmentToken represents an RFC 9535 child or descendant segment, which applies each of its
+// selectors to the input node, or the input node and all of its descendants, and returns the combined results.
+type segmentToken struct {
+	selectors  []Token
+	descendant bool
+	// maxDepth the maximum depth of the descendants, not enforced if zero
+	maxDepth int
+	// maxResults the maximum number of descendants, not enforced if zero
+	maxResults int
+}
+
+func (token *segmentToken) String() string {
+	selectors := make([]string, len(token.selectors))
+	for idx, selector := range token.selectors {
+		selectors[idx] = selectorString(selector)
+	}
+
+	prefix := ""
+	if token.descendant {
+		prefix = ".."
+	}
+	return fmt.Sprintf("%s[%s]", prefix, strings.Join(selectors, ","))
+}
+
+func (token *segmentToken) Type() string {
+	if token.descendant {
+		return "descendant"
+	}
+	return "child"
+}
+
+func (token *segmentToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	nodes, err := token.ApplyNodes(ctx, root, &Node{Path: Path{}, Value: current}, next)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(nodes))
+	for idx, node := range nodes {
+		values[idx] = node.Value
+	}
+	return values, nil
+}
+
+func (token *segmentToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	targets := []*Node{current}
+	if token.descendant {
+		descendants, err := token.descendantNodes(ctx, current, 0)
+		if err != nil {
+			return nil, err
+		}
+		targets = descendants
+	}
+
+	nodes := make([]*Node, 0)
+	for _, target := range targets {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for _, selector := range token.selectors {
+			// selectors that do not match the target select nothing
+			selected, _ := selector.ApplyNodes(ctx, root, target, nil)
+			for _, node := range selected {
+				nodes = append(nodes, collectNodes(ctx, root, node, next)...)
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// descendantNodes returns the node followed by all of its descendants, with
+// each node visited before its own descendants and array elements in order.
+func (token *segmentToken) descendantNodes(ctx context.Context, current *Node, depth int) ([]*Node, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := checkRecursionDepth(depth, token.maxDepth); err != nil {
+		return nil, err
+	}
+	nodes := []*Node{current}
+
+	keys, values, err := (&wildcardToken{}).getChildren(current.Value)
+	if err != nil {
+		return nodes, nil
+	}
+	for idx, value := range values {
+		descendants, err := token.descendantNodes(ctx, current.child(keys[idx], value), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, descendants...)
+		if err := checkResultCount(len(nodes), token.maxResults); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// selectorString returns the RFC 9535 representation of a token used as a segment selector
+func selectorString(selector Token) string {
+	switch typed := selector.(type) {
+	case *keyToken:
+		return fmt.Sprintf("'%s'", escapeNormalizedPathName(typed.key))
+	case *indexToken:
+		return fmt.Sprintf("%d", typed.index)
+	case *wildcardToken:
+		return "*"
+	case *filterToken:
+		return fmt.Sprintf("?%s", typed.expression)
+	default:
+		return strings.TrimSuffix(strings.TrimPrefix(selector.String(), "["), "]")
+	}
+}
@@ -0,0 +1,189 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test segmentToken struct conforms to Token interface
+var _ Token = &segmentToken{}
+
+func Test_newSegmentToken(t *testing.T) {
+	assert.IsType(t, &segmentToken{}, newSegmentToken(nil, false, nil))
+}
+
+func Test_SegmentToken_String(t *testing.T) {
+
+	tests := []*tokenStringTest{
+		{
+			input:    &segmentToken{selectors: []Token{&keyToken{key: "it's"}}},
+			expected: "['it\\'s']",
+		},
+		{
+			input:    &segmentToken{selectors: []Token{&wildcardToken{}}, descendant: true},
+			expected: "..[*]",
+		},
+		{
+			input: &segmentToken{selectors: []Token{
+				&indexToken{index: -1},
+				&sliceToken{},
+				&filterToken{expression: "@.a"},
+			}},
+			expected: "[-1,:,?@.a]",
+		},
+	}
+
+	batchTokenStringTests(t, tests)
+}
+
+func Test_SegmentToken_Type(t *testing.T) {
+	assert.Equal(t, "child", (&segmentToken{}).Type())
+	assert.Equal(t, "descendant", (&segmentToken{descendant: true}).Type())
+}
+
+var segmentTests = []*tokenTest{
+	{
+		token: &segmentToken{selectors: []Token{&keyToken{key: "missing"}}},
+		input: input{
+			current: map[string]interface{}{"a": 1},
+		},
+		expected: expected{
+			value: []interface{}{},
+		},
+	},
+	{
+		token: &segmentToken{selectors: []Token{&keyToken{key: "a"}, &keyToken{key: "a"}}},
+		input: input{
+			current: map[string]interface{}{"a": 1},
+		},
+		expected: expected{
+			value: []interface{}{1, 1},
+		},
+	},
+	{
+		token: &segmentToken{selectors: []Token{&keyToken{key: "a"}}, descendant: true},
+		input: input{
+			current: map[string]interface{}{
+				"a": map[string]interface{}{"a": 2},
+			},
+		},
+		expected: expected{
+			value: []interface{}{
+				map[string]interface{}{"a": 2},
+				2,
+			},
+		},
+	},
+}
+
+func Test_SegmentToken_Apply(t *testing.T) {
+	batchTokenTests(t, segmentTests)
+}
+
+func Benchmark_SegmentToken_Apply(b *testing.B) {
+	batchTokenBenchmarks(b, segmentTests)
+}
+
+func Test_SegmentToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &segmentToken{selectors: []Token{&indexToken{index: 0}}},
+			input: nodesInput{
+				root: "string",
+			},
+			expected: nodesExpected{
+				paths: []string{},
+			},
+		},
+		{
+			token: &segmentToken{selectors: []Token{&indexToken{index: 1}, &indexToken{index: 0}}},
+			input: nodesInput{
+				root: []interface{}{"one", "two"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[1]", "$[0]"},
+				values: []interface{}{"two", "one"},
+			},
+		},
+		{
+			token: &segmentToken{selectors: []Token{&wildcardToken{}}, descendant: true},
+			input: nodesInput{
+				root: map[string]interface{}{
+					"a": []interface{}{"x", "y"},
+					"b": 1,
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['a']", "$['b']", "$['a'][0]", "$['a'][1]"},
+				values: []interface{}{[]interface{}{"x", "y"}, 1, "x", "y"},
+			},
+		},
+		{
+			token: &segmentToken{selectors: []Token{&keyToken{key: "a"}}},
+			input: nodesInput{
+				root: map[string]interface{}{
+					"a": map[string]interface{}{"b": "value"},
+				},
+				tokens: []Token{
+					&segmentToken{selectors: []Token{&keyToken{key: "b"}, &keyToken{key: "c"}}},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['a']['b']"},
+				values: []interface{}{"value"},
+			},
+		},
+	})
+}
+
+func Test_descendantNodes(t *testing.T) {
+	nodes, err := (&segmentToken{descendant: true}).descendantNodes(context.Background(), &Node{Path: Path{}, Value: []interface{}{
+		[]interface{}{1},
+		map[string]interface{}{"a": 2},
+	}}, 0)
+	assert.Nil(t, err)
+
+	paths := make([]string, len(nodes))
+	for idx, node := range nodes {
+		paths[idx] = node.Path.String()
+	}
+	assert.Equal(t, []string{"$", "$[0]", "$[0][0]", "$[1]", "$[1]['a']"}, paths)
+
+	t.Run("limits", func(t *testing.T) {
+		input := &Node{Path: Path{}, Value: []interface{}{[]interface{}{1}, 2}}
+
+		token := newSegmentToken(nil, true, &option.QueryOptions{Limits: option.Limits{MaxRecursionDepth: 1}})
+		nodes, err := token.descendantNodes(context.Background(), input, 0)
+		assert.EqualError(t, err, "limit exceeded. recursion depth exceeds maximum of 1")
+		assert.Nil(t, nodes)
+
+		token = newSegmentToken(nil, true, &option.QueryOptions{Limits: option.Limits{MaxResults: 3}})
+		nodes, err = token.descendantNodes(context.Background(), input, 0)
+		assert.EqualError(t, err, "limit exceeded. result count exceeds maximum of 3")
+		assert.Nil(t, nodes)
+	})
+}
+
+func Test_selectorString(t *testing.T) {
+
+	tests := []struct {
+		input    Token
+		expected string
+	}{
+		{input: &keyToken{key: "a"}, expected: "'a'"},
+		{input: &indexToken{index: 2}, expected: "2"},
+		{input: &wildcardToken{}, expected: "*"},
+		{input: &filterToken{expression: "@.a > 1"}, expected: "?@.a > 1"},
+		{input: &sliceToken{}, expected: ":"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, selectorString(test.input))
+		})
+	}
+}
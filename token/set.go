@@ -0,0 +1,271 @@
+package token
+
+import (
+	"context"
+	goErr "errors"
+	"fmt"
+)
+
+// Set applies the tokens of many selectors to the same data in a single pass. The selectors are
+// arranged as a trie so the tokens at the start of the selectors that are the same, for example
+// the tokens of $.store.book in $.store.book[0] and $.store.book[*].title, are only applied once.
+type Set struct {
+	root  *setNode
+	nodes int
+	count int
+}
+
+// setNode is a node of the trie of tokens
+type setNode struct {
+	id int
+	// token the token applied to the nodes selected by the parent, nil for the root of the trie
+	token Token
+	// tail the tokens that follow the token which are not shared, used when the token depends on the next token
+	tail []Token
+	// selectors the selectors that end with the token
+	selectors []int
+	// descendants the selectors that end with the token or the tokens of its children
+	descendants []int
+	children    []*setNode
+}
+
+// NewSet returns a set that applies the tokens of each selector
+func NewSet(selectors [][]Token) *Set {
+	set := &Set{
+		root:  &setNode{},
+		nodes: 1,
+		count: len(selectors),
+	}
+
+	for idx, tokens := range selectors {
+		current := set.root
+		current.descendants = append(current.descendants, idx)
+
+		for position := 0; position < len(tokens); position++ {
+			token := tokens[position]
+			if position+1 < len(tokens) && isIndexDependent(token, tokens[position+1]) {
+				// the token selects an element of its own result so the remaining tokens are applied together
+				current.children = append(current.children, &setNode{
+					id:          set.nodes,
+					token:       token,
+					tail:        tokens[position+1:],
+					selectors:   []int{idx},
+					descendants: []int{idx},
+				})
+				set.nodes++
+				current = nil
+				break
+			}
+
+			var child *setNode
+			for _, existing := range current.children {
+				if existing.tail == nil && isSameToken(existing.token, token) {
+					child = existing
+					break
+				}
+			}
+			if child == nil {
+				child = &setNode{id: set.nodes, token: token}
+				set.nodes++
+				current.children = append(current.children, child)
+			}
+			child.descendants = append(child.descendants, idx)
+			current = child
+		}
+
+		if current != nil {
+			current.selectors = append(current.selectors, idx)
+		}
+	}
+
+	return set
+}
+
+// isSameToken returns true if the tokens select the same values
+func isSameToken(one, two Token) bool {
+	return one.Type() == two.Type() && one.String() == two.String()
+}
+
+// isIndexDependent returns true if the token applies the next index token to its own result, rather than
+// to each of the values it selects, for example $[?(@.price < 10)][0] selects the first matching element
+func isIndexDependent(token, next Token) bool {
+	if _, ok := next.(*indexToken); !ok {
+		return false
+	}
+	switch token.(type) {
+	case *filterToken, *rangeToken, *unionToken:
+		return true
+	}
+	return false
+}
+
+// ApplyNodes applies the tokens of each selector against the root, returning the nodes matched by each
+// selector, or the error that would be returned if the selector was applied on its own, in the order the
+// selectors were passed to NewSet. If the context is cancelled or its deadline is exceeded, the context
+// error is returned for all selectors.
+func (set *Set) ApplyNodes(ctx context.Context, root interface{}) ([][]*Node, []error) {
+	state := &setState{
+		chains:  make([][]Token, set.nodes),
+		results: make([][]*Node, set.count),
+	}
+	state.build(set.root)
+
+	errs := make([]error, set.count)
+	err := state.apply(ctx, root, set.root, &Node{Path: Path{}, Value: root})
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		failed, ok := err.(*setError)
+		for idx := range errs {
+			if !ok {
+				errs[idx] = err
+			} else if failure, found := failed.errors[idx]; found {
+				errs[idx] = failure
+			}
+			if errs[idx] != nil {
+				state.results[idx] = nil
+			}
+		}
+	}
+	return state.results, errs
+}
+
+// setState holds the nodes matched by each selector while a set is applied
+type setState struct {
+	// chains the tokens applied for each node of the trie
+	chains  [][]Token
+	results [][]*Node
+}
+
+// build creates the chain of tokens applied for each node of the trie, where the
+// token of the node is followed by a branch token that applies its children
+func (state *setState) build(node *setNode) {
+	switch {
+	case node.token == nil:
+	case node.tail != nil:
+		state.chains[node.id] = append([]Token{node.token}, node.tail...)
+	default:
+		state.chains[node.id] = []Token{node.token, &branchToken{node: node, state: state}}
+	}
+	for _, child := range node.children {
+		state.build(child)
+	}
+}
+
+// apply records the current node as a result of the selectors that end with the node of the trie
+// and applies the children of the node, returning the errors of the selectors that failed as a setError.
+// The nodes recorded for the selectors that fail are removed, as a selector on its own does not match any
+// nodes below the current node when it fails, even if the token that applied the node ignores the error.
+func (state *setState) apply(ctx context.Context, root interface{}, node *setNode, current *Node) error {
+	for _, idx := range node.selectors {
+		state.results[idx] = append(state.results[idx], current)
+	}
+
+	var recorded map[int]int
+	if len(node.children) > 0 {
+		recorded = make(map[int]int, len(node.descendants))
+		for _, idx := range node.descendants {
+			recorded[idx] = len(state.results[idx])
+		}
+	}
+
+	var failed *setError
+	for _, child := range node.children {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		nodes, err := applyNodesNext(ctx, root, current, state.chains[child.id])
+		if err != nil {
+			if goErr.Is(err, context.Canceled) || goErr.Is(err, context.DeadlineExceeded) {
+				return err
+			}
+			if failed == nil {
+				failed = &setError{errors: make(map[int]error)}
+			}
+			failed.add(child, err)
+			continue
+		}
+		if child.tail != nil {
+			for _, idx := range child.selectors {
+				state.results[idx] = append(state.results[idx], nodes...)
+			}
+		}
+	}
+
+	if failed != nil {
+		for idx := range failed.errors {
+			if length, ok := recorded[idx]; ok {
+				state.results[idx] = state.results[idx][:length]
+			}
+		}
+		return failed
+	}
+	return nil
+}
+
+// applyMissing records the node of a missing key or index, which has a nil value, as a result of the selectors
+// that end with the node of the trie and returns the reason it is missing as the error of the selectors that
+// continue past it
+func (state *setState) applyMissing(node *setNode, current *Node, reason error) error {
+	for _, idx := range node.selectors {
+		state.results[idx] = append(state.results[idx], current)
+	}
+	if len(node.children) == 0 {
+		return nil
+	}
+
+	failed := &setError{errors: make(map[int]error)}
+	for _, child := range node.children {
+		failed.add(child, reason)
+	}
+	return failed
+}
+
+// setError is returned by a branch token when one or more of the selectors that follow it fail, it
+// is returned in place of the error of each selector so tokens can ignore or return it in the same
+// way they would the error of the selector, without affecting the other selectors.
+type setError struct {
+	errors map[int]error
+}
+
+func (err *setError) Error() string {
+	return fmt.Sprintf("%d selectors failed", len(err.errors))
+}
+
+// add records the error as the error of the selectors that follow the node, or of the selectors of a setError
+func (err *setError) add(node *setNode, reason error) {
+	if failed, ok := reason.(*setError); ok {
+		for idx, failure := range failed.errors {
+			err.errors[idx] = failure
+		}
+		return
+	}
+	for _, idx := range node.descendants {
+		err.errors[idx] = reason
+	}
+}
+
+// branchToken follows a token of a set, recording the nodes selected by the token
+// for the selectors that end with it and applying the tokens of its children
+type branchToken struct {
+	node  *setNode
+	state *setState
+}
+
+func (token *branchToken) String() string {
+	return ""
+}
+
+func (token *branchToken) Type() string {
+	return "branch"
+}
+
+func (token *branchToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	return nil, token.state.apply(ctx, root, token.node, &Node{Path: Path{}, Value: current})
+}
+
+func (token *branchToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	return nil, token.state.apply(ctx, root, token.node, current)
+}
@@ -0,0 +1,186 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test branchToken struct conforms to Token interface
+var _ Token = &branchToken{}
+
+func Test_NewSet(t *testing.T) {
+	filter := &filterToken{expression: "@.price < 10"}
+	set := NewSet([][]Token{
+		{&rootToken{}, &keyToken{key: "store"}, &keyToken{key: "book"}},
+		{&rootToken{}, &keyToken{key: "store"}, &keyToken{key: "book"}, &wildcardToken{}},
+		{&rootToken{}, &keyToken{key: "store"}, &keyToken{key: "bicycle"}},
+		{&rootToken{}, &keyToken{key: "store"}, &keyToken{key: "book"}, filter, &indexToken{index: 0}},
+		{&currentToken{}},
+	})
+
+	assert.Equal(t, 5, set.count)
+	assert.Equal(t, 8, set.nodes)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, set.root.descendants)
+	assert.Len(t, set.root.children, 2)
+
+	root := set.root.children[0]
+	assert.Equal(t, "$", root.token.String())
+	assert.Equal(t, []int{0, 1, 2, 3}, root.descendants)
+	assert.Len(t, root.children, 1)
+
+	store := root.children[0]
+	assert.Equal(t, "['store']", store.token.String())
+	assert.Len(t, store.children, 2)
+
+	book := store.children[0]
+	assert.Equal(t, "['book']", book.token.String())
+	assert.Equal(t, []int{0}, book.selectors)
+	assert.Equal(t, []int{0, 1, 3}, book.descendants)
+	assert.Len(t, book.children, 2)
+
+	wildcard := book.children[0]
+	assert.Equal(t, "[*]", wildcard.token.String())
+	assert.Equal(t, []int{1}, wildcard.selectors)
+
+	tail := book.children[1]
+	assert.Equal(t, filter, tail.token)
+	assert.Equal(t, []Token{&indexToken{index: 0}}, tail.tail)
+	assert.Equal(t, []int{3}, tail.selectors)
+
+	bicycle := store.children[1]
+	assert.Equal(t, "['bicycle']", bicycle.token.String())
+	assert.Equal(t, []int{2}, bicycle.selectors)
+
+	current := set.root.children[1]
+	assert.Equal(t, "@", current.token.String())
+	assert.Equal(t, []int{4}, current.selectors)
+}
+
+func Test_isIndexDependent(t *testing.T) {
+	tests := []struct {
+		token    Token
+		next     Token
+		expected bool
+	}{
+		{token: &filterToken{}, next: &indexToken{}, expected: true},
+		{token: &rangeToken{}, next: &indexToken{}, expected: true},
+		{token: &unionToken{}, next: &indexToken{}, expected: true},
+		{token: &filterToken{}, next: &keyToken{}, expected: false},
+		{token: &wildcardToken{}, next: &indexToken{}, expected: false},
+		{token: &keyToken{}, next: &indexToken{}, expected: false},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, isIndexDependent(test.token, test.next))
+		})
+	}
+}
+
+func Test_setError(t *testing.T) {
+	err := &setError{errors: map[int]error{}}
+	err.add(&setNode{descendants: []int{0, 2}}, fmt.Errorf("one"))
+	err.add(&setNode{descendants: []int{3}}, &setError{errors: map[int]error{3: fmt.Errorf("two")}})
+
+	assert.Equal(t, map[int]error{
+		0: fmt.Errorf("one"),
+		2: fmt.Errorf("one"),
+		3: fmt.Errorf("two"),
+	}, err.errors)
+	assert.EqualError(t, err, "3 selectors failed")
+}
+
+func Test_BranchToken_String(t *testing.T) {
+	assert.Equal(t, "", (&branchToken{}).String())
+}
+
+func Test_BranchToken_Type(t *testing.T) {
+	assert.Equal(t, "branch", (&branchToken{}).Type())
+}
+
+func Test_BranchToken_Apply(t *testing.T) {
+	node := &setNode{selectors: []int{0}}
+	state := &setState{results: make([][]*Node, 1)}
+
+	actual, err := (&branchToken{node: node, state: state}).Apply(context.Background(), nil, "value", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+	assert.Equal(t, [][]*Node{{{Path: Path{}, Value: "value"}}}, state.results)
+}
+
+func Test_Set_ApplyNodes(t *testing.T) {
+	root := map[string]interface{}{
+		"book": []interface{}{
+			map[string]interface{}{"price": float64(8), "title": "one"},
+			map[string]interface{}{"price": float64(12)},
+		},
+	}
+
+	set := NewSet([][]Token{
+		{&rootToken{}, &keyToken{key: "book"}, &indexToken{index: 0}, &keyToken{key: "title"}},
+		{&rootToken{}, &keyToken{key: "book"}, &indexToken{index: 1}, &keyToken{key: "title"}},
+		{&rootToken{}, &keyToken{key: "book"}, &wildcardToken{}, &keyToken{key: "title"}},
+		{&rootToken{}, &keyToken{key: "book"}, &indexToken{index: 5}},
+		{&rootToken{}, &keyToken{key: "book"}},
+	})
+
+	results, errs := set.ApplyNodes(context.Background(), root)
+
+	assert.Equal(t, [][]*Node{
+		{{Path: Path{"book", 0, "title"}, Value: "one"}},
+		nil,
+		{{Path: Path{"book", 0, "title"}, Value: "one"}},
+		nil,
+		{{Path: Path{"book"}, Value: root["book"]}},
+	}, results)
+
+	assert.Nil(t, errs[0])
+	queryErr, ok := errs[1].(*errors.QueryError)
+	assert.True(t, ok)
+	assert.EqualError(t, queryErr, "key: invalid token key 'title' not found")
+	assert.Equal(t, "$['book'][1]", queryErr.Path)
+	assert.Equal(t, "['title']", queryErr.Token)
+	assert.Equal(t, reflect.Map, queryErr.Kind)
+	assert.Nil(t, errs[2])
+	assert.EqualError(t, errs[3], "index: invalid token out of range")
+	assert.Nil(t, errs[4])
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results, errs := set.ApplyNodes(ctx, root)
+		assert.Equal(t, make([][]*Node, 5), results)
+		for _, err := range errs {
+			assert.ErrorIs(t, err, context.Canceled)
+		}
+	})
+}
+
+func Test_Set_ApplyNodes_leafToNull(t *testing.T) {
+	root := map[string]interface{}{
+		"a": map[string]interface{}{"x": float64(1)},
+	}
+
+	set := NewSet([][]Token{
+		{&rootToken{}, &keyToken{key: "a"}, &keyToken{key: "y", leafToNull: true}},
+		{&rootToken{}, &keyToken{key: "a"}, &keyToken{key: "y", leafToNull: true}, &keyToken{key: "z"}},
+		{&rootToken{}, &keyToken{key: "a"}, &keyToken{key: "x", leafToNull: true}},
+	})
+
+	results, errs := set.ApplyNodes(context.Background(), root)
+
+	assert.Equal(t, [][]*Node{
+		{{Path: Path{"a", "y"}, Value: nil, Missing: true}},
+		nil,
+		{{Path: Path{"a", "x"}, Value: float64(1)}},
+	}, results)
+	assert.Nil(t, errs[0])
+	assert.EqualError(t, errs[1], "key: invalid token key 'y' not found")
+	assert.Nil(t, errs[2])
+}
@@ -0,0 +1,132 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+func newSliceToken(start, end, step *int64) *sliceToken {
+	return &sliceToken{
+		start: start,
+		end:   end,
+		step:  step,
+	}
+}
+
+// sliceToken represents an RFC 9535 array slice selector, unlike the range token
+// negative steps select elements in reverse order and a zero step selects nothing.
+type sliceToken struct {
+	start, end, step *int64
+}
+
+func (token *sliceToken) String() string {
+	format := func(value *int64) string {
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprint(*value)
+	}
+
+	if token.step == nil {
+		return fmt.Sprintf("[%s:%s]", format(token.start), format(token.end))
+	}
+	return fmt.Sprintf("[%s:%s:%s]", format(token.start), format(token.end), format(token.step))
+}
+
+func (token *sliceToken) Type() string {
+	return "slice"
+}
+
+func (token *sliceToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	nodes, err := token.ApplyNodes(ctx, root, &Node{Path: Path{}, Value: current}, next)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(nodes))
+	for idx, node := range nodes {
+		values[idx] = node.Value
+	}
+	return values, nil
+}
+
+func (token *sliceToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	indices, err := token.getIndices(current.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	_, objVal := getTypeAndValue(current.Value)
+	nodes := make([]*Node, 0)
+	for _, index := range indices {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		element := current.child(int(index), objVal.Index(int(index)).Interface())
+		nodes = append(nodes, collectNodes(ctx, root, element, next)...)
+	}
+	return nodes, nil
+}
+
+// getIndices returns the indices of the elements selected by the slice, in selection order
+func (token *sliceToken) getIndices(current interface{}) ([]int64, error) {
+	objType, objVal := getTypeAndValue(current)
+	if objType == nil {
+		return nil, getInvalidTokenTargetNilError(
+			token.Type(),
+			reflect.Array, reflect.Slice,
+		)
+	}
+	if kind := getKind(current, objType); kind != reflect.Array && kind != reflect.Slice {
+		return nil, getInvalidTokenTargetError(
+			token.Type(),
+			kind,
+			reflect.Array, reflect.Slice,
+		)
+	}
+	length := int64(objVal.Len())
+
+	var step int64 = 1
+	if token.step != nil {
+		step = *token.step
+	}
+	if step == 0 {
+		return []int64{}, nil
+	}
+
+	normalize := func(value *int64, fallback int64) int64 {
+		if value == nil {
+			return fallback
+		}
+		if *value < 0 {
+			return length + *value
+		}
+		return *value
+	}
+	bound := func(value, lower, upper int64) int64 {
+		if value < lower {
+			return lower
+		}
+		if value > upper {
+			return upper
+		}
+		return value
+	}
+
+	indices := make([]int64, 0)
+	if step > 0 {
+		lower := bound(normalize(token.start, 0), 0, length)
+		upper := bound(normalize(token.end, length), 0, length)
+		for i := lower; i < upper; i += step {
+			indices = append(indices, i)
+		}
+	} else {
+		upper := bound(normalize(token.start, length-1), -1, length-1)
+		lower := bound(normalize(token.end, -length-1), -1, length-1)
+		for i := upper; lower < i; i += step {
+			indices = append(indices, i)
+		}
+	}
+	return indices, nil
+}
@@ -0,0 +1,157 @@
+package token
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test sliceToken struct conforms to Token interface
+var _ Token = &sliceToken{}
+
+func Test_newSliceToken(t *testing.T) {
+	assert.IsType(t, &sliceToken{}, newSliceToken(nil, nil, nil))
+}
+
+func sliceArgument(value int64) *int64 {
+	return &value
+}
+
+func Test_SliceToken_String(t *testing.T) {
+
+	tests := []*tokenStringTest{
+		{
+			input:    &sliceToken{},
+			expected: "[:]",
+		},
+		{
+			input:    &sliceToken{start: sliceArgument(1), end: sliceArgument(-1)},
+			expected: "[1:-1]",
+		},
+		{
+			input:    &sliceToken{step: sliceArgument(-1)},
+			expected: "[::-1]",
+		},
+	}
+
+	batchTokenStringTests(t, tests)
+}
+
+func Test_SliceToken_Type(t *testing.T) {
+	assert.Equal(t, "slice", (&sliceToken{}).Type())
+}
+
+var sliceTests = []*tokenTest{
+	{
+		token: &sliceToken{},
+		input: input{
+			current: nil,
+		},
+		expected: expected{
+			err: "slice: invalid token target. expected [array slice] got [nil]",
+		},
+	},
+	{
+		token: &sliceToken{},
+		input: input{
+			current: "string",
+		},
+		expected: expected{
+			err: "slice: invalid token target. expected [array slice] got [string]",
+		},
+	},
+	{
+		token: &sliceToken{start: sliceArgument(1), end: sliceArgument(3)},
+		input: input{
+			current: []string{"a", "b", "c", "d"},
+		},
+		expected: expected{
+			value: []interface{}{"b", "c"},
+		},
+	},
+	{
+		token: &sliceToken{start: sliceArgument(1)},
+		input: input{
+			current: &[3]int{1, 2, 3},
+		},
+		expected: expected{
+			value: []interface{}{2, 3},
+		},
+	},
+}
+
+func Test_SliceToken_Apply(t *testing.T) {
+	batchTokenTests(t, sliceTests)
+}
+
+func Benchmark_SliceToken_Apply(b *testing.B) {
+	batchTokenBenchmarks(b, sliceTests)
+}
+
+func Test_SliceToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &sliceToken{},
+			input: nodesInput{
+				root: map[string]interface{}{},
+			},
+			expected: nodesExpected{
+				err: "slice: invalid token target. expected [array slice] got [map]",
+			},
+		},
+		{
+			token: &sliceToken{step: sliceArgument(-2)},
+			input: nodesInput{
+				root: []interface{}{"a", "b", "c"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[2]", "$[0]"},
+				values: []interface{}{"c", "a"},
+			},
+		},
+		{
+			token: &sliceToken{},
+			input: nodesInput{
+				root: []interface{}{
+					map[string]interface{}{"a": 1},
+					map[string]interface{}{"b": 2},
+				},
+				tokens: []Token{&keyToken{key: "a"}},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[0]['a']"},
+				values: []interface{}{1},
+			},
+		},
+	})
+}
+
+func Test_SliceToken_getIndices(t *testing.T) {
+
+	current := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	tests := []struct {
+		token    *sliceToken
+		expected []int64
+	}{
+		{token: &sliceToken{}, expected: []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{token: &sliceToken{start: sliceArgument(-3)}, expected: []int64{7, 8, 9}},
+		{token: &sliceToken{end: sliceArgument(-8)}, expected: []int64{0, 1}},
+		{token: &sliceToken{start: sliceArgument(1), end: sliceArgument(8), step: sliceArgument(3)}, expected: []int64{1, 4, 7}},
+		{token: &sliceToken{start: sliceArgument(-100), end: sliceArgument(100), step: sliceArgument(4)}, expected: []int64{0, 4, 8}},
+		{token: &sliceToken{step: sliceArgument(0)}, expected: []int64{}},
+		{token: &sliceToken{step: sliceArgument(-3)}, expected: []int64{9, 6, 3, 0}},
+		{token: &sliceToken{start: sliceArgument(5), end: sliceArgument(1), step: sliceArgument(-2)}, expected: []int64{5, 3}},
+		{token: &sliceToken{start: sliceArgument(100), end: sliceArgument(-100), step: sliceArgument(-4)}, expected: []int64{9, 5, 1}},
+		{token: &sliceToken{start: sliceArgument(1), end: sliceArgument(5), step: sliceArgument(-1)}, expected: []int64{}},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, err := test.token.getIndices(current)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,459 @@
+package token
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/ordered"
+)
+
+// streamSelection describes the children of a value that a token may select, it is used to skip
+// the children that can not be selected while the value is still being read from a stream.
+type streamSelection struct {
+	// keys returns true if the object member with the key may be selected
+	keys func(key string) bool
+	// indices returns true if the array element at the index may be selected
+	indices func(index int64) bool
+	// trailing the number of elements at the end of an array that may be selected
+	trailing int64
+	// next the tokens that will be applied to the selected children
+	next []Token
+}
+
+// streamable is implemented by tokens that can select the children of a value before it is completely read,
+// false is returned if the token requires the complete value.
+type streamable interface {
+	streamSelection(next []Token) (*streamSelection, bool)
+}
+
+// Decode reads the next JSON value from the decoder, the tokens should be the tokens of a
+// selector starting with the root token.
+//
+// Object members and array elements that can not be selected by the tokens are skipped, so only
+// the parts of the value that the selector can match are held in memory. Skipped array elements are
+// replaced with nil to preserve the index of the remaining elements. Key, index, wildcard, range,
+// union, and slice tokens are applied while reading, any other token causes the complete value it
+// is applied to be read, or the complete root value if the token may reference the root.
+// Numbers are decoded as json.Number when the UseNumber option is enabled, and objects are decoded
+// as *ordered.Object when the PreserveKeyOrder option is enabled.
+func Decode(decoder *json.Decoder, tokens []Token, options *option.QueryOptions) (interface{}, error) {
+	if options != nil && options.UseNumber {
+		decoder.UseNumber()
+	}
+	preserveKeyOrder := options != nil && options.PreserveKeyOrder
+
+	if len(tokens) == 0 {
+		return decodeAll(decoder, preserveKeyOrder)
+	}
+	if _, ok := tokens[0].(*rootToken); !ok {
+		return decodeAll(decoder, preserveKeyOrder)
+	}
+
+	if requiresRoot(tokens[1:]) {
+		return decodeAll(decoder, preserveKeyOrder)
+	}
+	return decodeSelection(decoder, tokens[1:], preserveKeyOrder)
+}
+
+// requiresRoot returns true if any of the tokens that require the complete value may reference the root
+func requiresRoot(tokens []Token) bool {
+	for _, token := range tokens {
+		if stream, ok := token.(streamable); ok {
+			if _, ok := stream.streamSelection(nil); ok {
+				continue
+			}
+		}
+		if strings.Contains(token.String(), "$") {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeAll reads the complete next value, objects are decoded as *ordered.Object if preserveKeyOrder is true
+func decodeAll(decoder *json.Decoder, preserveKeyOrder bool) (interface{}, error) {
+	if preserveKeyOrder && decoder.More() {
+		// when there is no value the json package reports the error
+		return ordered.Decode(decoder)
+	}
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// decodeSelection reads the next value, only including the children that may be selected by the tokens
+func decodeSelection(decoder *json.Decoder, tokens []Token, preserveKeyOrder bool) (interface{}, error) {
+	if len(tokens) == 0 {
+		return decodeAll(decoder, preserveKeyOrder)
+	}
+	stream, ok := tokens[0].(streamable)
+	if !ok {
+		return decodeAll(decoder, preserveKeyOrder)
+	}
+	selection, ok := stream.streamSelection(tokens[1:])
+	if !ok {
+		return decodeAll(decoder, preserveKeyOrder)
+	}
+
+	next, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	decode := func() (interface{}, error) {
+		return decodeSelection(decoder, selection.next, preserveKeyOrder)
+	}
+	skip := func() error {
+		return skipValue(decoder)
+	}
+
+	switch next {
+	case json.Delim('{'):
+		key := func() (string, error) {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return "", err
+			}
+			key, _ := keyToken.(string)
+			return key, nil
+		}
+		object, err := selection.decodeObject(decoder.More, key, decode, skip, preserveKeyOrder)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return object, nil
+	case json.Delim('['):
+		array, err := selection.decodeArray(decoder.More, decode, skip)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return array, nil
+	}
+
+	// strings, numbers, booleans, and null are returned as is
+	return next, nil
+}
+
+// decodeObject returns the object members that may be selected, reading each key and
+// then either decoding or skipping the value until there are no more members.
+// The object is returned as an *ordered.Object if preserveKeyOrder is true.
+func (selection *streamSelection) decodeObject(more func() bool, key func() (string, error), decode func() (interface{}, error), skip func() error, preserveKeyOrder bool) (interface{}, error) {
+	object := make(map[string]interface{})
+	var orderedObject *ordered.Object
+	if preserveKeyOrder {
+		orderedObject = ordered.NewObject()
+	}
+	for more() {
+		key, err := key()
+		if err != nil {
+			return nil, err
+		}
+
+		if selection.keys == nil || !selection.keys(key) {
+			if err := skip(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		value, err := decode()
+		if err != nil {
+			return nil, err
+		}
+		if orderedObject != nil {
+			orderedObject.Set(key, value)
+			continue
+		}
+		object[key] = value
+	}
+	if orderedObject != nil {
+		return orderedObject, nil
+	}
+	return object, nil
+}
+
+// decodeArray returns the array elements that may be selected, either decoding or skipping
+// each element until there are no more elements. Elements that are skipped are replaced by nil.
+func (selection *streamSelection) decodeArray(more func() bool, decode func() (interface{}, error), skip func() error) ([]interface{}, error) {
+	array := make([]interface{}, 0)
+	// the elements that are only kept as they may be at the end of the array
+	trailing := make([]int, 0)
+
+	for index := int64(0); more(); index++ {
+		selected := selection.indices != nil && selection.indices(index)
+		if !selected && selection.trailing <= 0 {
+			if err := skip(); err != nil {
+				return nil, err
+			}
+			array = append(array, nil)
+			continue
+		}
+
+		value, err := decode()
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, value)
+
+		if !selected {
+			trailing = append(trailing, len(array)-1)
+			if int64(len(trailing)) > selection.trailing {
+				array[trailing[0]] = nil
+				trailing = trailing[1:]
+			}
+		}
+	}
+	return array, nil
+}
+
+// skipValue reads the next value without keeping it
+func skipValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		next, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch next {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// getStreamRange returns the array elements that may be selected by a range from and to the indices,
+// selecting every step element when the step is positive.
+func getStreamRange(from, to *int64, step int64) (func(index int64) bool, int64) {
+	if from != nil && *from < 0 {
+		// the start is relative to the end of the array
+		return nil, -*from
+	}
+
+	var lower int64 = 0
+	if from != nil {
+		lower = *from
+	}
+
+	return func(index int64) bool {
+		if index < lower {
+			return false
+		}
+		if to != nil && *to >= 0 && index >= *to {
+			return false
+		}
+		if step > 1 {
+			return (index-lower)%step == 0
+		}
+		return true
+	}, 0
+}
+
+func selectAllKeys(string) bool {
+	return true
+}
+
+func selectAllIndices(int64) bool {
+	return true
+}
+
+func (token *keyToken) streamSelection(next []Token) (*streamSelection, bool) {
+	return &streamSelection{
+		keys: func(key string) bool {
+			// every key that matches is read, the token selects between keys that collide
+			return token.matcher.equal(token.key, key)
+		},
+		next: next,
+	}, true
+}
+
+func (token *indexToken) streamSelection(next []Token) (*streamSelection, bool) {
+	selection := &streamSelection{
+		next: next,
+	}
+	if token.allowMap {
+		// maps are referenced by the index of the sorted keys
+		selection.keys = selectAllKeys
+	}
+	if token.index < 0 {
+		selection.trailing = -token.index
+	} else {
+		selection.indices = func(index int64) bool {
+			return index == token.index
+		}
+	}
+	return selection, true
+}
+
+func (token *wildcardToken) streamSelection(next []Token) (*streamSelection, bool) {
+	return &streamSelection{
+		keys:    selectAllKeys,
+		indices: selectAllIndices,
+		next:    next,
+	}, true
+}
+
+func (token *rangeToken) streamSelection(next []Token) (*streamSelection, bool) {
+	arguments := make([]*int64, 3)
+	for idx, argument := range []interface{}{token.from, token.to, token.step} {
+		if argument == nil {
+			continue
+		}
+		value, ok := isInteger(argument)
+		if !ok {
+			// scripts require the complete value
+			return nil, false
+		}
+		arguments[idx] = &value
+	}
+
+	var step int64 = 1
+	if arguments[2] != nil {
+		step = *arguments[2]
+	}
+
+	selection := &streamSelection{
+		next: getStreamNext(next),
+	}
+	if token.allowMap {
+		selection.keys = selectAllKeys
+	}
+	selection.indices, selection.trailing = getStreamRange(arguments[0], arguments[1], step)
+	return selection, true
+}
+
+func (token *unionToken) streamSelection(next []Token) (*streamSelection, bool) {
+	keys := make(map[string]bool)
+	indices := make(map[int64]bool)
+	var trailing int64 = 0
+
+	for _, argument := range token.arguments {
+		if key, ok := argument.(string); ok {
+			keys[key] = true
+			continue
+		}
+		index, ok := isInteger(argument)
+		if !ok {
+			// scripts require the complete value
+			return nil, false
+		}
+		if index < 0 && -index > trailing {
+			trailing = -index
+		} else if index >= 0 {
+			indices[index] = true
+		}
+	}
+
+	selection := &streamSelection{
+		keys: func(key string) bool {
+			if keys[key] {
+				return true
+			}
+			if token.matcher.enabled() {
+				for requested := range keys {
+					if token.matcher.equal(requested, key) {
+						return true
+					}
+				}
+			}
+			return false
+		},
+		indices: func(index int64) bool {
+			return indices[index]
+		},
+		trailing: trailing,
+		next:     getStreamNext(next),
+	}
+	if token.allowMap && (len(indices) > 0 || trailing > 0) {
+		selection.keys = selectAllKeys
+	}
+	return selection, true
+}
+
+// getStreamNext returns the tokens applied to each child selected by a range or union token,
+// an index token that follows these tokens is applied to the list of selected children.
+func getStreamNext(next []Token) []Token {
+	if len(next) > 0 {
+		if _, ok := next[0].(*indexToken); ok {
+			return next[1:]
+		}
+	}
+	return next
+}
+
+func (token *sliceToken) streamSelection(next []Token) (*streamSelection, bool) {
+	selection := &streamSelection{
+		next: next,
+	}
+
+	var step int64 = 1
+	if token.step != nil {
+		step = *token.step
+	}
+	switch {
+	case step == 0:
+		// selects nothing
+	case step < 0:
+		selection.indices = selectAllIndices
+	default:
+		selection.indices, selection.trailing = getStreamRange(token.start, token.end, step)
+	}
+	return selection, true
+}
+
+func (token *segmentToken) streamSelection(next []Token) (*streamSelection, bool) {
+	if token.descendant {
+		return nil, false
+	}
+
+	selections := make([]*streamSelection, len(token.selectors))
+	for idx, selector := range token.selectors {
+		stream, ok := selector.(streamable)
+		if !ok {
+			return nil, false
+		}
+		if selections[idx], ok = stream.streamSelection(nil); !ok {
+			return nil, false
+		}
+	}
+
+	selection := &streamSelection{
+		keys: func(key string) bool {
+			for _, selection := range selections {
+				if selection.keys != nil && selection.keys(key) {
+					return true
+				}
+			}
+			return false
+		},
+		indices: func(index int64) bool {
+			for _, selection := range selections {
+				if selection.indices != nil && selection.indices(index) {
+					return true
+				}
+			}
+			return false
+		},
+		next: next,
+	}
+	for _, child := range selections {
+		if child.trailing > selection.trailing {
+			selection.trailing = child.trailing
+		}
+	}
+	return selection, true
+}
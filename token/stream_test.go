@@ -0,0 +1,128 @@
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Decode(t *testing.T) {
+
+	data := `{"a":{"b":[1,{"c":2,"d":3},[4,5]],"e":"f"},"g":[6,7,8,9],"$":10}`
+
+	parse := func(selector string, options *option.QueryOptions) []Token {
+		tokenStrings, err := Tokenize(selector, nil)
+		assert.Nil(t, err)
+		tokens := make([]Token, len(tokenStrings))
+		for idx, tokenString := range tokenStrings {
+			tokens[idx], err = Parse(tokenString, &testEngine{}, options)
+			assert.Nil(t, err)
+		}
+		return tokens
+	}
+
+	tests := []struct {
+		selector string
+		options  *option.QueryOptions
+		rfc9535  bool
+		expected string
+	}{
+		{selector: "$", expected: data},
+		{selector: "$.a.e", expected: `{"a":{"e":"f"}}`},
+		{selector: "$.a.b[1].c", expected: `{"a":{"b":[null,{"c":2},null]}}`},
+		{selector: "$.a.b[1]", expected: `{"a":{"b":[null,{"c":2,"d":3},null]}}`},
+		{selector: "$.g[-2]", expected: `{"g":[null,null,8,9]}`},
+		{selector: "$.g[1:3]", expected: `{"g":[null,7,8,null]}`},
+		{selector: "$.g[:-1]", expected: `{"g":[6,7,8,9]}`},
+		{selector: "$.g[-2:]", expected: `{"g":[null,null,8,9]}`},
+		{selector: "$.g[::2]", expected: `{"g":[6,null,8,null]}`},
+		{selector: "$.g[0,2]", expected: `{"g":[6,null,8,null]}`},
+		{selector: "$['a','g'].e", expected: `{"a":{"e":"f"},"g":[null,null,null,null]}`},
+		{selector: "$.g[1:3][0]", expected: `{"g":[null,7,8,null]}`},
+		{selector: "$.*.e", expected: `{"$":10,"a":{"e":"f"},"g":[null,null,null,null]}`},
+		{selector: "$.a[0]", options: &option.QueryOptions{AllowMapReferenceByIndex: true}, expected: `{"a":{"b":[1,{"c":2,"d":3},[4,5]],"e":"f"}}`},
+		{selector: "$.a.b[?(@.c)]", expected: `{"a":{"b":[1,{"c":2,"d":3},[4,5]]}}`},
+		{selector: "$.a.b[?(@.c == $.g)]", expected: data},
+		{selector: "$.a[(@.length-1)]", expected: `{"a":{"b":[1,{"c":2,"d":3},[4,5]],"e":"f"}}`},
+		{selector: "$..c", expected: data},
+		{selector: "$.A.E", options: &option.QueryOptions{CaseInsensitiveKeys: true}, expected: `{"a":{"e":"f"}}`},
+		{selector: "$['A','G'].e", options: &option.QueryOptions{CaseInsensitiveKeys: true}, expected: `{"a":{"e":"f"},"g":[null,null,null,null]}`},
+		{selector: "$.a.b[1]['c','d']", rfc9535: true, expected: `{"a":{"b":[null,{"c":2,"d":3},null]}}`},
+		{selector: "$.g[-1,0]", rfc9535: true, expected: `{"g":[6,null,null,9]}`},
+		{selector: "$.g[::-1]", rfc9535: true, expected: `{"g":[6,7,8,9]}`},
+		{selector: "$.g[::0]", rfc9535: true, expected: `{"g":[null,null,null,null]}`},
+		{selector: "$.a.b[?@.c]", rfc9535: true, expected: `{"a":{"b":[1,{"c":2,"d":3},[4,5]]}}`},
+		{selector: "$.a.b[?@.c == $.g]", rfc9535: true, expected: data},
+		{selector: "$.a..c", rfc9535: true, expected: `{"a":{"b":[1,{"c":2,"d":3},[4,5]],"e":"f"}}`},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			var tokens []Token
+			if test.rfc9535 {
+				var err error
+				tokens, err = ParseRFC9535(test.selector, &testEngine{}, nil)
+				assert.Nil(t, err)
+			} else {
+				tokens = parse(test.selector, test.options)
+			}
+
+			actual, err := Decode(json.NewDecoder(strings.NewReader(data)), tokens, nil)
+			assert.Nil(t, err)
+
+			var expected interface{}
+			assert.Nil(t, json.Unmarshal([]byte(test.expected), &expected))
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("no tokens", func(t *testing.T) {
+		actual, err := Decode(json.NewDecoder(strings.NewReader(`[1]`)), nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{float64(1)}, actual)
+	})
+	t.Run("scalar", func(t *testing.T) {
+		actual, err := Decode(json.NewDecoder(strings.NewReader(`"value"`)), parse("$.a", nil), nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "value", actual)
+	})
+	t.Run("options", func(t *testing.T) {
+		options := &option.QueryOptions{PreserveKeyOrder: true, UseNumber: true}
+		actual, err := Decode(json.NewDecoder(strings.NewReader(`{"b":{"y":1,"x":2},"c":0,"a":[{"d":1,"c":2}]}`)), parse("$['b','a']", nil), options)
+		assert.Nil(t, err)
+		assert.Equal(t, ordered.NewObject(
+			ordered.Member{Key: "b", Value: ordered.NewObject(
+				ordered.Member{Key: "y", Value: json.Number("1")},
+				ordered.Member{Key: "x", Value: json.Number("2")},
+			)},
+			ordered.Member{Key: "a", Value: []interface{}{
+				ordered.NewObject(ordered.Member{Key: "d", Value: json.Number("1")}, ordered.Member{Key: "c", Value: json.Number("2")}),
+			}},
+		), actual)
+
+		actual, err = Decode(json.NewDecoder(strings.NewReader(`{"b":1,"a":2}`)), nil, options)
+		assert.Nil(t, err)
+		assert.Equal(t, ordered.NewObject(ordered.Member{Key: "b", Value: json.Number("1")}, ordered.Member{Key: "a", Value: json.Number("2")}), actual)
+	})
+
+	errorTests := []string{
+		``,
+		`{"a":`,
+		`{"a":[1,`,
+		`{"b":[1,`,
+		`{"a":{"b":1}`,
+		`[1,2`,
+	}
+	for idx, test := range errorTests {
+		t.Run(fmt.Sprintf("error %d", idx), func(t *testing.T) {
+			actual, err := Decode(json.NewDecoder(strings.NewReader(test)), parse("$.a[0]", nil), nil)
+			assert.NotNil(t, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
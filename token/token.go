@@ -1,6 +1,7 @@
 package token
 
 import (
+	"context"
 	"strconv"
 	"strings"
 
@@ -14,21 +15,27 @@ support double quotes in keys?
 
 // Token represents a component of a JSON Path selector
 type Token interface {
-	Apply(root, current interface{}, next []Token) (interface{}, error)
+	Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error)
+	ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error)
 	String() string
 	Type() string
 }
 
 // Tokenize converts a JSON Path selector to a collection of parsable tokens
-func Tokenize(selector string) ([]string, error) {
+func Tokenize(selector string, options *option.QueryOptions) ([]string, error) {
 	if selector == "" {
-		return nil, getUnexpectedTokenError("", 0)
+		return nil, NewSyntaxError(getUnexpectedTokenError("", 0), selector, 0, "", "$", "@")
+	}
+	limits := Limits(options)
+	if limits.MaxSelectorLength > 0 && len(selector) > limits.MaxSelectorLength {
+		return nil, getLimitExceededError("selector length", limits.MaxSelectorLength)
 	}
 
 	tokens := []string{}
 	tokenString := ""
 
-	quoteCount := 0
+	var quote rune
+	escaped := false
 	openScriptBracket := 0
 	closeScriptBracket := 0
 	openSubscriptBracket := 0
@@ -37,24 +44,33 @@ func Tokenize(selector string) ([]string, error) {
 	for idx, rne := range selector {
 
 		if tokenString == "" {
-			quoteCount = 0
+			quote = 0
 			openScriptBracket = 0
 			closeScriptBracket = 0
 		}
-		switch rne {
-		case '\'':
-			quoteCount++
-			break
-		case '(':
+		switch {
+		case quote != 0:
+			// brackets and escaped quotes within quotes are part of the string
+			if escaped {
+				escaped = false
+			} else if rne == '\\' {
+				escaped = true
+			} else if rne == quote {
+				quote = 0
+			}
+		case rne == '\'' || (rne == '"' && strings.Contains(tokenString, "[")):
+			// single quotes can also quote the keys of dot notation, such as $.'some.key'
+			quote = rne
+		case rne == '(':
 			openScriptBracket++
 			break
-		case ')':
+		case rne == ')':
 			closeScriptBracket++
 			break
-		case '[':
+		case rne == '[':
 			openSubscriptBracket++
 			break
-		case ']':
+		case rne == ']':
 			closeSubscriptBracket++
 			break
 		}
@@ -63,12 +79,12 @@ func Tokenize(selector string) ([]string, error) {
 
 		if idx == 0 {
 			if tokenString != "$" && tokenString != "@" {
-				return nil, getUnexpectedTokenError(string(rne), idx)
+				return nil, NewSyntaxError(getUnexpectedTokenError(string(rne), idx), selector, idx, string(rne), "$", "@")
 			}
 
 			if len(selector) > 1 {
 				if next := selector[1]; next != '.' && next != '[' {
-					return nil, getUnexpectedTokenError(string(next), idx+1)
+					return nil, NewSyntaxError(getUnexpectedTokenError(string(next), idx+1), selector, idx+1, string(next), ".", "[")
 				}
 			}
 
@@ -114,7 +130,7 @@ func Tokenize(selector string) ([]string, error) {
 		}
 
 		if strings.Contains(tokenString, "[") {
-			if quoteCount%2 == 1 || openScriptBracket != closeScriptBracket {
+			if quote != 0 || openScriptBracket != closeScriptBracket {
 				// inside expression or quotes
 				continue
 			}
@@ -132,7 +148,7 @@ func Tokenize(selector string) ([]string, error) {
 				continue
 			}
 		} else if rne == '.' {
-			if quoteCount%2 == 1 || openScriptBracket != closeScriptBracket {
+			if quote != 0 || openScriptBracket != closeScriptBracket {
 				// inside expression or quotes
 				continue
 			}
@@ -159,11 +175,93 @@ func Tokenize(selector string) ([]string, error) {
 		tokens = append(tokens, tokenString[:])
 	}
 
+	if limits.MaxTokens > 0 && len(tokens) > limits.MaxTokens {
+		return nil, getLimitExceededError("token count", limits.MaxTokens)
+	}
+	return tokens, nil
+}
+
+// ParseSelector will tokenize and parse the JSON Path selector and return the actionable tokens.
+//
+// Errors are returned as a *errors.SyntaxError positioned within the selector.
+func ParseSelector(selector string, engine script.Engine, options *option.QueryOptions) ([]Token, error) {
+	tokenStrings, err := Tokenize(selector, options)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]Token, len(tokenStrings))
+	offset := 0
+	for idx, tokenString := range tokenStrings {
+		tokenString = strings.TrimSpace(tokenString)
+		if start := strings.Index(selector[offset:], tokenString); start >= 0 {
+			offset += start
+		}
+
+		token, err := Parse(tokenString, engine, options)
+		if err != nil {
+			return nil, RelocateSyntaxError(err, selector, offset)
+		}
+		tokens[idx] = token
+		offset += len(tokenString)
+	}
+
+	for idx := 1; idx < len(tokens); idx++ {
+		if _, ok := tokens[idx-1].(*recursiveToken); ok {
+			// recursive descent only selects the descendants that include the key or index
+			switch token := tokens[idx].(type) {
+			case *keyToken:
+				token.leafToNull = false
+			case *indexToken:
+				token.leafToNull = false
+			case *unionToken:
+				token.leafToNull = false
+			}
+		}
+	}
 	return tokens, nil
 }
 
-// Parse will parse a single token string and return an actionable token
+// Parse will parse a single token string and return an actionable token.
+//
+// Errors are returned as a *errors.SyntaxError positioned within the token string.
 func Parse(tokenString string, engine script.Engine, options *option.QueryOptions) (Token, error) {
+	tokenString = strings.TrimSpace(tokenString)
+	token, err := parseToken(tokenString, engine, options)
+	if err != nil {
+		return nil, RelocateSyntaxError(err, tokenString, 0)
+	}
+	return token, nil
+}
+
+// IsDefinite returns true if the tokens can only ever match a single value, made up of only
+// key, index, length, script, and expression tokens, RFC 9535 segments with a single name or index selector,
+// or filter, range, and union tokens followed by an index token, which selects a single element of their result.
+func IsDefinite(tokens []Token) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	for idx := 1; idx < len(tokens); idx++ {
+		token := tokens[idx]
+		switch token.(type) {
+		case *keyToken, *indexToken, *lengthToken, *scriptToken, *expressionToken:
+			continue
+		case *segmentToken:
+			if !IsSingularQuery([]Token{tokens[0], token}) {
+				return false
+			}
+		default:
+			if idx+1 < len(tokens) && isIndexDependent(token, tokens[idx+1]) {
+				idx++
+				continue
+			}
+			return false
+		}
+	}
+	return true
+}
+
+func parseToken(tokenString string, engine script.Engine, options *option.QueryOptions) (Token, error) {
 	isScript := func(token string) bool {
 		return len(token) > 2 && strings.HasPrefix(token, "(") && strings.HasSuffix(token, ")")
 	}
@@ -186,36 +284,41 @@ func Parse(tokenString string, engine script.Engine, options *option.QueryOption
 		return newCurrentToken(), nil
 	}
 	if tokenString == "*" {
-		return newWildcardToken(), nil
+		return newWildcardToken(options), nil
 	}
 	if tokenString == ".." {
-		return newRecursiveToken(), nil
+		return newRecursiveToken(options), nil
 	}
 
 	if !strings.HasPrefix(tokenString, "[") {
 		if tokenString == "length" {
 			return newLengthToken(), nil
 		}
-		return newKeyToken(tokenString), nil
+		return newKeyToken(tokenString, options), nil
 	}
 
 	if !strings.HasSuffix(tokenString, "]") {
-		return nil, getInvalidTokenFormatError(tokenString)
+		return nil, NewSyntaxError(getInvalidTokenFormatError(tokenString), tokenString, len(tokenString), "", "]")
 	}
 	// subscript, or child operator
 
 	subscript := strings.TrimSpace(tokenString[1 : len(tokenString)-1])
 	if subscript == "" {
-		return nil, getInvalidTokenFormatError(tokenString)
+		return nil, NewSyntaxError(getInvalidTokenFormatError(tokenString), tokenString, len(tokenString)-1, "]")
 	}
+	// the offset of the subscript within the token string
+	subscriptOffset := strings.Index(tokenString, subscript)
 
 	if subscript == "*" {
 		// range all
-		return newWildcardToken(), nil
+		return newWildcardToken(options), nil
 	} else if strings.HasPrefix(subscript, "?") {
 		// filter
-		if !strings.HasPrefix(subscript, "?(") || !strings.HasSuffix(subscript, ")") {
-			return nil, getInvalidTokenFormatError(tokenString)
+		if !strings.HasPrefix(subscript, "?(") {
+			return nil, NewSyntaxError(getInvalidTokenFormatError(tokenString), tokenString, subscriptOffset+1, firstCharacter(subscript[1:]), "(")
+		} else if !strings.HasSuffix(subscript, ")") {
+			end := subscriptOffset + len(subscript)
+			return nil, NewSyntaxError(getInvalidTokenFormatError(tokenString), tokenString, end, "]", ")")
 		}
 		return newFilterToken(strings.TrimSpace(subscript[2:len(subscript)-1]), engine, options)
 	}
@@ -226,6 +329,7 @@ func Parse(tokenString string, engine script.Engine, options *option.QueryOption
 	openBracketCount, closeBracketCount := 0, 0
 	openSingleQuote := false
 	openDoubleQuote := false
+	escaped := false
 
 	inQuotes := func() bool {
 		return openSingleQuote || openDoubleQuote
@@ -236,6 +340,19 @@ func Parse(tokenString string, engine script.Engine, options *option.QueryOption
 	bufferString := ""
 	for idx, rne := range subscript {
 		bufferString += string(rne)
+		if escaped {
+			// backslashes and the quote character are escaped, other escape sequences are kept as they are
+			escaped = false
+			if rne == '\\' || (rne == '\'' && openSingleQuote) || (rne == '"' && openDoubleQuote) {
+				bufferString = bufferString[0:len(bufferString)-2] + string(rne)
+			}
+			continue
+		}
+		if rne == '\\' && inQuotes() {
+			escaped = true
+			continue
+		}
+
 		switch rne {
 		case ' ':
 			if !inQuotes() && openBracketCount == closeBracketCount {
@@ -267,23 +384,17 @@ func Parse(tokenString string, engine script.Engine, options *option.QueryOption
 				continue
 			}
 
-			// if last token is escape character, then this is not an open or close
-			if len(bufferString) > 1 && bufferString[len(bufferString)-2] == '\\' {
-				bufferString = bufferString[0:len(bufferString)-2] + "'"
-				break
-			}
-
 			openSingleQuote = !openSingleQuote
 
 			if openSingleQuote {
 				// open quote
 				if bufferString != "'" {
-					return nil, getInvalidTokenFormatError(tokenString)
+					return nil, NewSyntaxError(getInvalidTokenFormatError(tokenString), tokenString, subscriptOffset+idx, string(rne))
 				}
 			} else {
 				// close quote
 				if !isKey(bufferString) {
-					return nil, getInvalidTokenFormatError(tokenString)
+					return nil, NewSyntaxError(getInvalidTokenFormatError(tokenString), tokenString, subscriptOffset+idx, string(rne))
 				}
 				args = append(args, bufferString[:])
 				bufferString = ""
@@ -294,23 +405,17 @@ func Parse(tokenString string, engine script.Engine, options *option.QueryOption
 				continue
 			}
 
-			// if last token is escape character, then this is not an open or close
-			if len(bufferString) > 1 && bufferString[len(bufferString)-2] == '\\' {
-				bufferString = bufferString[0:len(bufferString)-2] + "\""
-				break
-			}
-
 			openDoubleQuote = !openDoubleQuote
 
 			if openDoubleQuote {
 				// open quote
 				if bufferString != "\"" {
-					return nil, getInvalidTokenFormatError(tokenString)
+					return nil, NewSyntaxError(getInvalidTokenFormatError(tokenString), tokenString, subscriptOffset+idx, string(rne))
 				}
 			} else {
 				// close quote
 				if !isKey(bufferString) {
-					return nil, getInvalidTokenFormatError(tokenString)
+					return nil, NewSyntaxError(getInvalidTokenFormatError(tokenString), tokenString, subscriptOffset+idx, string(rne))
 				}
 				args = append(args, bufferString[:])
 				bufferString = ""
@@ -324,7 +429,7 @@ func Parse(tokenString string, engine script.Engine, options *option.QueryOption
 				if num, err := strconv.ParseInt(arg, 10, 64); err == nil {
 					args = append(args, num)
 				} else {
-					return nil, getInvalidTokenFormatError(tokenString)
+					return nil, NewSyntaxError(getInvalidTokenFormatError(tokenString), tokenString, subscriptOffset+idx, string(rne))
 				}
 			} else if idx == 0 {
 				// if the token starts with :
@@ -366,7 +471,7 @@ func Parse(tokenString string, engine script.Engine, options *option.QueryOption
 		arg := args[0]
 		if strArg, ok := arg.(string); ok {
 			if isKey(strArg) {
-				return newKeyToken(strArg[1 : len(strArg)-1]), nil
+				return newKeyToken(strArg[1:len(strArg)-1], options), nil
 			} else if isScript(strArg) {
 				return newScriptToken(strArg[1:len(strArg)-1], engine, options)
 			}
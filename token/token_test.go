@@ -1,9 +1,12 @@
 package token
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/evilmonkeyinc/jsonpath/errors"
+	"github.com/evilmonkeyinc/jsonpath/option"
 	"github.com/evilmonkeyinc/jsonpath/script"
 	"github.com/stretchr/testify/assert"
 )
@@ -13,9 +16,15 @@ type testToken struct {
 	err   error
 }
 
-func (token *testToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
+func (token *testToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
 	return token.value, token.err
 }
+func (token *testToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	if token.err != nil {
+		return nil, token.err
+	}
+	return []*Node{{Path: current.Path, Value: token.value}}, nil
+}
 func (token *testToken) String() string { return "test" }
 func (token *testToken) Type() string   { return "test" }
 
@@ -487,6 +496,22 @@ func Test_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: input{selector: `['a\b\\c']`},
+			expected: expected{
+				token: &keyToken{
+					key: `a\b\c`,
+				},
+			},
+		},
+		{
+			input: input{selector: `["it's \"x\"\\"]`},
+			expected: expected{
+				token: &keyToken{
+					key: `it's "x"\`,
+				},
+			},
+		},
 		{
 			input: input{selector: "[\\'key\\'s']"},
 			expected: expected{
@@ -496,19 +521,19 @@ func Test_Parse(t *testing.T) {
 		{
 			input: input{selector: `["key"]`},
 			expected: expected{
-				token: newKeyToken("key"),
+				token: newKeyToken("key", nil),
 			},
 		},
 		{
 			input: input{selector: `["key's"]`},
 			expected: expected{
-				token: newKeyToken("key's"),
+				token: newKeyToken("key's", nil),
 			},
 		},
 		{
 			input: input{selector: `["\"keys\""]`},
 			expected: expected{
-				token: newKeyToken("\"keys\""),
+				token: newKeyToken("\"keys\"", nil),
 			},
 		},
 		{
@@ -829,11 +854,29 @@ func Test_Tokenize(t *testing.T) {
 				tokens: []string{"@", "'.'"},
 			},
 		},
+		{
+			input: `$['it\'s']['b']`,
+			expected: expected{
+				tokens: []string{"$", `['it\'s']`, "['b']"},
+			},
+		},
+		{
+			input: `$['a\\'].b`,
+			expected: expected{
+				tokens: []string{"$", `['a\\']`, "b"},
+			},
+		},
+		{
+			input: `$["a]b"]['c(']`,
+			expected: expected{
+				tokens: []string{"$", `["a]b"]`, "['c(']"},
+			},
+		},
 	}
 
 	for idx, test := range tests {
 		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
-			tokens, err := Tokenize(test.input)
+			tokens, err := Tokenize(test.input, nil)
 
 			if test.expected.err != "" {
 				assert.EqualError(t, err, test.expected.err, "unexpected error for %s", test.input)
@@ -848,6 +891,21 @@ func Test_Tokenize(t *testing.T) {
 		})
 	}
 
+	t.Run("limits", func(t *testing.T) {
+		options := &option.QueryOptions{Limits: option.Limits{MaxSelectorLength: 12, MaxTokens: 3}}
+
+		tokens, err := Tokenize("$.a.b", options)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"$", "a", "b"}, tokens)
+
+		tokens, err = Tokenize("$.a.b.c", options)
+		assert.EqualError(t, err, "limit exceeded. token count exceeds maximum of 3")
+		assert.Nil(t, tokens)
+
+		tokens, err = Tokenize("$['abcdefgh']", options)
+		assert.EqualError(t, err, "limit exceeded. selector length exceeds maximum of 12")
+		assert.Nil(t, tokens)
+	})
 }
 
 type tokenStringTest struct {
@@ -883,7 +941,7 @@ type tokenTest struct {
 func batchTokenTests(t *testing.T, tests []*tokenTest) {
 	for idx, test := range tests {
 		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
-			actual, err := test.token.Apply(test.input.root, test.input.current, test.input.tokens)
+			actual, err := test.token.Apply(context.Background(), test.input.root, test.input.current, test.input.tokens)
 
 			if test.expected.err == "" {
 				assert.Nil(t, err)
@@ -911,7 +969,7 @@ func batchTokenTests(t *testing.T, tests []*tokenTest) {
 func batchTokenBenchmarks(b *testing.B, tests []*tokenTest) {
 	for idx, test := range tests {
 		b.Run(fmt.Sprintf("%d", idx), func(b *testing.B) {
-			actual, err := test.token.Apply(test.input.root, test.input.current, test.input.tokens)
+			actual, err := test.token.Apply(context.Background(), test.input.root, test.input.current, test.input.tokens)
 
 			if test.expected.err == "" {
 				assert.Nil(b, err)
@@ -935,3 +993,206 @@ func batchTokenBenchmarks(b *testing.B, tests []*tokenTest) {
 		})
 	}
 }
+
+type nodesInput struct {
+	root    interface{}
+	current *Node
+	tokens  []Token
+}
+
+type nodesExpected struct {
+	paths  []string
+	values []interface{}
+	err    string
+}
+
+type tokenNodesTest struct {
+	token    Token
+	input    nodesInput
+	expected nodesExpected
+}
+
+func batchTokenNodesTests(t *testing.T, tests []*tokenNodesTest) {
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			current := test.input.current
+			if current == nil {
+				current = &Node{Path: Path{}, Value: test.input.root}
+			}
+
+			nodes, err := test.token.ApplyNodes(context.Background(), test.input.root, current, test.input.tokens)
+
+			if test.expected.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, test.expected.err)
+			}
+
+			paths := make([]string, 0)
+			values := make([]interface{}, 0)
+			for _, node := range nodes {
+				paths = append(paths, node.Path.String())
+				values = append(values, node.Value)
+			}
+
+			if test.expected.paths == nil {
+				assert.Nil(t, nodes)
+				return
+			}
+			assert.Equal(t, test.expected.paths, paths)
+			if test.expected.values != nil {
+				assert.Equal(t, test.expected.values, values)
+			}
+		})
+	}
+}
+
+func Test_ParseSelector(t *testing.T) {
+
+	type expected struct {
+		tokens   int
+		err      string
+		offset   int
+		token    string
+		expected []string
+	}
+
+	tests := []struct {
+		selector string
+		expected expected
+	}{
+		{
+			selector: "$.store.book[0].author",
+			expected: expected{tokens: 5},
+		},
+		{
+			selector: "$x",
+			expected: expected{
+				err:      "unexpected token 'x' at index 1",
+				offset:   1,
+				token:    "x",
+				expected: []string{".", "["},
+			},
+		},
+		{
+			selector: "$.store.book[]",
+			expected: expected{
+				err:    "invalid token. '[]' does not match any token format",
+				offset: 13,
+				token:  "]",
+			},
+		},
+		{
+			selector: "$.store.book[?@.price]",
+			expected: expected{
+				err:      "invalid token. '[?@.price]' does not match any token format",
+				offset:   14,
+				token:    "@",
+				expected: []string{"("},
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			tokens, err := ParseSelector(test.selector, nil, nil)
+			if test.expected.err == "" {
+				assert.Nil(t, err)
+				assert.Len(t, tokens, test.expected.tokens)
+				return
+			}
+
+			assert.Nil(t, tokens)
+			assert.EqualError(t, err, test.expected.err)
+			syntaxError, ok := err.(*errors.SyntaxError)
+			if !assert.True(t, ok) {
+				return
+			}
+			assert.Equal(t, test.selector, syntaxError.Selector)
+			assert.Equal(t, test.expected.offset, syntaxError.Offset)
+			assert.Equal(t, test.expected.token, syntaxError.Token)
+			assert.Equal(t, test.expected.expected, syntaxError.Expected)
+		})
+	}
+
+	t.Run("leaf to null", func(t *testing.T) {
+		tokens, err := ParseSelector("$..a.b[0]", nil, &option.QueryOptions{DefaultPathLeafToNull: true})
+		assert.Nil(t, err)
+		assert.Len(t, tokens, 5)
+		assert.False(t, tokens[2].(*keyToken).leafToNull)
+		assert.True(t, tokens[3].(*keyToken).leafToNull)
+		assert.True(t, tokens[4].(*indexToken).leafToNull)
+	})
+}
+
+func Test_Token_ApplyCancelled(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	root := map[string]interface{}{
+		"array": []interface{}{
+			map[string]interface{}{"key": 1},
+			map[string]interface{}{"key": 2},
+		},
+	}
+	array := root["array"]
+
+	tests := []struct {
+		token   Token
+		current interface{}
+	}{
+		{token: &recursiveToken{}, current: root},
+		{token: &wildcardToken{}, current: array},
+		{token: &filterToken{expression: "true", compiledExpression: &testCompiledExpression{response: true}}, current: array},
+		{token: newRangeToken(int64(0), nil, nil, nil), current: array},
+	}
+
+	for _, test := range tests {
+		t.Run(test.token.Type(), func(t *testing.T) {
+			actual, err := test.token.Apply(ctx, root, test.current, nil)
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Nil(t, actual)
+
+			nodes, err := test.token.ApplyNodes(ctx, root, &Node{Path: Path{}, Value: test.current}, nil)
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Nil(t, nodes)
+		})
+	}
+}
+
+func Test_IsDefinite(t *testing.T) {
+	tests := []struct {
+		tokens   []Token
+		expected bool
+	}{
+		{tokens: nil, expected: false},
+		{tokens: []Token{&rootToken{}}, expected: true},
+		{tokens: []Token{&currentToken{}, &keyToken{key: "a"}}, expected: true},
+		{tokens: []Token{&rootToken{}, &keyToken{key: "a"}, &indexToken{index: 0}, &lengthToken{}}, expected: true},
+		{tokens: []Token{&rootToken{}, &scriptToken{expression: "@.length-1"}}, expected: true},
+		{tokens: []Token{&rootToken{}, &expressionToken{expression: "1"}}, expected: true},
+		{tokens: []Token{&rootToken{}, &keyToken{key: "a"}, &wildcardToken{}}, expected: false},
+		{tokens: []Token{&rootToken{}, &recursiveToken{}, &keyToken{key: "a"}}, expected: false},
+		{tokens: []Token{&rootToken{}, &rangeToken{}}, expected: false},
+		{tokens: []Token{&rootToken{}, &unionToken{}}, expected: false},
+		{tokens: []Token{&rootToken{}, &filterToken{}}, expected: false},
+		{tokens: []Token{&rootToken{}, &keyToken{key: "book"}, &filterToken{}, &indexToken{index: 0}}, expected: true},
+		{tokens: []Token{&rootToken{}, &keyToken{key: "book"}, &rangeToken{}, &indexToken{index: 1}}, expected: true},
+		{tokens: []Token{&rootToken{}, &unionToken{}, &indexToken{index: 0}, &keyToken{key: "a"}}, expected: true},
+		{tokens: []Token{&rootToken{}, &filterToken{}, &keyToken{key: "a"}}, expected: false},
+		{tokens: []Token{&rootToken{}, &wildcardToken{}, &indexToken{index: 0}}, expected: false},
+		{tokens: []Token{&rootToken{}, &recursiveToken{}, &filterToken{}, &indexToken{index: 0}}, expected: false},
+		{tokens: []Token{&rootToken{}, newSegmentToken([]Token{&keyToken{key: "a"}}, false, nil)}, expected: true},
+		{tokens: []Token{&rootToken{}, newSegmentToken([]Token{&indexToken{index: 0}}, false, nil)}, expected: true},
+		{tokens: []Token{&rootToken{}, newSegmentToken([]Token{&keyToken{key: "a"}}, true, nil)}, expected: false},
+		{tokens: []Token{&rootToken{}, newSegmentToken([]Token{&keyToken{key: "a"}, &keyToken{key: "b"}}, false, nil)}, expected: false},
+		{tokens: []Token{&rootToken{}, newSegmentToken([]Token{&wildcardToken{}}, false, nil)}, expected: false},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			assert.Equal(t, test.expected, IsDefinite(test.tokens))
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package token
+
+import (
+	"context"
+	"time"
+)
+
+// maxTraceErrors the maximum number of failed filter evaluations kept for each token
+const maxTraceErrors int = 10
+
+type traceKey struct{}
+
+// Trace records how each token of a selector is applied by a query. The trace is passed
+// to the tokens using the context returned by WithTrace and only queries applied using
+// ApplyNodes are recorded, the queries evaluated by filter and script expressions are not.
+type Trace struct {
+	// Tokens the record of each token in the order they appear in the selector
+	Tokens []*TokenTrace
+
+	tokens    []Token
+	current   int
+	inclusive []time.Duration
+}
+
+// TokenTrace records how a single token was applied by a query
+type TokenTrace struct {
+	// Token the string representation of the token
+	Token string
+	// Type the type of the token
+	Type string
+	// Input the number of nodes the token was applied to
+	Input int
+	// Output the number of nodes selected by the token
+	Output int
+	// Matched the number of filter evaluations that were true
+	Matched int
+	// Rejected the number of filter evaluations that were false
+	Rejected int
+	// Failed the number of filter evaluations that returned an error, which filters treat as false
+	Failed int
+	// Errors the errors of the first failed filter evaluations, each a query error with the path of the evaluated value
+	Errors []error
+	// Duration the time spent applying the token, excluding the time spent applying the tokens that follow it
+	Duration time.Duration
+}
+
+// NewTrace returns a trace for the tokens of a selector
+func NewTrace(tokens []Token) *Trace {
+	trace := &Trace{
+		Tokens:    make([]*TokenTrace, len(tokens)),
+		tokens:    tokens,
+		inclusive: make([]time.Duration, len(tokens)),
+	}
+	for idx, token := range tokens {
+		trace.Tokens[idx] = &TokenTrace{
+			Token: token.String(),
+			Type:  token.Type(),
+		}
+	}
+	return trace
+}
+
+// WithTrace returns a copy of the context that records how the tokens are applied in the trace
+func WithTrace(ctx context.Context, trace *Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+// Finish completes the trace once the query has been applied, using the number of nodes
+// returned by the query and the time taken to apply the first token.
+func (trace *Trace) Finish(results int, elapsed time.Duration) {
+	if len(trace.Tokens) == 0 {
+		return
+	}
+	trace.Tokens[0].Input = 1
+	trace.inclusive[0] = elapsed
+
+	for idx, tokenTrace := range trace.Tokens {
+		tokenTrace.Output = results
+		tokenTrace.Duration = trace.inclusive[idx]
+		if next := idx + 1; next < len(trace.Tokens) {
+			// the nodes selected by a token are the nodes the next token is applied to
+			tokenTrace.Output = trace.Tokens[next].Input
+			tokenTrace.Duration -= trace.inclusive[next]
+		}
+		if tokenTrace.Duration < 0 {
+			tokenTrace.Duration = 0
+		}
+	}
+}
+
+// getTrace returns the trace of the context, or nil if the query is not being traced
+func getTrace(ctx context.Context) *Trace {
+	trace, _ := ctx.Value(traceKey{}).(*Trace)
+	return trace
+}
+
+// withoutTrace returns a context that does not record how tokens are applied,
+// used so the queries evaluated by expressions are not included in the trace.
+func withoutTrace(ctx context.Context) context.Context {
+	if getTrace(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, traceKey{}, nil)
+}
+
+// position returns the position of the first of the next tokens in the selector, or -1 if
+// the tokens are not part of the selector, such as those created by a script token.
+func (trace *Trace) position(next []Token) int {
+	if len(next) == 0 {
+		return -1
+	}
+	for idx := range trace.tokens {
+		if &trace.tokens[idx] == &next[0] {
+			return idx
+		}
+	}
+	return -1
+}
+
+// evaluated records the result of a filter evaluation against the value at the path
+func (trace *Trace) evaluated(token Token, path Path, value interface{}, included bool, err error) {
+	if trace == nil || trace.current >= len(trace.Tokens) {
+		return
+	}
+	tokenTrace := trace.Tokens[trace.current]
+	switch {
+	case err != nil:
+		tokenTrace.Failed++
+		if len(tokenTrace.Errors) < maxTraceErrors {
+			tokenTrace.Errors = append(tokenTrace.Errors, newQueryError(err, path.String(), token, value))
+		}
+	case included:
+		tokenTrace.Matched++
+	default:
+		tokenTrace.Rejected++
+	}
+}
+
+// traceNodes calls apply, which applies the first of the next tokens to the number of input nodes,
+// recording the input nodes and the time spent in the trace when the query is being traced.
+func traceNodes(ctx context.Context, next []Token, input int, apply func() ([]*Node, error)) ([]*Node, error) {
+	trace := getTrace(ctx)
+	if trace == nil {
+		return apply()
+	}
+	position := trace.position(next)
+	if position < 0 {
+		return apply()
+	}
+
+	previous := trace.current
+	trace.current = position
+	started := time.Now()
+
+	nodes, err := apply()
+
+	trace.inclusive[position] += time.Since(started)
+	trace.Tokens[position].Input += input
+	trace.current = previous
+	return nodes, err
+}
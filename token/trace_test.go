@@ -0,0 +1,204 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evilmonkeyinc/jsonpath/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// traceExpression is a compiled expression that evaluates using a function
+type traceExpression func(root, current interface{}) (interface{}, error)
+
+func (expression traceExpression) Evaluate(root, current interface{}) (interface{}, error) {
+	return expression(root, current)
+}
+
+// priceBelowTen is true for maps with a price below ten and fails for values without a price
+var priceBelowTen traceExpression = func(root, current interface{}) (interface{}, error) {
+	price, ok := current.(map[string]interface{})["price"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("price not found")
+	}
+	return price < 10, nil
+}
+
+func Test_NewTrace(t *testing.T) {
+	tokens := []Token{&rootToken{}, &keyToken{key: "book"}, &wildcardToken{}}
+	trace := NewTrace(tokens)
+	assert.Equal(t, []*TokenTrace{
+		{Token: "$", Type: "root"},
+		{Token: "['book']", Type: "key"},
+		{Token: "[*]", Type: "wildcard"},
+	}, trace.Tokens)
+	assert.Len(t, trace.inclusive, 3)
+}
+
+func Test_Trace_Finish(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		trace := NewTrace(nil)
+		trace.Finish(0, time.Second)
+		assert.Len(t, trace.Tokens, 0)
+	})
+	t.Run("tokens", func(t *testing.T) {
+		trace := NewTrace([]Token{&rootToken{}, &keyToken{key: "book"}, &wildcardToken{}})
+		trace.Tokens[1].Input = 1
+		trace.Tokens[2].Input = 1
+		trace.inclusive[1] = 8 * time.Millisecond
+		trace.inclusive[2] = 5 * time.Millisecond
+
+		trace.Finish(4, 10*time.Millisecond)
+
+		assert.Equal(t, 1, trace.Tokens[0].Input)
+		assert.Equal(t, 1, trace.Tokens[0].Output)
+		assert.Equal(t, 1, trace.Tokens[1].Output)
+		assert.Equal(t, 4, trace.Tokens[2].Output)
+		assert.Equal(t, 2*time.Millisecond, trace.Tokens[0].Duration)
+		assert.Equal(t, 3*time.Millisecond, trace.Tokens[1].Duration)
+		assert.Equal(t, 5*time.Millisecond, trace.Tokens[2].Duration)
+	})
+	t.Run("negative", func(t *testing.T) {
+		trace := NewTrace([]Token{&rootToken{}, &keyToken{key: "book"}})
+		trace.inclusive[1] = 5 * time.Millisecond
+
+		trace.Finish(0, time.Millisecond)
+		assert.Equal(t, time.Duration(0), trace.Tokens[0].Duration)
+	})
+}
+
+func Test_withoutTrace(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, withoutTrace(ctx))
+
+	traced := WithTrace(ctx, NewTrace(nil))
+	assert.NotNil(t, getTrace(traced))
+	assert.Nil(t, getTrace(withoutTrace(traced)))
+}
+
+func Test_Trace_position(t *testing.T) {
+	tokens := []Token{&rootToken{}, &wildcardToken{}, &wildcardToken{}}
+	trace := NewTrace(tokens)
+
+	assert.Equal(t, -1, trace.position(nil))
+	assert.Equal(t, 0, trace.position(tokens))
+	assert.Equal(t, 1, trace.position(tokens[1:]))
+	assert.Equal(t, 2, trace.position(tokens[2:]))
+	assert.Equal(t, -1, trace.position([]Token{tokens[2]}))
+}
+
+func Test_Trace_evaluated(t *testing.T) {
+	token := &filterToken{expression: "@.price < 10"}
+	trace := NewTrace([]Token{&rootToken{}, token})
+	trace.current = 1
+
+	trace.evaluated(token, Path{0}, true, true, nil)
+	trace.evaluated(token, Path{1}, false, false, nil)
+	for i := 2; i < 14; i++ {
+		trace.evaluated(token, Path{i}, "value", false, fmt.Errorf("failed"))
+	}
+
+	actual := trace.Tokens[1]
+	assert.Equal(t, 1, actual.Matched)
+	assert.Equal(t, 1, actual.Rejected)
+	assert.Equal(t, 12, actual.Failed)
+	assert.Len(t, actual.Errors, maxTraceErrors)
+
+	queryErr, ok := actual.Errors[0].(*errors.QueryError)
+	assert.True(t, ok)
+	assert.Equal(t, "$[2]", queryErr.Path)
+	assert.Equal(t, "[?(@.price < 10)]", queryErr.Token)
+	assert.EqualError(t, queryErr, "failed")
+
+	t.Run("nil", func(t *testing.T) {
+		var trace *Trace
+		trace.evaluated(token, Path{0}, true, true, nil)
+	})
+}
+
+func Test_Trace_applyNodes(t *testing.T) {
+	root := map[string]interface{}{
+		"book": []interface{}{
+			map[string]interface{}{"price": float64(8), "title": "one"},
+			map[string]interface{}{"price": float64(12), "title": "two"},
+			map[string]interface{}{"title": "three"},
+			map[string]interface{}{"price": float64(5), "title": "four"},
+		},
+	}
+
+	tests := []struct {
+		tokens   []Token
+		expected []*TokenTrace
+		results  int
+	}{
+		{
+			tokens: []Token{
+				&rootToken{},
+				&keyToken{key: "book"},
+				&filterToken{expression: "@.price < 10", compiledExpression: priceBelowTen},
+				&keyToken{key: "title"},
+			},
+			expected: []*TokenTrace{
+				{Token: "$", Type: "root", Input: 1, Output: 1},
+				{Token: "['book']", Type: "key", Input: 1, Output: 1},
+				{Token: "[?(@.price < 10)]", Type: "filter", Input: 1, Output: 2, Matched: 2, Rejected: 1, Failed: 1},
+				{Token: "['title']", Type: "key", Input: 2, Output: 2},
+			},
+			results: 2,
+		},
+		{
+			tokens: []Token{
+				&rootToken{},
+				&keyToken{key: "book"},
+				&filterToken{expression: "@.price < 10", compiledExpression: priceBelowTen},
+				&indexToken{index: -1},
+			},
+			expected: []*TokenTrace{
+				{Token: "$", Type: "root", Input: 1, Output: 1},
+				{Token: "['book']", Type: "key", Input: 1, Output: 1},
+				{Token: "[?(@.price < 10)]", Type: "filter", Input: 1, Output: 2, Matched: 2, Rejected: 1, Failed: 1},
+				{Token: "[-1]", Type: "index", Input: 2, Output: 1},
+			},
+			results: 1,
+		},
+		{
+			tokens: []Token{
+				&rootToken{},
+				&keyToken{key: "book"},
+				newSegmentToken([]Token{&filterToken{expression: "@.price < 10", compiledExpression: priceBelowTen}}, false, nil),
+				&keyToken{key: "missing"},
+			},
+			expected: []*TokenTrace{
+				{Token: "$", Type: "root", Input: 1, Output: 1},
+				{Token: "['book']", Type: "key", Input: 1, Output: 1},
+				{Token: "[?@.price < 10]", Type: "child", Input: 1, Output: 2, Matched: 2, Rejected: 1, Failed: 1},
+				{Token: "['missing']", Type: "key", Input: 2, Output: 0},
+			},
+			results: 0,
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			trace := NewTrace(test.tokens)
+			ctx := WithTrace(context.Background(), trace)
+
+			nodes, _ := test.tokens[0].ApplyNodes(ctx, root, &Node{Path: Path{}, Value: root}, test.tokens[1:])
+			assert.Len(t, nodes, test.results)
+			trace.Finish(len(nodes), time.Millisecond)
+
+			for _, tokenTrace := range trace.Tokens {
+				assert.GreaterOrEqual(t, tokenTrace.Duration, time.Duration(0))
+				tokenTrace.Duration = 0
+				if tokenTrace.Failed > 0 {
+					assert.Len(t, tokenTrace.Errors, tokenTrace.Failed)
+					assert.Equal(t, "$['book'][2]", tokenTrace.Errors[0].(*errors.QueryError).Path)
+				}
+				tokenTrace.Errors = nil
+			}
+			assert.Equal(t, test.expected, trace.Tokens)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package token
+
+import (
+	"github.com/evilmonkeyinc/jsonpath/ast"
+	"github.com/evilmonkeyinc/jsonpath/script"
+)
+
+// Tree returns the syntax tree of the query represented by the tokens
+func Tree(tokens []Token) *ast.Query {
+	query := &ast.Query{
+		Segments: make([]*ast.Segment, 0),
+	}
+	if len(tokens) == 0 {
+		return query
+	}
+	if _, ok := tokens[0].(*currentToken); ok {
+		query.Relative = true
+	}
+
+	descendant := false
+	for _, token := range tokens[1:] {
+		switch token := token.(type) {
+		case *recursiveToken:
+			if descendant {
+				query.Segments = append(query.Segments, &ast.Segment{Descendant: true})
+			}
+			descendant = true
+			continue
+		case *segmentToken:
+			selectors := make([]ast.Selector, 0, len(token.selectors))
+			for _, selector := range token.selectors {
+				selectors = append(selectors, getTreeSelectors(selector)...)
+			}
+			query.Segments = append(query.Segments, &ast.Segment{
+				Descendant: token.descendant,
+				Selectors:  selectors,
+			})
+		default:
+			query.Segments = append(query.Segments, &ast.Segment{
+				Descendant: descendant,
+				Selectors:  getTreeSelectors(token),
+			})
+		}
+		descendant = false
+	}
+	if descendant {
+		query.Segments = append(query.Segments, &ast.Segment{Descendant: true})
+	}
+	return query
+}
+
+// getTreeSelectors returns the selectors represented by the token
+func getTreeSelectors(token Token) []ast.Selector {
+	switch token := token.(type) {
+	case *keyToken:
+		return []ast.Selector{&ast.NameSelector{Name: token.key}}
+	case *indexToken:
+		return []ast.Selector{&ast.IndexSelector{Index: token.index}}
+	case *wildcardToken:
+		return []ast.Selector{&ast.WildcardSelector{}}
+	case *lengthToken:
+		return []ast.Selector{&ast.LengthSelector{}}
+	case *rangeToken:
+		return []ast.Selector{&ast.SliceSelector{
+			Start: getTreeArgument(token.from),
+			End:   getTreeArgument(token.to),
+			Step:  getTreeArgument(token.step),
+		}}
+	case *sliceToken:
+		return []ast.Selector{&ast.SliceSelector{
+			Start: getTreeArgument(token.start),
+			End:   getTreeArgument(token.end),
+			Step:  getTreeArgument(token.step),
+		}}
+	case *unionToken:
+		selectors := make([]ast.Selector, 0, len(token.arguments))
+		for _, argument := range token.arguments {
+			if key, ok := argument.(string); ok {
+				selectors = append(selectors, &ast.NameSelector{Name: key})
+			} else if index, ok := isInteger(argument); ok {
+				selectors = append(selectors, &ast.IndexSelector{Index: index})
+			} else if expression := getTreeArgument(argument); expression != nil {
+				selectors = append(selectors, &ast.ScriptSelector{Expression: expression})
+			}
+		}
+		return selectors
+	case *filterToken:
+		return []ast.Selector{&ast.FilterSelector{
+			Expression: getTreeExpression(token.expression, token.compiledExpression),
+		}}
+	case *scriptToken:
+		return []ast.Selector{&ast.ScriptSelector{
+			Expression: getTreeExpression(token.expression, token.compiledExpression),
+		}}
+	case *expressionToken:
+		return []ast.Selector{&ast.ScriptSelector{
+			Expression: getTreeExpression(token.expression, token.compiledExpression),
+		}}
+	}
+	return nil
+}
+
+// getTreeArgument returns the expression of a range or union argument, nil if the argument is not set
+func getTreeArgument(argument interface{}) ast.Expression {
+	switch argument := argument.(type) {
+	case nil:
+		return nil
+	case *int64:
+		if argument == nil {
+			return nil
+		}
+		return &ast.Literal{Value: *argument}
+	case *expressionToken:
+		return getTreeExpression(argument.expression, argument.compiledExpression)
+	}
+	if integer, ok := isInteger(argument); ok {
+		return &ast.Literal{Value: integer}
+	}
+	return nil
+}
+
+// getTreeExpression returns the syntax tree of the compiled expression,
+// or the raw expression if the script engine can not describe it
+func getTreeExpression(expression string, compiledExpression script.CompiledExpression) ast.Expression {
+	if tree, ok := compiledExpression.(script.CompiledExpressionTree); ok {
+		if expression := tree.Tree(); expression != nil {
+			return expression
+		}
+	}
+	return &ast.RawExpression{Source: expression}
+}
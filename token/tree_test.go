@@ -0,0 +1,88 @@
+package token
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTreeCompiledExpression struct {
+	testCompiledExpression
+	tree ast.Expression
+}
+
+func (expression *testTreeCompiledExpression) Tree() ast.Expression {
+	return expression.tree
+}
+
+func Test_Tree(t *testing.T) {
+
+	engine := &testEngine{compiledExpression: &testCompiledExpression{}}
+
+	tests := []struct {
+		selector string
+		rfc9535  bool
+		expected string
+	}{
+		{selector: "$", expected: "$"},
+		{selector: "@.a", expected: "@['a']"},
+		{selector: "$.store.book[0].author", expected: "$['store']['book'][0]['author']"},
+		{selector: "$..author", expected: "$..['author']"},
+		{selector: "$..*", expected: "$..[*]"},
+		{selector: "$..", expected: "$.."},
+		{selector: "$.a.length", expected: "$['a'].length"},
+		{selector: "$[1:2]", expected: "$[1:2]"},
+		{selector: "$[::2]", expected: "$[::2]"},
+		{selector: "$[:(@.length-1)]", expected: "$[:(@.length-1)]"},
+		{selector: "$['a',1,(@.b)]", expected: "$['a',1,(@.b)]"},
+		{selector: "$[?(@.a == 1)]", expected: "$[?(@.a == 1)]"},
+		{selector: "$[(@.length-1)]", expected: "$[(@.length-1)]"},
+		{selector: "$..['a','b']", rfc9535: true, expected: "$..['a','b']"},
+		{selector: "$[1:-1:2,0]", rfc9535: true, expected: "$[1:-1:2,0]"},
+		{selector: "$[?@.a]", rfc9535: true, expected: "$[?(@.a)]"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			var tokens []Token
+			var err error
+			if test.rfc9535 {
+				tokens, err = ParseRFC9535(test.selector, engine, nil)
+			} else {
+				tokens, err = ParseSelector(test.selector, engine, nil)
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, Tree(tokens).String())
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, &ast.Query{Segments: []*ast.Segment{}}, Tree(nil))
+	})
+
+	t.Run("nodes", func(t *testing.T) {
+		tokens, err := ParseSelector("$..a[1:]", engine, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, &ast.Query{
+			Segments: []*ast.Segment{
+				{Descendant: true, Selectors: []ast.Selector{&ast.NameSelector{Name: "a"}}},
+				{Selectors: []ast.Selector{&ast.SliceSelector{Start: &ast.Literal{Value: int64(1)}}}},
+			},
+		}, Tree(tokens))
+	})
+
+	t.Run("expression", func(t *testing.T) {
+		tree := &ast.QueryExpression{Query: &ast.Query{Relative: true}}
+		engine := &testEngine{compiledExpression: nil}
+		token, err := newFilterToken("@", engine, nil)
+		assert.Nil(t, err)
+		token.compiledExpression = &testTreeCompiledExpression{tree: tree}
+
+		assert.Equal(t, []ast.Selector{&ast.FilterSelector{Expression: tree}}, getTreeSelectors(token))
+
+		token.compiledExpression = &testTreeCompiledExpression{}
+		assert.Equal(t, []ast.Selector{&ast.FilterSelector{Expression: &ast.RawExpression{Source: "@"}}}, getTreeSelectors(token))
+	})
+}
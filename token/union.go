@@ -1,6 +1,7 @@
 package token
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sort"
@@ -13,12 +14,16 @@ func newUnionToken(arguments []interface{}, options *option.QueryOptions) *union
 	allowMap := false
 	allowString := false
 	failUnionOnInvalidIdentifier := false
+	leafToNull := false
+	requireProperties := false
 
 	if options != nil {
 		allowMap = options.AllowMapReferenceByIndex || options.AllowMapReferenceByIndexInUnion
 		allowString = options.AllowStringReferenceByIndex || options.AllowStringReferenceByIndexInUnion
 
 		failUnionOnInvalidIdentifier = options.FailUnionOnInvalidIdentifier
+		leafToNull = options.DefaultPathLeafToNull
+		requireProperties = options.RequireProperties
 	}
 
 	return &unionToken{
@@ -26,6 +31,9 @@ func newUnionToken(arguments []interface{}, options *option.QueryOptions) *union
 		allowMap:                     allowMap,
 		allowString:                  allowString,
 		failUnionOnInvalidIdentifier: failUnionOnInvalidIdentifier,
+		leafToNull:                   leafToNull,
+		requireProperties:            requireProperties,
+		matcher:                      newKeyMatcher(options),
 	}
 }
 
@@ -34,6 +42,12 @@ type unionToken struct {
 	allowMap                     bool
 	allowString                  bool
 	failUnionOnInvalidIdentifier bool
+	// leafToNull include nil for missing keys and indices at the end of the selector, and keep the nil results of the next tokens
+	leafToNull bool
+	// requireProperties fail on missing keys, and when a key that follows the union is missing
+	requireProperties bool
+	// matcher compares the keys of the union with the keys of maps and fields of structs
+	matcher keyMatcher
 }
 
 func (token *unionToken) String() string {
@@ -55,46 +69,90 @@ func (token *unionToken) Type() string {
 	return "union"
 }
 
-func (token *unionToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
+func (token *unionToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	keys, indices, err := token.parseArguments(ctx, root, current)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) > 0 {
+		return token.getUnionByKey(ctx, root, current, keys, next)
+	}
+	return token.getUnionByIndex(ctx, root, current, indices, next)
+}
+
+func (token *unionToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	keys, indices, err := token.parseArguments(ctx, root, current.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathKeys, values []interface{}
+	var missing map[int]bool
+	if len(keys) > 0 {
+		pathKeys, values, missing, err = token.getKeyElements(current.Value, keys, isLeaf(next))
+	} else {
+		pathKeys, values, missing, _, err = token.getIndexElements(current.Value, indices, isLeaf(next))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([]*Node, len(values))
+	for idx, value := range values {
+		elements[idx] = current.child(pathKeys[idx], value)
+		elements[idx].Missing = missing[idx]
+	}
+
+	if len(next) > 0 {
+		if indexToken, ok := next[0].(*indexToken); ok {
+			return traceNodes(ctx, next, len(elements), func() ([]*Node, error) {
+				return indexToken.applyToNodes(ctx, root, current.Path.String()+token.String(), elements, next[1:])
+			})
+		}
+	}
+
+	return collectElementNodes(ctx, root, elements, next, token.requireProperties)
+}
+
+// parseArguments returns the keys or indices requested by the union
+func (token *unionToken) parseArguments(ctx context.Context, root, current interface{}) ([]string, []int64, error) {
 	arguments := token.arguments
 	if len(arguments) == 0 {
-		return nil, getInvalidTokenArgumentNilError(token.Type(), reflect.Array, reflect.Slice)
+		return nil, nil, getInvalidTokenArgumentNilError(token.Type(), reflect.Array, reflect.Slice)
 	}
 
 	keys := make([]string, 0)
 	indices := make([]int64, 0)
 
 	for _, arg := range arguments {
-		argument, kind, err := token.parseArgument(root, current, arg)
+		argument, kind, err := token.parseArgument(ctx, root, current, arg)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		switch kind {
 		case reflect.String:
 			keys = append(keys, argument.(string))
 			if len(indices) > 0 {
-				return nil, getInvalidTokenArgumentError(token.Type(), reflect.String, reflect.Int)
+				return nil, nil, getInvalidTokenArgumentError(token.Type(), reflect.String, reflect.Int)
 			}
 			break
 		case reflect.Int64:
 			indices = append(indices, argument.(int64))
 			if len(keys) > 0 {
-				return nil, getInvalidTokenArgumentError(token.Type(), reflect.Int, reflect.String)
+				return nil, nil, getInvalidTokenArgumentError(token.Type(), reflect.Int, reflect.String)
 			}
 			break
 		}
 	}
 
-	if len(keys) > 0 {
-		return token.getUnionByKey(root, current, keys, next)
-	}
-	return token.getUnionByIndex(root, current, indices, next)
+	return keys, indices, nil
 }
 
-func (token *unionToken) parseArgument(root, current, argument interface{}) (interface{}, reflect.Kind, error) {
+func (token *unionToken) parseArgument(ctx context.Context, root, current, argument interface{}) (interface{}, reflect.Kind, error) {
 	if argToken, ok := argument.(Token); ok {
-		result, err := argToken.Apply(root, current, nil)
+		result, err := argToken.Apply(ctx, root, current, nil)
 		if err != nil {
 			return nil, reflect.Invalid, getInvalidTokenError(token.Type(), err)
 		}
@@ -114,19 +172,17 @@ func (token *unionToken) parseArgument(root, current, argument interface{}) (int
 	return nil, reflect.Invalid, getInvalidTokenArgumentError(token.Type(), argType.Kind(), reflect.Int, reflect.String)
 }
 
-func (token *unionToken) getUnionByKey(root, current interface{}, keys []string, next []Token) (interface{}, error) {
-	objType, objVal := getTypeAndValue(current)
-	if objType == nil {
-		return nil, getInvalidTokenTargetNilError(token.Type(), reflect.Map)
+func (token *unionToken) getUnionByKey(ctx context.Context, root, current interface{}, keys []string, next []Token) (interface{}, error) {
+	pathKeys, values, _, err := token.getKeyElements(current, keys, isLeaf(next))
+	if err != nil {
+		return nil, err
 	}
 
 	var nextToken Token
-	var futureTokens []Token
 	forEach := false
 
 	if len(next) > 0 {
 		nextToken = next[0]
-		futureTokens = next[1:]
 
 		if _, ok := nextToken.(*indexToken); !ok {
 			forEach = true
@@ -134,69 +190,143 @@ func (token *unionToken) getUnionByKey(root, current interface{}, keys []string,
 	}
 
 	elements := make([]interface{}, 0)
+	for idx, val := range values {
+		item, add, err := token.handleNext(ctx, root, pathKeys[idx], val, forEach, next)
+		if err != nil {
+			return nil, err
+		}
+		if add {
+			elements = append(elements, item)
+		}
+	}
 
-	switch objType.Kind() {
-	case reflect.Map:
-		mapKeys := objVal.MapKeys()
-		sortMapKeys(mapKeys)
+	if !forEach && nextToken != nil {
+		return applyNext(ctx, root, elements, next, token.String())
+	}
 
-		keysMap := make(map[string]reflect.Value)
-		for _, key := range mapKeys {
-			keysMap[key.String()] = key
+	return elements, nil
+}
+
+// getKeyElements returns the path elements and values of the requested keys, and the positions of missing keys,
+// missing keys have a nil value if the union is at the end of the selector and leafToNull is enabled.
+func (token *unionToken) getKeyElements(current interface{}, keys []string, leaf bool) ([]interface{}, []interface{}, map[int]bool, error) {
+	objType, objVal := getTypeAndValue(current)
+	if objType == nil {
+		return nil, nil, nil, getInvalidTokenTargetNilError(token.Type(), reflect.Map)
+	}
+
+	pathKeys := make([]interface{}, 0)
+	values := make([]interface{}, 0)
+	var missing map[int]bool
+
+	switch getKind(current, objType) {
+	case reflect.Map:
+		var keysMap map[string]interface{}
+		if object, ok := getOrderedObject(current); ok {
+			keysMap = ObjectValues(object)
+		} else {
+			keysMap = getMapValues(objVal)
 		}
 
 		missingKeys := make([]string, 0)
 
 		for _, requestedKey := range keys {
-			if key, ok := keysMap[requestedKey]; ok {
-				val := objVal.MapIndex(key).Interface()
-				if item, add := token.handleNext(root, val, forEach, nextToken, futureTokens); add {
-					elements = append(elements, item)
-				}
+			if key, ok := matchKey(token.matcher, requestedKey, keysMap); ok {
+				pathKeys = append(pathKeys, key)
+				values = append(values, keysMap[key])
+			} else if leaf && token.leafToNull {
+				missing = addMissing(missing, len(values))
+				pathKeys = append(pathKeys, requestedKey)
+				values = append(values, nil)
 			} else {
 				missingKeys = append(missingKeys, requestedKey)
 			}
 		}
 
-		if token.failUnionOnInvalidIdentifier && len(missingKeys) > 0 {
+		if (token.failUnionOnInvalidIdentifier || token.requireProperties) && len(missingKeys) > 0 {
 			sort.Strings(missingKeys)
-			return nil, getInvalidTokenKeyNotFoundError(token.Type(), strings.Join(missingKeys, ","))
+			return nil, nil, nil, getInvalidTokenKeyNotFoundError(token.Type(), strings.Join(missingKeys, ","))
 		}
 	case reflect.Struct:
 		keysMap := getStructFields(objVal, false)
 		missingKeys := make([]string, 0)
 
 		for _, requestedKey := range keys {
-			if field, ok := keysMap[requestedKey]; ok {
-				val := objVal.FieldByName(field.Name).Interface()
-				if item, add := token.handleNext(root, val, forEach, nextToken, futureTokens); add {
-					elements = append(elements, item)
-				}
+			if key, ok := matchKey(token.matcher, requestedKey, keysMap); ok {
+				pathKeys = append(pathKeys, key)
+				values = append(values, objVal.FieldByName(keysMap[key].Name).Interface())
+			} else if leaf && token.leafToNull {
+				missing = addMissing(missing, len(values))
+				pathKeys = append(pathKeys, requestedKey)
+				values = append(values, nil)
 			} else {
 				missingKeys = append(missingKeys, requestedKey)
 			}
 		}
 
-		if token.failUnionOnInvalidIdentifier && len(missingKeys) > 0 {
+		if (token.failUnionOnInvalidIdentifier || token.requireProperties) && len(missingKeys) > 0 {
 			sort.Strings(missingKeys)
-			return nil, getInvalidTokenKeyNotFoundError(token.Type(), strings.Join(missingKeys, ","))
+			return nil, nil, nil, getInvalidTokenKeyNotFoundError(token.Type(), strings.Join(missingKeys, ","))
 		}
 	default:
-		return nil, getInvalidTokenTargetError(
+		return nil, nil, nil, getInvalidTokenTargetError(
 			token.Type(),
 			objType.Kind(),
 			reflect.Map,
 		)
 	}
 
+	return pathKeys, values, missing, nil
+}
+
+func (token *unionToken) getUnionByIndex(ctx context.Context, root, current interface{}, indices []int64, next []Token) (interface{}, error) {
+	pathKeys, values, _, isString, err := token.getIndexElements(current, indices, isLeaf(next))
+	if err != nil {
+		return nil, err
+	}
+
+	var nextToken Token
+	forEach := false
+
+	if len(next) > 0 {
+		nextToken = next[0]
+
+		if _, ok := nextToken.(*indexToken); !ok {
+			forEach = true
+		}
+	}
+
+	if isString {
+		substring := ""
+		for _, value := range values {
+			substring += value.(string)
+		}
+		return applyNext(ctx, root, substring, next, token.String())
+	}
+
+	elements := make([]interface{}, 0)
+	for idx, val := range values {
+		item, add, err := token.handleNext(ctx, root, pathKeys[idx], val, forEach, next)
+		if err != nil {
+			return nil, err
+		}
+		if add {
+			elements = append(elements, item)
+		}
+	}
+
 	if !forEach && nextToken != nil {
-		return nextToken.Apply(root, elements, futureTokens)
+		return applyNext(ctx, root, elements, next, token.String())
 	}
 
 	return elements, nil
 }
 
-func (token *unionToken) getUnionByIndex(root, current interface{}, indices []int64, next []Token) (interface{}, error) {
+// getIndexElements returns the path elements and values of the requested indices, and the positions of missing indices,
+// indices that are out of range have a nil value if the union is at the end of the selector and leafToNull is enabled.
+//
+// if the current value is a string the values will be the individual characters of the substring.
+func (token *unionToken) getIndexElements(current interface{}, indices []int64, leaf bool) ([]interface{}, []interface{}, map[int]bool, bool, error) {
 	allowedType := []reflect.Kind{
 		reflect.Array,
 		reflect.Slice,
@@ -210,45 +340,32 @@ func (token *unionToken) getUnionByIndex(root, current interface{}, indices []in
 
 	objType, objVal := getTypeAndValue(current)
 	if objType == nil {
-		return nil, getInvalidTokenTargetNilError(
+		return nil, nil, nil, false, getInvalidTokenTargetNilError(
 			token.Type(),
 			allowedType...,
 		)
 	}
 
-	var nextToken Token
-	var futureTokens []Token
-	forEach := false
-
-	if len(next) > 0 {
-		nextToken = next[0]
-		futureTokens = next[1:]
-
-		if _, ok := nextToken.(*indexToken); !ok {
-			forEach = true
-		}
-	}
-
 	var length int64
-	var mapKeys []reflect.Value
+	var mapKeys []string
+	var mapValues []interface{}
 	isString := false
 
-	switch objType.Kind() {
+	switch getKind(current, objType) {
 	case reflect.Map:
 		if !token.allowMap {
-			return nil, getInvalidTokenTargetError(
+			return nil, nil, nil, false, getInvalidTokenTargetError(
 				token.Type(),
-				objType.Kind(),
+				reflect.Map,
 				allowedType...,
 			)
 		}
-		length = int64(objVal.Len())
-		mapKeys = objVal.MapKeys()
-		sortMapKeys(mapKeys)
+		mapKeys, mapValues = getMapElements(current, objVal)
+		length = int64(len(mapKeys))
 		break
 	case reflect.String:
 		if !token.allowString {
-			return nil, getInvalidTokenTargetError(
+			return nil, nil, nil, false, getInvalidTokenTargetError(
 				token.Type(),
 				objType.Kind(),
 				allowedType...,
@@ -263,70 +380,75 @@ func (token *unionToken) getUnionByIndex(root, current interface{}, indices []in
 		mapKeys = nil
 		break
 	default:
-		return nil, getInvalidTokenTargetError(
+		return nil, nil, nil, false, getInvalidTokenTargetError(
 			token.Type(),
 			objType.Kind(),
 			allowedType...,
 		)
 	}
 
+	pathKeys := make([]interface{}, 0)
 	values := make([]interface{}, 0)
-	substring := ""
+	var missing map[int]bool
 
 	for _, idx := range indices {
+		requested := idx
 		if idx < 0 {
 			idx = length + idx
 		}
 		if idx < 0 || idx >= length {
+			if leaf && token.leafToNull && !isString {
+				missing = addMissing(missing, len(values))
+				pathKeys = append(pathKeys, int(requested))
+				values = append(values, nil)
+				continue
+			}
 			if token.failUnionOnInvalidIdentifier {
-				return nil, getInvalidTokenOutOfRangeError(token.Type())
+				return nil, nil, nil, false, getInvalidTokenOutOfRangeError(token.Type())
 			}
 			continue
 		}
 
 		if mapKeys != nil {
-			key := mapKeys[idx]
-			val := objVal.MapIndex(key).Interface()
-			if item, add := token.handleNext(root, val, forEach, nextToken, futureTokens); add {
-				values = append(values, item)
-			}
+			pathKeys = append(pathKeys, mapKeys[idx])
+			values = append(values, mapValues[idx])
 		} else if isString {
 			value := objVal.Index(int(idx)).Interface()
 			if u, ok := value.(uint8); ok {
-				substring += fmt.Sprintf("%c", u)
+				pathKeys = append(pathKeys, int(idx))
+				values = append(values, fmt.Sprintf("%c", u))
 			}
 		} else {
-			val := objVal.Index(int(idx)).Interface()
-			if item, add := token.handleNext(root, val, forEach, nextToken, futureTokens); add {
-				values = append(values, item)
-			}
-		}
-	}
-
-	if isString {
-		if nextToken != nil {
-			return nextToken.Apply(root, substring, futureTokens)
+			pathKeys = append(pathKeys, int(idx))
+			values = append(values, objVal.Index(int(idx)).Interface())
 		}
-		return substring, nil
-	}
-
-	if !forEach && nextToken != nil {
-		return nextToken.Apply(root, values, futureTokens)
 	}
 
-	return values, nil
+	return pathKeys, values, missing, isString, nil
 }
 
-func (token *unionToken) handleNext(root, item interface{}, forEach bool, nextToken Token, futureTokens []Token) (interface{}, bool) {
+func (token *unionToken) handleNext(ctx context.Context, root, key, item interface{}, forEach bool, next []Token) (interface{}, bool, error) {
 	if !forEach {
-		return item, true
+		return item, true, nil
 	}
-	val, err := nextToken.Apply(root, item, futureTokens)
+	val, err := applyNext(ctx, root, item, next, getPathSegment(key))
 	if err != nil {
-		return nil, false
+		if token.requireProperties {
+			return nil, false, getRequiredError(err)
+		}
+		return nil, false, nil
 	}
-	if val == nil {
-		return nil, false
+	if val == nil && !token.leafToNull {
+		return nil, false, nil
+	}
+	return val, true, nil
+}
+
+// addMissing records the position of a missing key or index, creating the map if required
+func addMissing(missing map[int]bool, position int) map[int]bool {
+	if missing == nil {
+		missing = make(map[int]bool)
 	}
-	return val, true
+	missing[position] = true
+	return missing
 }
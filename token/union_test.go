@@ -1,10 +1,12 @@
 package token
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/ordered"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -115,6 +117,29 @@ func Test_newUnionToken(t *testing.T) {
 				allowString: true,
 			},
 		},
+		{
+			input: input{
+				options: &option.QueryOptions{
+					DefaultPathLeafToNull: true,
+					RequireProperties:     true,
+				},
+			},
+			expected: &unionToken{
+				leafToNull:        true,
+				requireProperties: true,
+			},
+		},
+		{
+			input: input{
+				options: &option.QueryOptions{
+					CaseInsensitiveKeys: true,
+					NormalizeKeys:       true,
+				},
+			},
+			expected: &unionToken{
+				matcher: keyMatcher{caseInsensitive: true, normalize: true},
+			},
+		},
 	}
 
 	for idx, test := range tests {
@@ -426,6 +451,59 @@ var unionTests = []*tokenTest{
 			},
 		},
 	},
+	{
+		token: &unionToken{arguments: []interface{}{"two", "three"}},
+		input: input{
+			current: ordered.NewObject(
+				ordered.Member{Key: "three", Value: 3},
+				ordered.Member{Key: "one", Value: 1},
+				ordered.Member{Key: "two", Value: 2},
+			),
+		},
+		expected: expected{
+			value: []interface{}{2, 3},
+		},
+	},
+	{
+		token: &unionToken{arguments: []interface{}{int64(0), int64(2)}, allowMap: true},
+		input: input{
+			current: ordered.NewObject(
+				ordered.Member{Key: "three", Value: 3},
+				ordered.Member{Key: "one", Value: 1},
+				ordered.Member{Key: "two", Value: 2},
+			),
+		},
+		expected: expected{
+			value: []interface{}{3, 2},
+		},
+	},
+	{
+		token: &unionToken{arguments: []interface{}{"key", "1"}},
+		input: input{
+			current: map[interface{}]interface{}{1: "one", "key": "value"},
+		},
+		expected: expected{
+			value: []interface{}{"value", "one"},
+		},
+	},
+	{
+		token: &unionToken{arguments: []interface{}{"10", "9"}},
+		input: input{
+			current: map[int]string{10: "ten", 9: "nine"},
+		},
+		expected: expected{
+			value: []interface{}{"ten", "nine"},
+		},
+	},
+	{
+		token: &unionToken{arguments: []interface{}{"1", "stringer-1"}},
+		input: input{
+			current: map[sampleStringerKey]string{1: "one"},
+		},
+		expected: expected{
+			value: []interface{}{"one"},
+		},
+	},
 }
 
 func Test_UnionToken_Apply(t *testing.T) {
@@ -714,11 +792,32 @@ func Test_UnionToken_getUnionByIndex(t *testing.T) {
 				obj: []interface{}{"1", "1"},
 			},
 		},
+		{
+			input: input{
+				token: &unionToken{leafToNull: true},
+				obj:   []interface{}{"one"},
+				keys:  []int64{0, 5},
+			},
+			expected: expected{
+				obj: []interface{}{"one", nil},
+			},
+		},
+		{
+			input: input{
+				token: &unionToken{leafToNull: true},
+				obj:   []interface{}{"one"},
+				keys:  []int64{0, 5},
+				next:  []Token{&keyToken{key: "key"}},
+			},
+			expected: expected{
+				obj: []interface{}{},
+			},
+		},
 	}
 
 	for idx, test := range tests {
 		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
-			obj, err := test.input.token.getUnionByIndex(nil, test.input.obj, test.input.keys, test.input.next)
+			obj, err := test.input.token.getUnionByIndex(context.Background(), nil, test.input.obj, test.input.keys, test.input.next)
 
 			if test.expected.obj == nil {
 				assert.Nil(t, obj)
@@ -993,11 +1092,55 @@ func Test_UnionToken_getUnionByKey(t *testing.T) {
 				obj: []interface{}{"1", "1", "1"},
 			},
 		},
+		{
+			input: input{
+				token: &unionToken{leafToNull: true},
+				obj:   map[string]interface{}{"a": "one"},
+				keys:  []string{"a", "b"},
+			},
+			expected: expected{
+				obj: []interface{}{"one", nil},
+			},
+		},
+		{
+			input: input{
+				token: &unionToken{requireProperties: true},
+				obj:   map[string]interface{}{"a": "one"},
+				keys:  []string{"a", "b"},
+			},
+			expected: expected{
+				err: "union: invalid token key 'b' not found",
+			},
+		},
+		{
+			input: input{
+				token: &unionToken{requireProperties: true},
+				obj: map[string]interface{}{
+					"a": map[string]interface{}{"key": "one"},
+					"b": map[string]interface{}{"other": "two"},
+				},
+				keys: []string{"a", "b"},
+				next: []Token{&keyToken{key: "key"}},
+			},
+			expected: expected{
+				err: "key: invalid token key 'key' not found",
+			},
+		},
+		{
+			input: input{
+				token: &unionToken{matcher: keyMatcher{caseInsensitive: true}},
+				obj:   map[string]interface{}{"userId": 1, "UserID": 2, "name": "one"},
+				keys:  []string{"USERID", "Name"},
+			},
+			expected: expected{
+				obj: []interface{}{2, "one"},
+			},
+		},
 	}
 
 	for idx, test := range tests {
 		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
-			obj, err := test.input.token.getUnionByKey(nil, test.input.obj, test.input.keys, test.input.next)
+			obj, err := test.input.token.getUnionByKey(context.Background(), nil, test.input.obj, test.input.keys, test.input.next)
 
 			if test.expected.obj == nil {
 				assert.Nil(t, obj)
@@ -1018,3 +1161,121 @@ func Test_UnionToken_getUnionByKey(t *testing.T) {
 		})
 	}
 }
+
+func Test_UnionToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &unionToken{},
+			input: nodesInput{
+				root: []interface{}{},
+			},
+			expected: nodesExpected{
+				err: "union: invalid token argument. expected [array slice] got [nil]",
+			},
+		},
+		{
+			token: &unionToken{arguments: []interface{}{int64(2), int64(0), int64(5)}},
+			input: nodesInput{
+				root: []interface{}{"one", "two", "three"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[2]", "$[0]"},
+				values: []interface{}{"three", "one"},
+			},
+		},
+		{
+			token: &unionToken{arguments: []interface{}{"b", "missing", "a"}},
+			input: nodesInput{
+				root: map[string]interface{}{"a": 1, "b": 2},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['b']", "$['a']"},
+				values: []interface{}{2, 1},
+			},
+		},
+		{
+			token: &unionToken{arguments: []interface{}{"two", "three"}},
+			input: nodesInput{
+				root: &sampleStruct{Two: "2", Four: 4},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['two']", "$['three']"},
+				values: []interface{}{"2", int64(4)},
+			},
+		},
+		{
+			token: &unionToken{arguments: []interface{}{int64(0), int64(2)}, allowString: true},
+			input: nodesInput{
+				root: "string",
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[0]", "$[2]"},
+				values: []interface{}{"s", "r"},
+			},
+		},
+		{
+			token: &unionToken{arguments: []interface{}{int64(0), int64(2)}},
+			input: nodesInput{
+				root: []interface{}{"one", "two", "three"},
+				tokens: []Token{
+					&indexToken{index: 1},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[2]"},
+				values: []interface{}{"three"},
+			},
+		},
+		{
+			token: &unionToken{arguments: []interface{}{"a", "b"}},
+			input: nodesInput{
+				root: map[string]interface{}{
+					"a": map[string]interface{}{"key": "one"},
+					"b": "two",
+				},
+				tokens: []Token{
+					&keyToken{key: "key"},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['a']['key']"},
+				values: []interface{}{"one"},
+			},
+		},
+		{
+			token: &unionToken{arguments: []interface{}{"ONE", "five"}, matcher: keyMatcher{caseInsensitive: true}},
+			input: nodesInput{
+				root: &sampleStruct{One: "1", Five: "5"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['one']", "$['Five']"},
+				values: []interface{}{"1", "5"},
+			},
+		},
+		{
+			token: &unionToken{arguments: []interface{}{"a", "missing"}, leafToNull: true},
+			input: nodesInput{
+				root: map[string]interface{}{"a": 1},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['a']", "$['missing']"},
+				values: []interface{}{1, nil},
+			},
+		},
+		{
+			token: &unionToken{arguments: []interface{}{"a", "b"}, requireProperties: true},
+			input: nodesInput{
+				root: map[string]interface{}{
+					"a": map[string]interface{}{"key": "one"},
+					"b": map[string]interface{}{"other": "two"},
+				},
+				tokens: []Token{
+					&keyToken{key: "key"},
+				},
+			},
+			expected: nodesExpected{
+				err: "key: invalid token key 'key' not found",
+			},
+		},
+	})
+}
@@ -0,0 +1,212 @@
+package token
+
+import (
+	"reflect"
+
+	"github.com/evilmonkeyinc/jsonpath/ordered"
+)
+
+// Update will replace the value at the path location within the root with the result of the update function.
+//
+// Maps, slices, and values referenced by pointers are modified in place, arrays and structs that can not be
+// addressed are copied, so the returned root should be used in place of the original.
+func (path Path) Update(root interface{}, update func(value interface{}) interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return update(root), nil
+	}
+
+	updated, err := modifyValue(reflect.ValueOf(root), Path{}, path, func(current reflect.Value, location Path) (reflect.Value, error) {
+		var value interface{}
+		if current.IsValid() && current.CanInterface() {
+			value = current.Interface()
+		}
+		return reflect.ValueOf(update(value)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated.Interface(), nil
+}
+
+// modifyValue will navigate to the remaining path from the current value and replace
+// the value found there with the value returned by the modify function.
+func modifyValue(current reflect.Value, location, remaining Path, modify func(current reflect.Value, location Path) (reflect.Value, error)) (reflect.Value, error) {
+	if len(remaining) == 0 {
+		return modify(current, location)
+	}
+
+	element := remaining[0]
+	childLocation := location.child(element)
+
+	if object, ok := getOrderedObjectValue(current); ok {
+		name, ok := element.(string)
+		if !ok {
+			return reflect.Value{}, getInvalidPathTargetError(location, reflect.Map)
+		}
+		value, ok := object.Get(name)
+		if !ok {
+			return reflect.Value{}, getInvalidPathKeyNotFoundError(location, name)
+		}
+
+		updated, err := modifyValue(reflect.ValueOf(value), childLocation, remaining[1:], modify)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value = nil
+		if updated.IsValid() {
+			value = updated.Interface()
+		}
+		object.Set(name, value)
+		return current, nil
+	}
+
+	switch current.Kind() {
+	case reflect.Interface:
+		if current.IsNil() {
+			return reflect.Value{}, getInvalidPathTargetError(location, reflect.Invalid)
+		}
+		return modifyValue(current.Elem(), location, remaining, modify)
+	case reflect.Ptr:
+		if current.IsNil() {
+			return reflect.Value{}, getInvalidPathTargetError(location, reflect.Invalid)
+		}
+		target := current.Elem()
+		updated, err := modifyValue(target, location, remaining, modify)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if err := assignValue(target, updated, location); err != nil {
+			return reflect.Value{}, err
+		}
+		return current, nil
+	case reflect.Map:
+		name, ok := element.(string)
+		if !ok {
+			return reflect.Value{}, getInvalidPathTargetError(location, current.Kind())
+		}
+		key, ok := findMapKey(current, name)
+		if !ok {
+			return reflect.Value{}, getInvalidPathKeyNotFoundError(location, name)
+		}
+
+		updated, err := modifyValue(current.MapIndex(key), childLocation, remaining[1:], modify)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		converted, err := convertValue(updated, current.Type().Elem(), childLocation)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		current.SetMapIndex(key, converted)
+		return current, nil
+	case reflect.Array, reflect.Slice:
+		index, ok := element.(int)
+		if !ok {
+			return reflect.Value{}, getInvalidPathTargetError(location, current.Kind())
+		}
+		if index < 0 || index >= current.Len() {
+			return reflect.Value{}, getInvalidPathOutOfRangeError(location, index)
+		}
+		if current.Kind() == reflect.Array && !current.CanSet() {
+			current = copyValue(current)
+		}
+
+		target := current.Index(index)
+		updated, err := modifyValue(target, childLocation, remaining[1:], modify)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if err := assignValue(target, updated, childLocation); err != nil {
+			return reflect.Value{}, err
+		}
+		return current, nil
+	case reflect.Struct:
+		name, ok := element.(string)
+		if !ok {
+			return reflect.Value{}, getInvalidPathTargetError(location, current.Kind())
+		}
+		if !current.CanSet() {
+			current = copyValue(current)
+		}
+
+		field, ok := getStructFields(current, false)[name]
+		if !ok {
+			return reflect.Value{}, getInvalidPathKeyNotFoundError(location, name)
+		}
+		target := current.FieldByName(field.Name)
+		if !target.CanSet() {
+			return reflect.Value{}, getInvalidPathNotSettableError(childLocation)
+		}
+
+		updated, err := modifyValue(target, childLocation, remaining[1:], modify)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if err := assignValue(target, updated, childLocation); err != nil {
+			return reflect.Value{}, err
+		}
+		return current, nil
+	default:
+		return reflect.Value{}, getInvalidPathTargetError(location, current.Kind())
+	}
+}
+
+func assignValue(target, value reflect.Value, location Path) error {
+	converted, err := convertValue(value, target.Type(), location)
+	if err != nil {
+		return err
+	}
+	target.Set(converted)
+	return nil
+}
+
+// convertValue returns the value as the target type, numbers are converted
+// between types as long as the conversion does not lose any precision.
+func convertValue(value reflect.Value, targetType reflect.Type, location Path) (reflect.Value, error) {
+	if !value.IsValid() {
+		switch targetType.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return reflect.Zero(targetType), nil
+		}
+		return reflect.Value{}, getInvalidPathValueError(location, nil, targetType)
+	}
+
+	if value.Type().AssignableTo(targetType) {
+		return value, nil
+	}
+
+	if isNumberKind(value.Kind()) && isNumberKind(targetType.Kind()) {
+		converted := value.Convert(targetType)
+		if converted.Convert(value.Type()).Interface() == value.Interface() {
+			return converted, nil
+		}
+	}
+
+	return reflect.Value{}, getInvalidPathValueError(location, value.Type(), targetType)
+}
+
+func copyValue(value reflect.Value) reflect.Value {
+	copied := reflect.New(value.Type()).Elem()
+	copied.Set(value)
+	return copied
+}
+
+// getOrderedObjectValue returns the value as an ordered object, which is modified using its methods
+// as its members can not be set using reflection
+func getOrderedObjectValue(current reflect.Value) (*ordered.Object, bool) {
+	if current.Kind() != reflect.Ptr || current.IsNil() || !current.CanInterface() {
+		return nil, false
+	}
+	object, ok := current.Interface().(*ordered.Object)
+	return object, ok
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,316 @@
+package token
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/evilmonkeyinc/jsonpath/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+type updateStruct struct {
+	Name     string                 `json:"name"`
+	Count    int                    `json:"count"`
+	Values   []interface{}          `json:"values"`
+	Nested   *updateStruct          `json:"nested"`
+	Any      interface{}            `json:"any"`
+	Map      map[string]interface{} `json:"map"`
+	Array    [2]string              `json:"array"`
+	internal string
+}
+
+func Test_Path_Update(t *testing.T) {
+
+	replace := func(value interface{}) func(interface{}) interface{} {
+		return func(interface{}) interface{} {
+			return value
+		}
+	}
+
+	type input struct {
+		path   Path
+		root   interface{}
+		update func(interface{}) interface{}
+	}
+
+	type expected struct {
+		value interface{}
+		err   string
+	}
+
+	tests := []struct {
+		input    input
+		expected expected
+	}{
+		{
+			input: input{
+				path:   Path{},
+				root:   "old",
+				update: replace("new"),
+			},
+			expected: expected{
+				value: "new",
+			},
+		},
+		{
+			input: input{
+				path: Path{"key"},
+				root: map[string]interface{}{"key": float64(1), "other": "value"},
+				update: func(value interface{}) interface{} {
+					return value.(float64) + 1
+				},
+			},
+			expected: expected{
+				value: map[string]interface{}{"key": float64(2), "other": "value"},
+			},
+		},
+		{
+			input: input{
+				path: Path{"key", 1, "nested"},
+				root: map[string]interface{}{
+					"key": []interface{}{
+						"zero",
+						map[string]interface{}{"nested": "old"},
+					},
+				},
+				update: replace("new"),
+			},
+			expected: expected{
+				value: map[string]interface{}{
+					"key": []interface{}{
+						"zero",
+						map[string]interface{}{"nested": "new"},
+					},
+				},
+			},
+		},
+		{
+			input: input{
+				path:   Path{"missing"},
+				root:   map[string]interface{}{},
+				update: replace("new"),
+			},
+			expected: expected{
+				err: "invalid path $. key 'missing' not found",
+			},
+		},
+		{
+			input: input{
+				path:   Path{"key", 3},
+				root:   map[string]interface{}{"key": []interface{}{1}},
+				update: replace("new"),
+			},
+			expected: expected{
+				err: "invalid path $['key']. index 3 out of range",
+			},
+		},
+		{
+			input: input{
+				path:   Path{"key", 0},
+				root:   map[string]interface{}{"key": "string"},
+				update: replace("new"),
+			},
+			expected: expected{
+				err: "invalid path $['key']. unexpected target [string]",
+			},
+		},
+		{
+			input: input{
+				path:   Path{"key", "nested"},
+				root:   map[string]interface{}{"key": nil},
+				update: replace("new"),
+			},
+			expected: expected{
+				err: "invalid path $['key']. unexpected target [invalid]",
+			},
+		},
+		{
+			input: input{
+				path:   Path{0},
+				root:   map[string]interface{}{"0": "old"},
+				update: replace("new"),
+			},
+			expected: expected{
+				err: "invalid path $. unexpected target [map]",
+			},
+		},
+		{
+			input: input{
+				path:   Path{"key"},
+				root:   map[string]int{"key": 1},
+				update: replace("new"),
+			},
+			expected: expected{
+				err: "invalid path $['key']. can not use value of type [string] as [int]",
+			},
+		},
+		{
+			input: input{
+				path:   Path{"key"},
+				root:   map[string]int{"key": 1},
+				update: replace(float64(5)),
+			},
+			expected: expected{
+				value: map[string]int{"key": 5},
+			},
+		},
+		{
+			input: input{
+				path:   Path{"key"},
+				root:   map[string]int{"key": 1},
+				update: replace(5.5),
+			},
+			expected: expected{
+				err: "invalid path $['key']. can not use value of type [float64] as [int]",
+			},
+		},
+		{
+			input: input{
+				path:   Path{"key"},
+				root:   map[string]int{"key": 1},
+				update: replace(nil),
+			},
+			expected: expected{
+				err: "invalid path $['key']. can not use value of type [nil] as [int]",
+			},
+		},
+		{
+			input: input{
+				path:   Path{"name"},
+				root:   updateStruct{Name: "old"},
+				update: replace("new"),
+			},
+			expected: expected{
+				value: updateStruct{Name: "new"},
+			},
+		},
+		{
+			input: input{
+				path:   Path{"nested", "count"},
+				root:   &updateStruct{Nested: &updateStruct{Count: 1}},
+				update: replace(2),
+			},
+			expected: expected{
+				value: &updateStruct{Nested: &updateStruct{Count: 2}},
+			},
+		},
+		{
+			input: input{
+				path:   Path{"any", "name"},
+				root:   &updateStruct{Any: updateStruct{Name: "old"}},
+				update: replace("new"),
+			},
+			expected: expected{
+				value: &updateStruct{Any: updateStruct{Name: "new"}},
+			},
+		},
+		{
+			input: input{
+				path:   Path{"array", 1},
+				root:   updateStruct{Array: [2]string{"one", "two"}},
+				update: replace("new"),
+			},
+			expected: expected{
+				value: updateStruct{Array: [2]string{"one", "new"}},
+			},
+		},
+		{
+			input: input{
+				path:   Path{1},
+				root:   [2]string{"one", "two"},
+				update: replace("new"),
+			},
+			expected: expected{
+				value: [2]string{"one", "new"},
+			},
+		},
+		{
+			input: input{
+				path:   Path{"nested", "name"},
+				root:   &updateStruct{},
+				update: replace("new"),
+			},
+			expected: expected{
+				err: "invalid path $['nested']. unexpected target [invalid]",
+			},
+		},
+		{
+			input: input{
+				path:   Path{"internal"},
+				root:   &updateStruct{},
+				update: replace("new"),
+			},
+			expected: expected{
+				err: "invalid path $. key 'internal' not found",
+			},
+		},
+		{
+			input: input{
+				path:   Path{"missing"},
+				root:   &updateStruct{},
+				update: replace("new"),
+			},
+			expected: expected{
+				err: "invalid path $. key 'missing' not found",
+			},
+		},
+		{
+			input: input{
+				path: Path{"b", "nested"},
+				root: ordered.NewObject(
+					ordered.Member{Key: "b", Value: ordered.NewObject(ordered.Member{Key: "nested", Value: "old"})},
+					ordered.Member{Key: "a", Value: 1},
+				),
+				update: replace("new"),
+			},
+			expected: expected{
+				value: ordered.NewObject(
+					ordered.Member{Key: "b", Value: ordered.NewObject(ordered.Member{Key: "nested", Value: "new"})},
+					ordered.Member{Key: "a", Value: 1},
+				),
+			},
+		},
+		{
+			input: input{
+				path:   Path{"missing"},
+				root:   ordered.NewObject(ordered.Member{Key: "a", Value: 1}),
+				update: replace("new"),
+			},
+			expected: expected{
+				err: "invalid path $. key 'missing' not found",
+			},
+		},
+		{
+			input: input{
+				path:   Path{0},
+				root:   ordered.NewObject(ordered.Member{Key: "a", Value: 1}),
+				update: replace("new"),
+			},
+			expected: expected{
+				err: "invalid path $. unexpected target [map]",
+			},
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			actual, err := test.input.path.Update(test.input.root, test.input.update)
+			if test.expected.err != "" {
+				assert.EqualError(t, err, test.expected.err)
+				assert.Nil(t, actual)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected.value, actual)
+		})
+	}
+
+	t.Run("in place", func(t *testing.T) {
+		root := map[string]interface{}{
+			"key": []interface{}{"one", "two"},
+		}
+		_, err := Path{"key", 0}.Update(root, replace("new"))
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"new", "two"}, root["key"])
+	})
+}
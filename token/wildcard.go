@@ -1,14 +1,26 @@
 package token
 
 import (
+	"context"
 	"reflect"
+
+	"github.com/evilmonkeyinc/jsonpath/option"
 )
 
-func newWildcardToken() *wildcardToken {
-	return &wildcardToken{}
+func newWildcardToken(options *option.QueryOptions) *wildcardToken {
+	token := &wildcardToken{}
+	if options != nil {
+		token.leafToNull = options.DefaultPathLeafToNull
+		token.requireProperties = options.RequireProperties
+	}
+	return token
 }
 
 type wildcardToken struct {
+	// leafToNull keep the nil results of the next tokens
+	leafToNull bool
+	// requireProperties fail when a key that follows the wildcard is missing
+	requireProperties bool
 }
 
 func (token *wildcardToken) String() string {
@@ -19,72 +31,100 @@ func (token *wildcardToken) Type() string {
 	return "wildcard"
 }
 
-func (token *wildcardToken) Apply(root, current interface{}, next []Token) (interface{}, error) {
+func (token *wildcardToken) Apply(ctx context.Context, root, current interface{}, next []Token) (interface{}, error) {
+	keys, values, err := token.getChildren(current)
+	if err != nil {
+		return nil, err
+	}
 
 	elements := make([]interface{}, 0)
+	for idx, value := range values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		item, add, err := token.handleNext(ctx, root, keys[idx], value, next)
+		if err != nil {
+			return nil, err
+		}
+		if add {
+			elements = append(elements, item)
+		}
+	}
+
+	return elements, nil
+}
 
-	var nextToken Token
-	var futureTokens []Token
+func (token *wildcardToken) ApplyNodes(ctx context.Context, root interface{}, current *Node, next []Token) ([]*Node, error) {
+	keys, values, err := token.getChildren(current.Value)
+	if err != nil {
+		return nil, err
+	}
 
-	if len(next) > 0 {
-		nextToken = next[0]
-		futureTokens = next[1:]
+	elements := make([]*Node, len(values))
+	for idx, value := range values {
+		elements[idx] = current.child(keys[idx], value)
 	}
+	return collectElementNodes(ctx, root, elements, next, token.requireProperties)
+}
 
+// getChildren returns the path elements and values of all child members
+func (token *wildcardToken) getChildren(current interface{}) ([]interface{}, []interface{}, error) {
 	objType, objVal := getTypeAndValue(current)
 	if objType == nil {
-		return nil, getInvalidTokenTargetNilError(
+		return nil, nil, getInvalidTokenTargetNilError(
 			token.Type(),
 			reflect.Array, reflect.Map, reflect.Slice,
 		)
 	}
 
-	switch objType.Kind() {
+	keys := make([]interface{}, 0)
+	values := make([]interface{}, 0)
+
+	switch getKind(current, objType) {
 	case reflect.Map:
-		keys := objVal.MapKeys()
-		sortMapKeys(keys)
-		for _, kv := range keys {
-			value := objVal.MapIndex(kv).Interface()
-			if item, add := token.handleNext(root, value, nextToken, futureTokens); add {
-				elements = append(elements, item)
-			}
+		mapKeys, mapValues := getMapElements(current, objVal)
+		for idx, key := range mapKeys {
+			keys = append(keys, key)
+			values = append(values, mapValues[idx])
 		}
 		break
 	case reflect.Array, reflect.Slice:
 		length := objVal.Len()
 		for i := 0; i < length; i++ {
-			value := objVal.Index(i).Interface()
-			if item, add := token.handleNext(root, value, nextToken, futureTokens); add {
-				elements = append(elements, item)
-			}
+			keys = append(keys, i)
+			values = append(values, objVal.Index(i).Interface())
 		}
 	case reflect.Struct:
 		fields := getStructFields(objVal, true)
-		for _, field := range fields {
-			value := objVal.FieldByName(field.Name).Interface()
-			if item, add := token.handleNext(root, value, nextToken, futureTokens); add {
-				elements = append(elements, item)
-			}
+		for _, name := range getStructFieldNames(fields) {
+			keys = append(keys, name)
+			values = append(values, objVal.FieldByName(fields[name].Name).Interface())
 		}
 		break
 	default:
-		return nil, getInvalidTokenTargetError(
+		return nil, nil, getInvalidTokenTargetError(
 			token.Type(),
 			objType.Kind(),
 			reflect.Array, reflect.Map, reflect.Slice,
 		)
 	}
 
-	return elements, nil
+	return keys, values, nil
 }
 
-func (token *wildcardToken) handleNext(root, item interface{}, nextToken Token, futureTokens []Token) (interface{}, bool) {
-	if nextToken == nil {
-		return item, true
+func (token *wildcardToken) handleNext(ctx context.Context, root, key, item interface{}, next []Token) (interface{}, bool, error) {
+	if len(next) == 0 {
+		return item, true, nil
+	}
+	result, err := applyNext(ctx, root, item, next, getPathSegment(key))
+	if err != nil {
+		if token.requireProperties {
+			return nil, false, getRequiredError(err)
+		}
+		return nil, false, nil
 	}
-	result, _ := nextToken.Apply(root, item, futureTokens)
-	if result == nil {
-		return nil, false
+	if result == nil && !token.leafToNull {
+		return nil, false, nil
 	}
-	return result, true
+	return result, true, nil
 }
@@ -3,6 +3,8 @@ package token
 import (
 	"testing"
 
+	"github.com/evilmonkeyinc/jsonpath/option"
+	"github.com/evilmonkeyinc/jsonpath/ordered"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -10,7 +12,12 @@ import (
 var _ Token = &wildcardToken{}
 
 func Test_newWildcardToken(t *testing.T) {
-	assert.IsType(t, &wildcardToken{}, newWildcardToken())
+	assert.IsType(t, &wildcardToken{}, newWildcardToken(nil))
+	assert.Equal(t, &wildcardToken{}, newWildcardToken(&option.QueryOptions{}))
+	assert.Equal(t, &wildcardToken{leafToNull: true, requireProperties: true}, newWildcardToken(&option.QueryOptions{
+		DefaultPathLeafToNull: true,
+		RequireProperties:     true,
+	}))
 }
 
 func Test_WildcardToken_String(t *testing.T) {
@@ -215,6 +222,91 @@ var wildcardTests = []*tokenTest{
 			value: []interface{}{"1", "2", "3"},
 		},
 	},
+	{
+		token: &wildcardToken{leafToNull: true},
+		input: input{
+			current: []interface{}{
+				map[string]interface{}{"key": "one"},
+				map[string]interface{}{"other": "two"},
+			},
+			tokens: []Token{
+				&keyToken{key: "key", leafToNull: true},
+			},
+		},
+		expected: expected{
+			value: []interface{}{"one", nil},
+		},
+	},
+	{
+		token: &wildcardToken{requireProperties: true},
+		input: input{
+			current: []interface{}{
+				map[string]interface{}{"key": "one"},
+				map[string]interface{}{"other": "two"},
+			},
+			tokens: []Token{
+				&keyToken{key: "key"},
+			},
+		},
+		expected: expected{
+			err: "key: invalid token key 'key' not found",
+		},
+	},
+	{
+		token: &wildcardToken{requireProperties: true},
+		input: input{
+			current: []interface{}{
+				map[string]interface{}{"key": "one"},
+				"two",
+			},
+			tokens: []Token{
+				&keyToken{key: "key"},
+			},
+		},
+		expected: expected{
+			value: []interface{}{"one"},
+		},
+	},
+	{
+		token: &wildcardToken{},
+		input: input{
+			current: ordered.NewObject(
+				ordered.Member{Key: "three", Value: 3},
+				ordered.Member{Key: "one", Value: 1},
+				ordered.Member{Key: "two", Value: 2},
+			),
+		},
+		expected: expected{
+			value: []interface{}{3, 1, 2},
+		},
+	},
+	{
+		token: &wildcardToken{},
+		input: input{
+			current: map[interface{}]interface{}{"b": "two", 1: "one", "c": "three"},
+		},
+		expected: expected{
+			value: []interface{}{"one", "two", "three"},
+		},
+	},
+	{
+		token: &wildcardToken{},
+		input: input{
+			current: map[int]string{10: "ten", 9: "nine", 100: "hundred"},
+		},
+		expected: expected{
+			value: []interface{}{"nine", "ten", "hundred"},
+		},
+	},
+	{
+		token: &wildcardToken{},
+		input: input{
+			current: map[sampleTextKey]string{{id: 2}: "two", {id: 1}: "one"},
+		},
+		expected: expected{
+			value: []interface{}{"one", "two"},
+		},
+	},
 }
 
 func Test_WildcardToken_Apply(t *testing.T) {
@@ -224,3 +316,125 @@ func Test_WildcardToken_Apply(t *testing.T) {
 func Benchmark_WildcardToken_Apply(b *testing.B) {
 	batchTokenBenchmarks(b, wildcardTests)
 }
+
+func Test_WildcardToken_ApplyNodes(t *testing.T) {
+	batchTokenNodesTests(t, []*tokenNodesTest{
+		{
+			token: &wildcardToken{},
+			input: nodesInput{
+				root: "not array or map",
+			},
+			expected: nodesExpected{
+				err: "wildcard: invalid token target. expected [array map slice] got [string]",
+			},
+		},
+		{
+			token: &wildcardToken{},
+			input: nodesInput{
+				root: []interface{}{"one", nil},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[0]", "$[1]"},
+				values: []interface{}{"one", nil},
+			},
+		},
+		{
+			token: &wildcardToken{},
+			input: nodesInput{
+				root: map[string]interface{}{"b": 2, "a": 1},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['a']", "$['b']"},
+				values: []interface{}{1, 2},
+			},
+		},
+		{
+			token: &wildcardToken{},
+			input: nodesInput{
+				root: []interface{}{
+					map[string]interface{}{"key": "one"},
+					map[string]interface{}{"other": "two"},
+					[]interface{}{"three"},
+					map[string]interface{}{"key": "four"},
+				},
+				tokens: []Token{
+					&keyToken{key: "key"},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[0]['key']", "$[3]['key']"},
+				values: []interface{}{"one", "four"},
+			},
+		},
+		{
+			token: &wildcardToken{},
+			input: nodesInput{
+				root: []interface{}{
+					[]interface{}{"one", "two"},
+					[]interface{}{"three"},
+				},
+				tokens: []Token{
+					&wildcardToken{},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[0][0]", "$[0][1]", "$[1][0]"},
+				values: []interface{}{"one", "two", "three"},
+			},
+		},
+		{
+			token: &wildcardToken{leafToNull: true},
+			input: nodesInput{
+				root: []interface{}{
+					map[string]interface{}{"key": "one"},
+					map[string]interface{}{"other": "two"},
+				},
+				tokens: []Token{
+					&keyToken{key: "key", leafToNull: true},
+				},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$[0]['key']", "$[1]['key']"},
+				values: []interface{}{"one", nil},
+			},
+		},
+		{
+			token: &wildcardToken{requireProperties: true},
+			input: nodesInput{
+				root: []interface{}{
+					map[string]interface{}{"key": "one"},
+					map[string]interface{}{"other": "two"},
+				},
+				tokens: []Token{
+					&keyToken{key: "key"},
+				},
+			},
+			expected: nodesExpected{
+				err: "key: invalid token key 'key' not found",
+			},
+		},
+		{
+			token: &wildcardToken{},
+			input: nodesInput{
+				root: ordered.NewObject(
+					ordered.Member{Key: "b", Value: "one"},
+					ordered.Member{Key: "a", Value: "two"},
+				),
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['b']", "$['a']"},
+				values: []interface{}{"one", "two"},
+			},
+		},
+		{
+			token: &wildcardToken{},
+			input: nodesInput{
+				root: map[int]string{10: "ten", 9: "nine"},
+			},
+			expected: nodesExpected{
+				paths:  []string{"$['9']", "$['10']"},
+				values: []interface{}{"nine", "ten"},
+			},
+		},
+	})
+}
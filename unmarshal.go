@@ -0,0 +1,254 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// bindings caches the binding of each struct type, so the selectors of its fields are only compiled once
+var bindings sync.Map
+
+// binding of a struct type, the fields that are set using the selectors of their jsonpath struct tags
+type binding struct {
+	fields   []*fieldBinding
+	complete bool
+}
+
+// fieldBinding of a struct field, fields without a selector are untagged structs whose own fields are bound
+type fieldBinding struct {
+	name     string
+	index    int
+	selector *Selector
+	required bool
+	binding  *binding
+}
+
+// Unmarshal will parse the JSON data and store the values selected by the jsonpath struct tags of v, which must be a non-nil pointer to a struct.
+//
+// Each tag is the JSONPath selector of the value of the field, optionally followed by the required flag, such as `jsonpath:"$.store.book[0].title,required"`.
+// The selected value is stored in the field in the same way as the Decode function of the Selector. Fields with a selector that does not match a value are
+// left unchanged, unless they are required in which case an error is returned. Fields with a struct type, or a slice, array, or pointer of a struct type,
+// that has its own jsonpath tags are bound to the selected value, so the selectors of the nested struct are applied to it in place of the document.
+// Untagged fields with a struct type are bound to the same document as the struct that includes them.
+func Unmarshal(data []byte, v interface{}) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return getInvalidUnmarshalTargetError(reflect.TypeOf(v))
+	}
+
+	binding, err := getBinding(target.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	var root interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return getInvalidJSONData(err)
+	}
+	return binding.bind(root, target.Elem(), "")
+}
+
+// getBinding returns the binding of the struct type, compiling the selectors of its fields if it is not cached
+func getBinding(structType reflect.Type) (*binding, error) {
+	if cached, ok := bindings.Load(structType); ok {
+		return cached.(*binding), nil
+	}
+
+	building := make(map[reflect.Type]*binding)
+	structBinding, err := buildBinding(structType, building)
+	if err != nil {
+		return nil, err
+	}
+	for bindingType, built := range building {
+		bindings.Store(bindingType, built)
+	}
+	return structBinding, nil
+}
+
+// buildBinding returns the binding of the struct type, the bindings of the struct types of its fields
+// are added to building so that struct types that reference themselves are only built once.
+func buildBinding(structType reflect.Type, building map[reflect.Type]*binding) (*binding, error) {
+	if cached, ok := bindings.Load(structType); ok {
+		return cached.(*binding), nil
+	}
+	if structBinding, ok := building[structType]; ok {
+		return structBinding, nil
+	}
+
+	structBinding := &binding{fields: make([]*fieldBinding, 0)}
+	building[structType] = structBinding
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		tag, tagged := field.Tag.Lookup("jsonpath")
+		if tag == "-" {
+			// explicitly told to skip
+			continue
+		}
+		if !tagged {
+			if field.Type.Kind() != reflect.Struct || (!field.Anonymous && !field.IsExported()) {
+				continue
+			}
+			nested, err := buildBinding(field.Type, building)
+			if err != nil {
+				return nil, err
+			}
+			if len(nested.fields) > 0 {
+				structBinding.fields = append(structBinding.fields, &fieldBinding{
+					name:    field.Name,
+					index:   i,
+					binding: nested,
+				})
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		selector, required := parseBindingTag(tag)
+		compiled, err := Compile(selector)
+		if err != nil {
+			name := field.Name
+			if structType.Name() != "" {
+				name = structType.Name() + "." + name
+			}
+			return nil, getUnmarshalFieldError(name, err)
+		}
+
+		nested, err := getElementBinding(field.Type, building)
+		if err != nil {
+			return nil, err
+		}
+
+		structBinding.fields = append(structBinding.fields, &fieldBinding{
+			name:     field.Name,
+			index:    i,
+			selector: compiled,
+			required: required,
+			binding:  nested,
+		})
+	}
+
+	structBinding.complete = true
+	return structBinding, nil
+}
+
+// getElementBinding returns the binding of the struct type of a field, or the struct elements of a pointer, slice, or array field,
+// nil is returned if the field is not a struct or the struct has no fields to bind.
+func getElementBinding(fieldType reflect.Type, building map[reflect.Type]*binding) (*binding, error) {
+	for fieldType.Kind() == reflect.Ptr || isListType(fieldType) {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	nested, err := buildBinding(fieldType, building)
+	if err != nil {
+		return nil, err
+	}
+	// a binding that is not complete is still being built, and will include the field that references it
+	if nested.complete && len(nested.fields) == 0 {
+		return nil, nil
+	}
+	return nested, nil
+}
+
+// parseBindingTag returns the selector of the jsonpath struct tag, and if the tag includes the required flag.
+// Selectors can include commas so only a known flag at the end of the tag is removed from the selector.
+func parseBindingTag(tag string) (string, bool) {
+	if idx := strings.LastIndex(tag, ","); idx > -1 && strings.TrimSpace(tag[idx+1:]) == "required" {
+		return strings.TrimSpace(tag[:idx]), true
+	}
+	return strings.TrimSpace(tag), false
+}
+
+// bind sets the fields of the struct value using the values selected from root
+func (structBinding *binding) bind(root interface{}, value reflect.Value, prefix string) error {
+	for _, field := range structBinding.fields {
+		fieldValue := value.Field(field.index)
+		name := prefix + field.name
+
+		if field.selector == nil {
+			if err := field.binding.bind(root, fieldValue, name+"."); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, err := field.selector.Query(root)
+		if err == nil && field.selector.isEmptyResult(result) {
+			err = getDecodeResultNotFoundError(field.selector.selector)
+		}
+		if err != nil {
+			if field.required {
+				return getUnmarshalFieldError(name, err)
+			}
+			continue
+		}
+
+		if field.binding == nil {
+			if err := field.selector.decode(result, fieldValue.Addr().Interface()); err != nil {
+				return getUnmarshalFieldError(name, err)
+			}
+			continue
+		}
+
+		result, err = field.selector.shapeResult(result, fieldValue.Type())
+		if err != nil {
+			return getUnmarshalFieldError(name, err)
+		}
+		if err := field.bindValue(result, fieldValue, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindValue binds the struct, or the struct elements of the pointer, slice, or array value, to the selected result
+func (field *fieldBinding) bindValue(result interface{}, value reflect.Value, name string) error {
+	if result == nil {
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return field.bindValue(result, value.Elem(), name)
+	case reflect.Struct:
+		resultValue := reflect.ValueOf(result)
+		if kind := resultValue.Kind(); kind != reflect.Map && kind != reflect.Struct {
+			return getUnmarshalFieldError(name, getDecodeResultKindError(field.selector.selector, value.Type(), kind))
+		}
+		return field.binding.bind(result, value, name+".")
+	case reflect.Slice, reflect.Array:
+		resultValue := reflect.ValueOf(result)
+		if kind := resultValue.Kind(); kind != reflect.Slice && kind != reflect.Array {
+			return getUnmarshalFieldError(name, getDecodeResultSingleValueError(field.selector.selector, value.Type(), kind))
+		}
+
+		length := resultValue.Len()
+		if value.Kind() == reflect.Slice {
+			value.Set(reflect.MakeSlice(value.Type(), length, length))
+		} else {
+			value.Set(reflect.Zero(value.Type()))
+			if length > value.Len() {
+				length = value.Len()
+			}
+		}
+		for idx := 0; idx < length; idx++ {
+			if err := field.bindValue(resultValue.Index(idx).Interface(), value.Index(idx), fmt.Sprintf("%s[%d]", name, idx)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
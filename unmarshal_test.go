@@ -0,0 +1,219 @@
+package jsonpath
+
+import (
+	goErr "errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/evilmonkeyinc/jsonpath/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type unmarshalBicycle struct {
+	Color string  `jsonpath:"$.color"`
+	Price float64 `jsonpath:"$.price"`
+}
+
+type unmarshalBook struct {
+	Title  string   `jsonpath:"$.title,required"`
+	Author string   `jsonpath:"$.author"`
+	ISBN   *string  `jsonpath:"$.isbn"`
+	Tags   []string `jsonpath:"$.tags[*]"`
+}
+
+type unmarshalSummary struct {
+	Count int `jsonpath:"$.store.book.length"`
+}
+
+type unmarshalStore struct {
+	unmarshalSummary
+	First     string            `jsonpath:"$.store.book[0].title"`
+	Authors   []string          `jsonpath:"$..author"`
+	Cheap     []unmarshalBook   `jsonpath:"$.store.book[?(@.price < 10)]"`
+	Books     [2]*unmarshalBook `jsonpath:"$.store.book"`
+	Bicycle   unmarshalBicycle  `jsonpath:"$.store.bicycle"`
+	Published time.Time         `jsonpath:"$.store.book[0].published"`
+	Missing   string            `jsonpath:"$.store.missing"`
+	Skipped   string            `jsonpath:"-"`
+	Details   struct {
+		Expensive int `jsonpath:"$.expensive"`
+	}
+	ignored string `jsonpath:"$.store.book[0].title"`
+}
+
+type unmarshalCategory struct {
+	Name     string              `jsonpath:"$.name"`
+	Children []unmarshalCategory `jsonpath:"$.children[*]"`
+}
+
+const unmarshalData = `{
+	"store": {
+		"book": [
+			{"author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95, "published": "1951-06-01T00:00:00Z", "tags": ["quotes"]},
+			{"author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+			{"author": "Herman Melville", "title": "Moby Dick", "price": 8.99, "isbn": "0-553-21311-3"}
+		],
+		"bicycle": {"color": "red", "price": 19.95}
+	},
+	"expensive": 10
+}`
+
+func Test_Unmarshal(t *testing.T) {
+	isbn := "0-553-21311-3"
+
+	t.Run("store", func(t *testing.T) {
+		store := unmarshalStore{Missing: "unchanged", Skipped: "unchanged"}
+		err := Unmarshal([]byte(unmarshalData), &store)
+		assert.Nil(t, err)
+
+		assert.Equal(t, unmarshalStore{
+			unmarshalSummary: unmarshalSummary{Count: 3},
+			First:            "Sayings of the Century",
+			Authors:          []string{"Nigel Rees", "Evelyn Waugh", "Herman Melville"},
+			Cheap: []unmarshalBook{
+				{Title: "Sayings of the Century", Author: "Nigel Rees", Tags: []string{"quotes"}},
+				{Title: "Moby Dick", Author: "Herman Melville", ISBN: &isbn},
+			},
+			Books: [2]*unmarshalBook{
+				{Title: "Sayings of the Century", Author: "Nigel Rees", Tags: []string{"quotes"}},
+				{Title: "Sword of Honour", Author: "Evelyn Waugh"},
+			},
+			Bicycle:   unmarshalBicycle{Color: "red", Price: 19.95},
+			Published: time.Date(1951, 6, 1, 0, 0, 0, 0, time.UTC),
+			Missing:   "unchanged",
+			Skipped:   "unchanged",
+			Details: struct {
+				Expensive int `jsonpath:"$.expensive"`
+			}{Expensive: 10},
+		}, store)
+	})
+	t.Run("recursive", func(t *testing.T) {
+		category := unmarshalCategory{}
+		err := Unmarshal([]byte(`{"name": "a", "children": [{"name": "b", "children": [{"name": "c"}]}]}`), &category)
+		assert.Nil(t, err)
+		assert.Equal(t, unmarshalCategory{Name: "a", Children: []unmarshalCategory{
+			{Name: "b", Children: []unmarshalCategory{{Name: "c"}}},
+		}}, category)
+	})
+	t.Run("cached", func(t *testing.T) {
+		first, err := getBinding(reflect.TypeOf(unmarshalStore{}))
+		assert.Nil(t, err)
+		second, err := getBinding(reflect.TypeOf(unmarshalStore{}))
+		assert.Nil(t, err)
+		assert.Same(t, first, second)
+
+		nested, _ := bindings.Load(reflect.TypeOf(unmarshalBook{}))
+		assert.Same(t, first.fields[3].binding, nested)
+	})
+
+	tests := []struct {
+		data     string
+		target   interface{}
+		expected string
+	}{
+		{
+			data:     `{"title": "Moby Dick"}`,
+			target:   unmarshalBook{},
+			expected: "invalid decode target. expected non-nil pointer to struct got [jsonpath.unmarshalBook]",
+		},
+		{
+			data:     `{"title": "Moby Dick"}`,
+			target:   new(string),
+			expected: "invalid decode target. expected non-nil pointer to struct got [*string]",
+		},
+		{
+			data:     `{"title": "Moby Dick"`,
+			target:   &unmarshalBook{},
+			expected: "invalid data. unexpected end of JSON input",
+		},
+		{
+			data:     `{"author": "Herman Melville"}`,
+			target:   &unmarshalBook{},
+			expected: "unable to unmarshal field 'Title'. key: invalid token key 'title' not found",
+		},
+		{
+			data:     `{"title": 1}`,
+			target:   &unmarshalBook{},
+			expected: "unable to unmarshal field 'Title'. unable to decode result of selector '$.title' into [string]. json: cannot unmarshal number into Go value of type string",
+		},
+		{
+			data: `{"book": [{"author": "Herman Melville"}]}`,
+			target: &struct {
+				Books []unmarshalBook `jsonpath:"$.book[*]"`
+			}{},
+			expected: "unable to unmarshal field 'Books[0].Title'. key: invalid token key 'title' not found",
+		},
+		{
+			data: `{"book": [{"title": "Moby Dick"}]}`,
+			target: &struct {
+				Book unmarshalBook `jsonpath:"$.book[*]"`
+			}{},
+			expected: "unable to unmarshal field 'Book'. unable to decode result of selector '$.book[*]' into [jsonpath.unmarshalBook]. selector can match more than one value and returns a list",
+		},
+		{
+			data: `{"book": "Moby Dick"}`,
+			target: &struct {
+				Book unmarshalBook `jsonpath:"$.book"`
+			}{},
+			expected: "unable to unmarshal field 'Book'. unable to decode result of selector '$.book' into [jsonpath.unmarshalBook]. unexpected value of kind [string]",
+		},
+		{
+			data: `{"book": "Moby Dick"}`,
+			target: &struct {
+				Books []unmarshalBook `jsonpath:"$.book"`
+			}{},
+			expected: "unable to unmarshal field 'Books'. unable to decode result of selector '$.book' into [[]jsonpath.unmarshalBook]. selector returns a single value of kind [string]",
+		},
+		{
+			data: `{"book": []}`,
+			target: &struct {
+				Books []unmarshalBook `jsonpath:"$.book[*],required"`
+			}{},
+			expected: "unable to unmarshal field 'Books'. unable to decode result of selector '$.book[*]'. selector did not match a value",
+		},
+		{
+			data: `{}`,
+			target: &struct {
+				Title string `jsonpath:"$.book["`
+			}{},
+			expected: "unable to unmarshal field 'Title'. invalid JSONPath selector '$.book[' invalid token. '[' does not match any token format",
+		},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			err := Unmarshal([]byte(test.data), test.target)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+
+	t.Run("error type", func(t *testing.T) {
+		err := Unmarshal([]byte(`{}`), &unmarshalBook{})
+		assert.True(t, goErr.Is(err, errors.ErrUnmarshalField))
+	})
+}
+
+func Test_parseBindingTag(t *testing.T) {
+	tests := []struct {
+		input    string
+		selector string
+		required bool
+	}{
+		{input: "$.title", selector: "$.title"},
+		{input: "$.title,required", selector: "$.title", required: true},
+		{input: "$.title, required", selector: "$.title", required: true},
+		{input: "$.book[0,1]", selector: "$.book[0,1]"},
+		{input: "$.book[0,1],required", selector: "$.book[0,1]", required: true},
+		{input: "$['a,required']", selector: "$['a,required']"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			selector, required := parseBindingTag(test.input)
+			assert.Equal(t, test.selector, selector)
+			assert.Equal(t, test.required, required)
+		})
+	}
+}